@@ -3,10 +3,11 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -14,8 +15,13 @@ import (
 )
 
 var (
-	port    int
-	verbose bool
+	port        int
+	verbose     bool
+	logFile     string
+	logLevel    string
+	logMaxSize  int64
+	logMaxAge   time.Duration
+	logMaxFiles int
 )
 
 func main() {
@@ -27,7 +33,12 @@ func main() {
 	}
 
 	rootCmd.Flags().IntVarP(&port, "port", "p", 51052, "port to listen on")
-	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "verbose logging")
+	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "verbose logging (same as --log-level debug)")
+	rootCmd.Flags().StringVar(&logFile, "log-file", "", "write JSON logs to this file instead of stderr")
+	rootCmd.Flags().StringVar(&logLevel, "log-level", "info", "minimum log level (debug, info, warn, error)")
+	rootCmd.Flags().Int64Var(&logMaxSize, "log-max-size", 10*1024*1024, "rotate the log file after this many bytes (0 disables)")
+	rootCmd.Flags().DurationVar(&logMaxAge, "log-max-age", 24*time.Hour, "rotate the log file after this long (0 disables)")
+	rootCmd.Flags().IntVar(&logMaxFiles, "log-max-files", 5, "number of rotated log files to keep (0 keeps all)")
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -38,8 +49,29 @@ func main() {
 func runServer(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
+	level, err := hostbridge.ParseLogLevel(logLevel)
+	if err != nil {
+		return err
+	}
+	if verbose && !cmd.Flags().Changed("log-level") {
+		level = slog.LevelDebug
+	}
+
+	logger, closer, err := hostbridge.NewLogger(hostbridge.LogOptions{
+		File:     logFile,
+		Level:    level,
+		MaxSize:  logMaxSize,
+		MaxAge:   logMaxAge,
+		MaxFiles: logMaxFiles,
+	})
+	if err != nil {
+		return err
+	}
+	defer closer.Close()
+	slog.SetDefault(logger)
+
 	// Create gRPC hostbridge service
-	service := hostbridge.NewGrpcServer(port, verbose)
+	service := hostbridge.NewGrpcServer(port, logger)
 
 	// Handle graceful shutdown
 	ctx, cancel := context.WithCancel(ctx)
@@ -50,17 +82,13 @@ func runServer(cmd *cobra.Command, args []string) error {
 		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 		<-sigChan
 
-		if verbose {
-			log.Println("Shutting down hostbridge server...")
-		}
+		logger.Info("shutting down hostbridge server")
 
 		cancel()
 	}()
 
 	// Start server
-	if verbose {
-		log.Printf("Starting Clica Host Bridge on port %d", port)
-	}
+	logger.Info("starting Clica Host Bridge", "port", port)
 
 	// Run the service
 	if err := service.Start(ctx); err != nil {
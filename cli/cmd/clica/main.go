@@ -3,17 +3,21 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
 	"github.com/charmbracelet/huh"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/clica/cli/pkg/cli"
 	"github.com/clica/cli/pkg/cli/auth"
+	"github.com/clica/cli/pkg/cli/config"
 	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/handlers"
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/clica/cli/pkg/cli/theme"
 	"github.com/clica/cli/pkg/common"
 	"github.com/clica/grpc-go/clica"
 	"github.com/spf13/cobra"
@@ -21,8 +25,13 @@ import (
 
 var (
 	coreAddress  string
+	instanceRef  string
 	verbose      bool
 	outputFormat string
+	screenshots  string
+	themeRef     string
+	recordPath   string
+	accessible   bool
 
 	// Task creation flags (for root command)
 	images   []string
@@ -31,6 +40,9 @@ var (
 	settings []string
 	yolo     bool
 	oneshot  bool
+	profile  string
+	detach   bool
+	tui      bool
 )
 
 func main() {
@@ -49,6 +61,23 @@ Or pipe a prompt via stdin:
 Or run with no arguments to enter interactive mode:
   clica
 
+Leave a task running in the background and come back to it later:
+  clica --detach "Refactor the parser"
+  clica ps
+  clica attach <task-id>
+Press Ctrl+\ in an interactive session to detach from it.
+
+Follow a task in a full-screen interface with a scrollable conversation,
+a tool panel and a status bar:
+  clica --tui "Add pagination to the API"
+
+Record a session and replay it later without an instance:
+  clica --record session.clrec "Add pagination to the API"
+  clica replay session.clrec --speed 4x
+
+Use plain text output for screen readers, with y/n approvals instead of menus:
+  clica --accessible "Add pagination to the API"
+
 This CLI also provides task management, configuration, and monitoring capabilities.
 
 For detailed documentation including all commands, options, and examples,
@@ -59,43 +88,115 @@ see the manual page: man clica`,
 				return fmt.Errorf("invalid output format '%s': must be one of 'rich', 'json', or 'plain'", outputFormat)
 			}
 
-			return global.InitializeGlobalConfig(&global.GlobalConfig{
-				Verbose:      verbose,
-				OutputFormat: outputFormat,
-				CoreAddress:  coreAddress,
-			})
+			// Rich output is drawn with box characters and redrawn while streaming, screen readers get plain text
+			accessible = accessible || os.Getenv("ACCESSIBLE") != ""
+			if accessible && outputFormat == "rich" {
+				outputFormat = "plain"
+			}
+
+			if err := global.InitializeGlobalConfig(&global.GlobalConfig{
+				Verbose:       verbose,
+				OutputFormat:  outputFormat,
+				CoreAddress:   coreAddress,
+				Instance:      instanceRef,
+				ScreenshotDir: screenshots,
+				Accessible:    accessible,
+			}); err != nil {
+				return err
+			}
+
+			selected, err := config.LoadTheme(themeRef)
+			if err != nil {
+				return err
+			}
+			theme.Set(selected)
+			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
+			if recordPath != "" {
+				if err := task.StartRecording(recordPath); err != nil {
+					return err
+				}
+				defer task.StopRecording()
+			}
+
+			if tui && global.Config.Accessible {
+				return fmt.Errorf("--tui can't be used with --accessible")
+			}
+
 			var instanceAddress string
 
-			// If --address flag not provided, start instance BEFORE getting prompt
-			if !cmd.Flags().Changed("address") {
-				if global.Config.Verbose {
-					fmt.Println("Starting new Clica instance...")
-				}
-				instance, err := global.Clients.StartNewInstance(ctx)
-				if err != nil {
-					return fmt.Errorf("failed to start new instance: %w", err)
-				}
-				instanceAddress = instance.Address
-				if global.Config.Verbose {
-					fmt.Printf("Started instance at %s\n\n", instanceAddress)
+			// Without --address or --instance, reuse the instance whose workspace contains the current directory
+			explicitInstance := cmd.Flags().Changed("address") || cmd.Flags().Changed("instance")
+			workspaceInstance := ""
+			if !explicitInstance {
+				workspaceInstance = global.WorkspaceInstance()
+			}
+
+			// Pool instances are recycled rather than returned if the task fails or a profile or the
+			// auth wizard changed them. Return also recycles them if the session changed their settings.
+			recycle := profile != ""
+			// A detached task keeps running, so its instance must outlive this command
+			detached := false
+
+			// If no instance was selected, claim a pool instance or start one BEFORE getting prompt
+			if !explicitInstance && workspaceInstance == "" {
+				lease, err := global.ClaimWorkspacePoolInstance(ctx)
+				if err != nil && global.Config.Verbose {
+					fmt.Printf("Warning: failed to claim pool instance: %v\n", err)
 				}
 
-				// Set up cleanup on exit
-				defer func() {
+				if lease != nil {
+					instanceAddress = lease.Instance.Address
+					if global.Config.Verbose {
+						fmt.Printf("Claimed pool instance at %s\n\n", instanceAddress)
+					}
+
+					defer func() {
+						if detached {
+							lease.Detach()
+							return
+						}
+						lease.Return(context.Background(), recycle)
+					}()
+				} else {
 					if global.Config.Verbose {
-						fmt.Println("\nCleaning up instance...")
+						fmt.Println("Starting new Clica instance...")
 					}
-					registry := global.Clients.GetRegistry()
-					if err := global.KillInstanceByAddress(context.Background(), registry, instanceAddress); err != nil {
+					instance, err := global.Clients.StartNewInstance(ctx)
+					if err != nil {
+						return fmt.Errorf("failed to start new instance: %w", err)
+					}
+					instanceAddress = instance.Address
+					if global.Config.Verbose {
+						fmt.Printf("Started instance at %s\n\n", instanceAddress)
+					}
+
+					// Set up cleanup on exit
+					defer func() {
+						if detached {
+							return
+						}
 						if global.Config.Verbose {
-							fmt.Printf("Warning: Failed to clean up instance: %v\n", err)
+							fmt.Println("\nCleaning up instance...")
+						}
+						registry := global.Clients.GetRegistry()
+						if err := global.KillInstanceByAddress(context.Background(), registry, instanceAddress); err != nil {
+							if global.Config.Verbose {
+								fmt.Printf("Warning: Failed to clean up instance: %v\n", err)
+							}
 						}
+					}()
+				}
+
+				// Apply the named profile before checking credentials, it may provide them
+				if profile != "" {
+					if err := cli.ApplyProfileToInstance(ctx, profile, instanceAddress); err != nil {
+						return err
 					}
-				}()
+				}
 
 				// Check if user has credentials configured
 				if !isUserReadyToUse(ctx, instanceAddress) {
@@ -111,6 +212,9 @@ see the manual page: man clica`,
 						return fmt.Errorf("auth setup failed: %w", err)
 					}
 
+					// Credentials entered here belong to this session, not to the next claimer
+					recycle = true
+
 					// Re-check after auth wizard
 					if !isUserReadyToUse(ctx, instanceAddress) {
 						return fmt.Errorf("credentials still not configured - please run 'clica auth' to complete setup")
@@ -119,8 +223,36 @@ see the manual page: man clica`,
 					fmt.Printf("\n%s\n\n", renderer.Dim("✓ Setup complete, you can now use the Clica CLI"))
 				}
 			} else {
-				// User specified --address flag, use that
-				instanceAddress = coreAddress
+				// User specified --address or --instance, or the workspace has an instance, use that
+				switch {
+				case cmd.Flags().Changed("instance"):
+					resolved, err := global.ResolveInstanceAddress(instanceRef)
+					if err != nil {
+						return err
+					}
+					instanceAddress = resolved
+				case explicitInstance:
+					resolved, err := global.ResolveInstanceAddress(coreAddress)
+					if err != nil {
+						return err
+					}
+					instanceAddress = resolved
+				default:
+					instanceAddress = workspaceInstance
+				}
+
+				if profile != "" {
+					if err := cli.ApplyProfileToInstance(ctx, profile, instanceAddress); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Fall back to the mode from env or .clica.yaml when --mode isn't given
+			if !cmd.Flags().Changed("mode") {
+				if configuredMode := cli.ConfiguredMode(settings); configuredMode != "" {
+					mode = configuredMode
+				}
 			}
 
 			// Get content from both args and stdin
@@ -129,8 +261,9 @@ see the manual page: man clica`,
 				return fmt.Errorf("failed to read prompt: %w", err)
 			}
 
-			// If no prompt from args or stdin, show interactive input
-			if prompt == "" {
+			// If no prompt from args or stdin, show interactive input.
+			// The TUI asks for the first message itself.
+			if prompt == "" && !tui {
 				// Pass the mode flag to banner so it shows correct mode
 				prompt, err = promptForInitialTask(ctx, instanceAddress, mode)
 				if err != nil {
@@ -151,7 +284,7 @@ see the manual page: man clica`,
 				yolo = true
 			}
 
-			return cli.CreateAndFollowTask(ctx, prompt, cli.TaskOptions{
+			err = cli.CreateAndFollowTask(ctx, prompt, cli.TaskOptions{
 				Images:   images,
 				Files:    files,
 				Mode:     mode,
@@ -159,32 +292,61 @@ see the manual page: man clica`,
 				Yolo:     yolo,
 				Address:  instanceAddress,
 				Verbose:  verbose,
+				Detach:   detach,
+				TUI:      tui,
 			})
+			if errors.Is(err, task.ErrDetached) {
+				detached = true
+				return nil
+			}
+			if err != nil {
+				recycle = true
+			}
+			return err
 		},
 	}
 
 	rootCmd.PersistentFlags().StringVar(&coreAddress, "address", fmt.Sprintf("localhost:%d", common.DEFAULT_CLICA_CORE_PORT), "Clica Core gRPC address")
+	rootCmd.PersistentFlags().StringVar(&instanceRef, "instance", "", "Clica instance name (or address) to use")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output-format", "F", "rich", "output format (rich|json|plain)")
+	rootCmd.PersistentFlags().StringVar(&themeRef, "theme", "", fmt.Sprintf("color theme (%s) or a theme file; NO_COLOR turns colors off", strings.Join(theme.Names(), "|")))
+	rootCmd.PersistentFlags().BoolVar(&accessible, "accessible", false, "screen reader friendly output: plain text with role labels, no animation, and y/n approvals (or set ACCESSIBLE)")
+	rootCmd.PersistentFlags().StringVar(&screenshots, "save-screenshots", "", "save browser screenshots and MCP images as numbered PNG files in this directory")
 
 	// Task creation flags (only apply when using root command with prompt)
 	rootCmd.Flags().StringSliceVarP(&images, "image", "i", nil, "attach image files")
 	rootCmd.Flags().StringSliceVarP(&files, "file", "f", nil, "attach files")
+	rootCmd.Flags().StringVar(&recordPath, "record", "", cli.RecordFlagUsage)
 	rootCmd.Flags().StringVarP(&mode, "mode", "m", "plan", "mode (act|plan) - defaults to plan")
 	rootCmd.Flags().StringSliceVarP(&settings, "setting", "s", nil, "task settings (key=value format)")
 	rootCmd.Flags().BoolVarP(&yolo, "yolo", "y", false, "enable yolo mode (non-interactive)")
 	rootCmd.Flags().BoolVar(&yolo, "no-interactive", false, "enable yolo mode (non-interactive)")
 	rootCmd.Flags().BoolVarP(&oneshot, "oneshot", "o", false, "full autonomous mode")
+	rootCmd.Flags().StringVar(&profile, "profile", "", "apply a saved configuration profile to the instance")
+	rootCmd.Flags().BoolVar(&detach, "detach", false, "start the task and leave it running; reattach with 'clica attach'")
+	rootCmd.Flags().BoolVar(&tui, "tui", false, "follow the task in a full-screen interface")
 
 	rootCmd.AddCommand(cli.NewTaskCommand())
 	rootCmd.AddCommand(cli.NewInstanceCommand())
 	rootCmd.AddCommand(cli.NewConfigCommand())
 	rootCmd.AddCommand(cli.NewVersionCommand())
 	rootCmd.AddCommand(cli.NewAuthCommand())
+	rootCmd.AddCommand(cli.NewModelCommand())
+	rootCmd.AddCommand(cli.NewProfileCommand())
 	rootCmd.AddCommand(cli.NewLogsCommand())
 	rootCmd.AddCommand(cli.NewDoctorCommand())
-
-	if err := rootCmd.ExecuteContext(context.Background()); err != nil {
+	rootCmd.AddCommand(cli.NewDaemonCommand())
+	rootCmd.AddCommand(cli.NewPoolCommand())
+	rootCmd.AddCommand(cli.NewPsCommand())
+	rootCmd.AddCommand(cli.NewAttachCommand())
+	rootCmd.AddCommand(cli.NewBatchCommand())
+	rootCmd.AddCommand(cli.NewReplayCommand())
+
+	err := rootCmd.ExecuteContext(context.Background())
+	// Message handlers may still be passing the last messages on
+	handlers.WaitForBackground()
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -196,16 +358,13 @@ func promptForInitialTask(ctx context.Context, instanceAddress, modeFlag string)
 	var prompt string
 
 	// Create custom theme with mode-colored cursor and title
-	theme := huh.ThemeCharm()
+	formTheme := huh.ThemeCharm()
 
 	// Set cursor and title color based on mode
-	modeColor := lipgloss.Color("3") // Yellow for plan
-	if modeFlag == "act" {
-		modeColor = lipgloss.Color("39") // Blue for act
-	}
+	modeColor := theme.Current().ModeColor(modeFlag).Lipgloss()
 
-	theme.Focused.TextInput.Cursor = theme.Focused.TextInput.Cursor.Foreground(modeColor)
-	theme.Focused.Title = theme.Focused.Title.Foreground(modeColor)
+	formTheme.Focused.TextInput.Cursor = formTheme.Focused.TextInput.Cursor.Foreground(modeColor)
+	formTheme.Focused.Title = formTheme.Focused.Title.Foreground(modeColor)
 
 	form := huh.NewForm(
 		huh.NewGroup(
@@ -216,9 +375,9 @@ func promptForInitialTask(ctx context.Context, instanceAddress, modeFlag string)
 				Lines(5).
 				Value(&prompt),
 		),
-	).WithWidth(48).WithTheme(theme)
+	).WithWidth(48).WithTheme(formTheme)
 
-	err := form.Run()
+	err := display.Form(form).Run()
 	if err != nil {
 		// Check if user cancelled with Control-C
 		if err == huh.ErrUserAborted {
@@ -0,0 +1,286 @@
+package e2e
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/sqlite"
+	"github.com/clica/cli/pkg/common"
+)
+
+// createLocksDB creates the locks database in clineDir, as a running clica-core would have
+func createLocksDB(t *testing.T, clineDir string) string {
+	t.Helper()
+
+	dbPath := filepath.Join(clineDir, common.SETTINGS_SUBFOLDER, "locks.db")
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		t.Fatalf("mkdir settings dir: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open locks db: %v", err)
+	}
+	defer db.Close()
+
+	if err := initLocksSchema(db); err != nil {
+		t.Fatalf("init locks schema: %v", err)
+	}
+	return dbPath
+}
+
+// newLockManager opens the locks database like a separate CLI process would
+func newLockManager(t *testing.T, clineDir string) *sqlite.LockManager {
+	t.Helper()
+
+	lm, err := sqlite.NewLockManager(clineDir)
+	if err != nil {
+		t.Fatalf("new lock manager: %v", err)
+	}
+	t.Cleanup(func() { lm.Close() })
+	return lm
+}
+
+// insertFileLock plants a file lock row directly, e.g. one left behind by a crashed process
+func insertFileLock(t *testing.T, dbPath, heldBy, target string, lockedAt time.Time) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open locks db: %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(common.InsertFileLockSQL, heldBy, target, lockedAt.UnixMilli()); err != nil {
+		t.Fatalf("insert file lock: %v", err)
+	}
+}
+
+// countLockWaiters returns how many contenders are queued for a file lock
+func countLockWaiters(t *testing.T, dbPath, target string) int {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("open locks db: %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM lock_waiters WHERE lock_target = ?`, target).Scan(&count); err != nil {
+		return 0
+	}
+	return count
+}
+
+// Many contenders across several lock managers never hold the same file lock at once
+func TestFileLockStressMutualExclusion(t *testing.T) {
+	clineDir := setTempClineDir(t)
+	createLocksDB(t, clineDir)
+	target := filepath.Join(clineDir, "contended.json")
+
+	const (
+		managers   = 4
+		workers    = 4
+		iterations = 15
+	)
+
+	var inFlight, overlaps, sections int32
+	var wg sync.WaitGroup
+	errs := make(chan error, managers*workers)
+
+	for m := 0; m < managers; m++ {
+		lm := newLockManager(t, clineDir)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(holder string) {
+				defer wg.Done()
+				for i := 0; i < iterations; i++ {
+					ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+					lock, err := lm.AcquireFileLock(ctx, target, holder, 0)
+					cancel()
+					if err != nil {
+						errs <- fmt.Errorf("%s: %w", holder, err)
+						return
+					}
+
+					if atomic.AddInt32(&inFlight, 1) != 1 {
+						atomic.AddInt32(&overlaps, 1)
+					}
+					atomic.AddInt32(&sections, 1)
+					time.Sleep(time.Millisecond)
+					atomic.AddInt32(&inFlight, -1)
+
+					if err := lock.Release(); err != nil {
+						errs <- fmt.Errorf("%s: release: %w", holder, err)
+						return
+					}
+				}
+			}(fmt.Sprintf("stress-%d-%d", m, w))
+		}
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	if overlaps != 0 {
+		t.Fatalf("lock was held by more than one contender %d times", overlaps)
+	}
+	if want := managers * workers * iterations; int(sections) != want {
+		t.Fatalf("expected %d critical sections, got %d", want, sections)
+	}
+}
+
+// Contenders get the lock in the order they started waiting for it
+func TestFileLockFairOrder(t *testing.T) {
+	clineDir := setTempClineDir(t)
+	dbPath := createLocksDB(t, clineDir)
+	target := filepath.Join(clineDir, "fair.json")
+	lm := newLockManager(t, clineDir)
+
+	first, err := lm.AcquireFileLock(context.Background(), target, "holder", 0)
+	if err != nil {
+		t.Fatalf("acquire initial lock: %v", err)
+	}
+
+	const waiters = 5
+	order := make(chan int, waiters)
+	var wg sync.WaitGroup
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), longTimeout)
+			defer cancel()
+
+			lock, err := newLockManager(t, clineDir).AcquireFileLock(ctx, target, fmt.Sprintf("waiter-%d", i), 0)
+			if err != nil {
+				t.Errorf("waiter %d: %v", i, err)
+				return
+			}
+			order <- i
+			lock.Release()
+		}(i)
+
+		// Make sure waiter i is queued before the next one arrives
+		waitFor(t, defaultTimeout, func() (bool, string) {
+			n := countLockWaiters(t, dbPath, target)
+			return n == i+1, fmt.Sprintf("expected %d queued waiters, have %d", i+1, n)
+		})
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("release initial lock: %v", err)
+	}
+	wg.Wait()
+	close(order)
+
+	next := 0
+	for i := range order {
+		if i != next {
+			t.Fatalf("waiter %d got the lock in position %d", i, next)
+		}
+		next++
+	}
+	if next != waiters {
+		t.Fatalf("expected %d waiters to get the lock, got %d", waiters, next)
+	}
+}
+
+// A lock left behind by a process that exited is taken over without waiting for its lease
+func TestFileLockStaleHolderTakeover(t *testing.T) {
+	clineDir := setTempClineDir(t)
+	dbPath := createLocksDB(t, clineDir)
+	target := filepath.Join(clineDir, "crashed.json")
+
+	// A PID that is guaranteed to be gone
+	cmd := exec.Command("true")
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("run true: %v", err)
+	}
+	insertFileLock(t, dbPath, fmt.Sprintf("cli-process-%d", cmd.Process.Pid), target, time.Now())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	lock, err := newLockManager(t, clineDir).AcquireFileLock(ctx, target, "survivor", time.Hour)
+	if err != nil {
+		t.Fatalf("expected to take over lock of exited process: %v", err)
+	}
+	lock.Release()
+}
+
+// A lock whose live holder stopped renewing it is taken over once its lease runs out
+func TestFileLockLeaseExpiry(t *testing.T) {
+	clineDir := setTempClineDir(t)
+	dbPath := createLocksDB(t, clineDir)
+	target := filepath.Join(clineDir, "hung.json")
+
+	// Held by this (live) process, but never renewed
+	insertFileLock(t, dbPath, fmt.Sprintf("cli-process-%d", os.Getpid()), target, time.Now())
+
+	ttl := 500 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+
+	start := time.Now()
+	lock, err := newLockManager(t, clineDir).AcquireFileLock(ctx, target, "successor", ttl)
+	if err != nil {
+		t.Fatalf("expected to take over expired lease: %v", err)
+	}
+	defer lock.Release()
+
+	if elapsed := time.Since(start); elapsed < ttl {
+		t.Fatalf("lease taken over after %s, before it expired (%s)", elapsed, ttl)
+	}
+}
+
+// A held lock keeps renewing its lease, so contenders time out instead of taking it over
+func TestFileLockLeaseRenewal(t *testing.T) {
+	clineDir := setTempClineDir(t)
+	createLocksDB(t, clineDir)
+	target := filepath.Join(clineDir, "renewed.json")
+	ttl := 300 * time.Millisecond
+
+	held, err := newLockManager(t, clineDir).AcquireFileLock(context.Background(), target, "owner", ttl)
+	if err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+
+	// Outlive several leases
+	time.Sleep(4 * ttl)
+
+	contender := newLockManager(t, clineDir)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*ttl)
+	_, err = contender.AcquireFileLock(ctx, target, "contender", ttl)
+	cancel()
+	if err == nil {
+		t.Fatalf("contender acquired a lock that is still being renewed")
+	}
+	if held.Lost() {
+		t.Fatalf("renewed lock reported as lost")
+	}
+
+	if err := held.Release(); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	lock, err := contender.AcquireFileLock(ctx, target, "contender", ttl)
+	if err != nil {
+		t.Fatalf("acquire after release: %v", err)
+	}
+	lock.Release()
+}
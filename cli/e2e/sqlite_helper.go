@@ -103,7 +103,23 @@ func insertRemoteInstanceIntoSQLite(t *testing.T, dbPath, address string, corePo
 	}
 	defer db.Close()
 
-	// Initialize database schema for testing
+	if err := initLocksSchema(db); err != nil {
+		return err
+	}
+
+	// Insert the remote instance
+	hostAddress := "remote.example.com:0"
+	if hostPort != 0 {
+		hostAddress = "remote.example.com:" + strconv.Itoa(hostPort)
+	}
+
+	insertSQL := `INSERT INTO locks (held_by, lock_type, lock_target, locked_at) VALUES (?, 'instance', ?, ?)`
+	_, err = db.Exec(insertSQL, address, hostAddress, time.Now().Unix()*1000)
+	return err
+}
+
+// initLocksSchema creates the locks table the way clica-core does
+func initLocksSchema(db *sql.DB) error {
 	createTableSQL := `
 		CREATE TABLE IF NOT EXISTS locks (
 			id INTEGER PRIMARY KEY,
@@ -123,18 +139,7 @@ func insertRemoteInstanceIntoSQLite(t *testing.T, dbPath, address string, corePo
 	if _, err := db.Exec(createTableSQL); err != nil {
 		return err
 	}
-	if _, err := db.Exec(createIndexesSQL); err != nil {
-		return err
-	}
-
-	// Insert the remote instance
-	hostAddress := "remote.example.com:0"
-	if hostPort != 0 {
-		hostAddress = "remote.example.com:" + strconv.Itoa(hostPort)
-	}
-
-	insertSQL := `INSERT INTO locks (held_by, lock_type, lock_target, locked_at) VALUES (?, 'instance', ?, ?)`
-	_, err = db.Exec(insertSQL, address, hostAddress, time.Now().Unix()*1000)
+	_, err := db.Exec(createIndexesSQL)
 	return err
 }
 
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func NewAttachCommand() *cobra.Command {
+	var (
+		tui        bool
+		recordPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "attach [task-id|instance]",
+		Short: "Reattach to a running task",
+		Long: `Reattach to a task left running with 'clica --detach' or Ctrl+\, with full
+interactive input and approvals.
+
+The argument is a task ID or an instance name or address, as listed by 'clica ps'.
+Without one, attaches to the only running task.
+
+Press Ctrl+\ to detach again; the task keeps running. With --tui, the task
+is shown in the full-screen interface.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			ctx := cmd.Context()
+
+			ref := ""
+			if len(args) == 1 {
+				ref = args[0]
+			}
+			target, err := resolveAttachTarget(ctx, ref)
+			if err != nil {
+				return err
+			}
+
+			if err := ensureTaskManager(ctx, target.Address); err != nil {
+				return err
+			}
+
+			if recordPath != "" {
+				if err := task.StartRecording(recordPath); err != nil {
+					return err
+				}
+				defer task.StopRecording()
+			}
+
+			if tui && term.IsTerminal(int(os.Stdout.Fd())) {
+				err = taskManager.RunTUI(ctx, target.Address, task.TUIOptions{})
+			} else {
+				err = taskManager.FollowConversation(ctx, target.Address, true)
+			}
+			if errors.Is(err, task.ErrDetached) {
+				printDetachHint(target.Address, target.TaskID)
+				return nil
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&tui, "tui", false, "show the task in the full-screen interface")
+	cmd.Flags().StringVar(&recordPath, "record", "", RecordFlagUsage)
+
+	return cmd
+}
+
+// resolveAttachTarget finds the running task a task ID or instance reference points to
+func resolveAttachTarget(ctx context.Context, ref string) (*runningTask, error) {
+	tasks, err := listRunningTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref == "" {
+		switch len(tasks) {
+		case 0:
+			return nil, fmt.Errorf("no tasks running. Start one with 'clica \"prompt\"'")
+		case 1:
+			return tasks[0], nil
+		}
+
+		var refs []string
+		for _, t := range tasks {
+			refs = append(refs, fmt.Sprintf("  %s (%s, %s)", t.TaskID, attachRef(t), t.Status))
+		}
+		return nil, fmt.Errorf("%d tasks running, choose one:\n%s", len(tasks), strings.Join(refs, "\n"))
+	}
+
+	for _, t := range tasks {
+		if t.TaskID == ref {
+			return t, nil
+		}
+	}
+
+	// Not a task ID, so an instance name or address
+	address, err := global.ResolveInstanceAddress(ref)
+	if err != nil {
+		return nil, fmt.Errorf("no running task or instance '%s'. Run 'clica ps' to see running tasks", ref)
+	}
+	for _, t := range tasks {
+		if t.Address == address {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("no task running on %s. Run 'clica ps' to see running tasks", ref)
+}
+
+// attachRef returns the shortest reference 'clica attach' accepts for a task's instance
+func attachRef(t *runningTask) string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.Address
+}
+
+// printDetachHint tells the user how to get back to a task left running
+func printDetachHint(address, taskID string) {
+	ref := address
+	if meta := global.Clients.GetRegistry().GetInstanceMetadata(address); meta != nil && meta.Name != "" {
+		ref = meta.Name
+	}
+
+	renderer := display.NewRenderer(global.Config.OutputFormat)
+	fmt.Printf("\n%s\n", renderer.Dim(fmt.Sprintf("Detached. Task %s keeps running on %s.", taskID, address)))
+	fmt.Printf("Reattach with: clica attach %s\n", ref)
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/huh"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/global"
 	"github.com/clica/cli/pkg/cli/task"
 	"github.com/clica/grpc-go/clica"
@@ -69,7 +70,7 @@ func signOutDialog(ctx context.Context) error {
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return nil
 	}
 
@@ -245,7 +246,7 @@ func HandleSelectOrganization(ctx context.Context) error {
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return fmt.Errorf("failed to select organization: %w", err)
 	}
 
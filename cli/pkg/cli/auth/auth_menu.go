@@ -215,7 +215,7 @@ func ShowAuthMenuWithStatus(isClineAuthenticated bool, hasOrganizations bool, cu
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		// Check if user cancelled with Control-C
 		if err == huh.ErrUserAborted {
 			// Return the error to allow deferred cleanup to run
@@ -281,7 +281,7 @@ func HandleSelectProvider(ctx context.Context) error {
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		// Check if user cancelled with Control-C
 		if err == huh.ErrUserAborted {
 			return huh.ErrUserAborted
@@ -7,6 +7,7 @@ import (
 	"sort"
 
 	"github.com/charmbracelet/huh"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/task"
 	"github.com/clica/grpc-go/clica"
 	"golang.org/x/term"
@@ -99,7 +100,7 @@ func DisplayModelSelectionMenu(models []string, providerName string) (string, er
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return "", fmt.Errorf("failed to select model: %w", err)
 	}
 
@@ -0,0 +1,400 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/clica/grpc-go/clica"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ModelSummary describes a single model for listing and selection purposes.
+// HasInfo is false when the provider only reports model IDs (OpenAI-compatible, Ollama).
+// Prices are nil when unknown, a known zero price is a free model.
+type ModelSummary struct {
+	ID             string   `json:"id"`
+	ContextWindow  int64    `json:"context_window,omitempty"`
+	MaxTokens      int64    `json:"max_tokens,omitempty"`
+	InputPrice     *float64 `json:"input_price,omitempty"`
+	OutputPrice    *float64 `json:"output_price,omitempty"`
+	SupportsImages bool     `json:"supports_images"`
+	HasInfo        bool     `json:"-"`
+}
+
+// ParseProviderID converts a provider ID (e.g. "anthropic", "openrouter") to its enum value
+func ParseProviderID(providerID string) (clica.ApiProvider, error) {
+	provider, ok := mapProviderStringToEnum(providerID)
+	if !ok {
+		return provider, fmt.Errorf("unknown provider '%s'", providerID)
+	}
+	return provider, nil
+}
+
+// GetActiveProviderForMode returns the provider currently configured for the given mode ("plan" or "act")
+func GetActiveProviderForMode(ctx context.Context, manager *task.Manager, mode string) (*ProviderDisplay, error) {
+	result, err := GetProviderConfigurations(ctx, manager)
+	if err != nil {
+		return nil, err
+	}
+
+	var providerDisplay *ProviderDisplay
+	if mode == "act" {
+		providerDisplay = result.ActProvider
+	} else {
+		providerDisplay = result.PlanProvider
+	}
+
+	if providerDisplay == nil {
+		return nil, fmt.Errorf("no provider configured for %s mode - run 'clica auth' first", mode)
+	}
+
+	return providerDisplay, nil
+}
+
+// ListProviderModels returns all models available for a provider.
+// Providers with live model lists are refreshed through Clica Core, the rest use the generated definitions.
+func ListProviderModels(ctx context.Context, manager *task.Manager, provider clica.ApiProvider) ([]ModelSummary, error) {
+	switch provider {
+	case clica.ApiProvider_OPENROUTER, clica.ApiProvider_CLICA:
+		models, err := FetchOpenRouterModels(ctx, manager)
+		if err != nil {
+			return nil, err
+		}
+		summaries := make([]ModelSummary, 0, len(models))
+		for modelID, info := range models {
+			summaries = append(summaries, openRouterModelSummary(modelID, info))
+		}
+		sortModelSummaries(summaries)
+		return summaries, nil
+
+	case clica.ApiProvider_OCA:
+		models, err := FetchOcaModels(ctx, manager)
+		if err != nil {
+			return nil, err
+		}
+		return idOnlyModelSummaries(ConvertModelsMapToSlice(ConvertOcaModelsToInterface(models))), nil
+
+	case clica.ApiProvider_OPENAI, clica.ApiProvider_OLLAMA:
+		apiConfig, err := getAPIConfigFromState(ctx, manager)
+		if err != nil {
+			return nil, err
+		}
+
+		var modelIDs []string
+		if provider == clica.ApiProvider_OLLAMA {
+			baseURL, _ := apiConfig["ollamaBaseUrl"].(string)
+			modelIDs, err = FetchOllamaModels(ctx, manager, baseURL)
+		} else {
+			baseURL, _ := apiConfig["openAiBaseUrl"].(string)
+			if baseURL == "" {
+				baseURL = "https://api.openai.com/v1"
+			}
+			modelIDs, err = FetchOpenAiModels(ctx, manager, baseURL, getProviderAPIKeyFromState(apiConfig, provider))
+		}
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(modelIDs)
+		return idOnlyModelSummaries(modelIDs), nil
+	}
+
+	if !SupportsStaticModelList(provider) {
+		return nil, fmt.Errorf("model listing not supported for provider: %s", GetProviderDisplayName(provider))
+	}
+
+	modelIDs, infos, err := FetchStaticModels(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]ModelSummary, 0, len(modelIDs))
+	for _, modelID := range modelIDs {
+		info := infos[modelID]
+		summaries = append(summaries, ModelSummary{
+			ID:             modelID,
+			ContextWindow:  int64(info.ContextWindow),
+			MaxTokens:      int64(info.MaxTokens),
+			InputPrice:     staticModelPrice(info.InputPrice),
+			OutputPrice:    staticModelPrice(info.OutputPrice),
+			SupportsImages: info.SupportsImages,
+			HasInfo:        true,
+		})
+	}
+	return summaries, nil
+}
+
+// UseModel switches the model for the given modes ("plan", "act") in the global API configuration.
+// Only the model ID fields of the selected modes (and the provider enum, if it changes) are updated.
+func UseModel(ctx context.Context, manager *task.Manager, provider clica.ApiProvider, modelID string, modes []string, setProvider bool) error {
+	fields, err := GetProviderFields(provider)
+	if err != nil {
+		return err
+	}
+
+	// Model info is only needed for providers that store it alongside the model ID
+	var modelInfo *clica.OpenRouterModelInfo
+	if provider == clica.ApiProvider_OPENROUTER || provider == clica.ApiProvider_CLICA {
+		modelInfo = lookupOpenRouterModelInfo(ctx, manager, modelID)
+	}
+
+	apiConfig := &clica.ModelsApiConfiguration{}
+	var fieldPaths []string
+
+	for _, mode := range modes {
+		fieldName, err := GetModelIDFieldName(provider, mode)
+		if err != nil {
+			return err
+		}
+		setModelIDField(apiConfig, fieldName, &modelID)
+		fieldPaths = append(fieldPaths, fieldName)
+
+		if modelInfo != nil {
+			if mode == "plan" {
+				apiConfig.PlanModeOpenRouterModelInfo = modelInfo
+				fieldPaths = append(fieldPaths, fields.PlanModeModelInfoField)
+			} else {
+				apiConfig.ActModeOpenRouterModelInfo = modelInfo
+				fieldPaths = append(fieldPaths, fields.ActModeModelInfoField)
+			}
+		}
+
+		if setProvider {
+			if mode == "plan" {
+				apiConfig.PlanModeApiProvider = &provider
+				fieldPaths = append(fieldPaths, "planModeApiProvider")
+			} else {
+				apiConfig.ActModeApiProvider = &provider
+				fieldPaths = append(fieldPaths, "actModeApiProvider")
+			}
+		}
+	}
+
+	request := &clica.UpdateApiConfigurationPartialRequest{
+		ApiConfiguration: apiConfig,
+		UpdateMask:       &fieldmaskpb.FieldMask{Paths: fieldPaths},
+	}
+
+	if err := updateApiConfigurationPartial(ctx, manager, request); err != nil {
+		return fmt.Errorf("failed to update API configuration: %w", err)
+	}
+
+	verboseLog("Model %s applied globally for modes %v", modelID, modes)
+	return nil
+}
+
+// UseModelForCurrentTask switches the model for the given modes on the current task only.
+// The global API configuration is left untouched.
+func UseModelForCurrentTask(ctx context.Context, manager *task.Manager, provider clica.ApiProvider, modelID string, modes []string, setProvider bool) error {
+	if err := manager.CheckSendEnabled(ctx); err != nil {
+		if errors.Is(err, task.ErrNoActiveTask) {
+			return fmt.Errorf("no active task to apply the model to")
+		}
+		if errors.Is(err, task.ErrTaskBusy) {
+			return fmt.Errorf("cannot switch the task model while the task is busy, try again when it waits for input")
+		}
+		return fmt.Errorf("failed to check task state: %w", err)
+	}
+
+	settings := &clica.Settings{}
+	for _, mode := range modes {
+		fieldName, err := GetModelIDFieldName(provider, mode)
+		if err != nil {
+			return err
+		}
+		if err := setTaskModelIDField(settings, fieldName, modelID); err != nil {
+			return err
+		}
+
+		if setProvider {
+			if mode == "plan" {
+				settings.PlanModeApiProvider = &provider
+			} else {
+				settings.ActModeApiProvider = &provider
+			}
+		}
+	}
+
+	if _, err := manager.GetClient().State.UpdateTaskSettings(ctx, &clica.UpdateTaskSettingsRequest{
+		Settings: settings,
+	}); err != nil {
+		return fmt.Errorf("failed to update task settings: %w", err)
+	}
+
+	verboseLog("Model %s applied to current task for modes %v", modelID, modes)
+	return nil
+}
+
+// SelectAndUseModelForCurrentTask shows a model picker for the provider active in the given mode
+// and applies the selection to the current task only. Used by the interactive /model command.
+func SelectAndUseModelForCurrentTask(ctx context.Context, manager *task.Manager, mode string) (string, error) {
+	active, err := GetActiveProviderForMode(ctx, manager, mode)
+	if err != nil {
+		return "", err
+	}
+
+	models, err := ListProviderModels(ctx, manager, active.Provider)
+	if err != nil {
+		return "", err
+	}
+
+	modelIDs := make([]string, len(models))
+	for i, model := range models {
+		modelIDs[i] = model.ID
+	}
+
+	modelID, err := DisplayModelSelectionMenu(modelIDs, GetProviderDisplayName(active.Provider))
+	if err != nil {
+		return "", err
+	}
+
+	if err := UseModelForCurrentTask(ctx, manager, active.Provider, modelID, []string{mode}, false); err != nil {
+		return "", err
+	}
+
+	return modelID, nil
+}
+
+// getAPIConfigFromState fetches the apiConfiguration object from Clica Core state
+func getAPIConfigFromState(ctx context.Context, manager *task.Manager) (map[string]interface{}, error) {
+	state, err := manager.GetClient().State.GetLatestState(ctx, &clica.EmptyRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state: %w", err)
+	}
+
+	var stateData map[string]interface{}
+	if err := json.Unmarshal([]byte(state.StateJson), &stateData); err != nil {
+		return nil, fmt.Errorf("failed to parse state JSON: %w", err)
+	}
+
+	apiConfig, ok := stateData["apiConfiguration"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no API configuration found in state")
+	}
+
+	return apiConfig, nil
+}
+
+// lookupOpenRouterModelInfo fetches model info for an OpenRouter-compatible model.
+// Returns nil if the model list can't be fetched or the model isn't listed.
+func lookupOpenRouterModelInfo(ctx context.Context, manager *task.Manager, modelID string) *clica.OpenRouterModelInfo {
+	models, err := FetchOpenRouterModels(ctx, manager)
+	if err != nil {
+		verboseLog("Could not fetch model info for %s: %v", modelID, err)
+		return nil
+	}
+	return models[modelID]
+}
+
+// setModelIDField sets a single plan or act mode model ID field in the API configuration
+func setModelIDField(apiConfig *clica.ModelsApiConfiguration, fieldName string, value *string) {
+	switch fieldName {
+	case "planModeApiModelId":
+		apiConfig.PlanModeApiModelId = value
+	case "actModeApiModelId":
+		apiConfig.ActModeApiModelId = value
+	case "planModeOpenAiModelId":
+		apiConfig.PlanModeOpenAiModelId = value
+	case "actModeOpenAiModelId":
+		apiConfig.ActModeOpenAiModelId = value
+	case "planModeOpenRouterModelId":
+		apiConfig.PlanModeOpenRouterModelId = value
+	case "actModeOpenRouterModelId":
+		apiConfig.ActModeOpenRouterModelId = value
+	case "planModeOllamaModelId":
+		apiConfig.PlanModeOllamaModelId = value
+	case "actModeOllamaModelId":
+		apiConfig.ActModeOllamaModelId = value
+	case "planModeAwsBedrockCustomModelBaseId":
+		apiConfig.PlanModeAwsBedrockCustomModelBaseId = value
+	case "actModeAwsBedrockCustomModelBaseId":
+		apiConfig.ActModeAwsBedrockCustomModelBaseId = value
+	case "planModeOcaModelId":
+		apiConfig.PlanModeOcaModelId = value
+	case "actModeOcaModelId":
+		apiConfig.ActModeOcaModelId = value
+	}
+}
+
+// setTaskModelIDField sets a single plan or act mode model ID field in task settings
+func setTaskModelIDField(settings *clica.Settings, fieldName string, value string) error {
+	switch fieldName {
+	case "planModeApiModelId":
+		settings.PlanModeApiModelId = &value
+	case "actModeApiModelId":
+		settings.ActModeApiModelId = &value
+	case "planModeOpenAiModelId":
+		settings.PlanModeOpenAiModelId = &value
+	case "actModeOpenAiModelId":
+		settings.ActModeOpenAiModelId = &value
+	case "planModeOpenRouterModelId":
+		settings.PlanModeOpenRouterModelId = &value
+	case "actModeOpenRouterModelId":
+		settings.ActModeOpenRouterModelId = &value
+	case "planModeOllamaModelId":
+		settings.PlanModeOllamaModelId = &value
+	case "actModeOllamaModelId":
+		settings.ActModeOllamaModelId = &value
+	case "planModeAwsBedrockCustomModelBaseId":
+		settings.PlanModeAwsBedrockCustomModelBaseId = &value
+	case "actModeAwsBedrockCustomModelBaseId":
+		settings.ActModeAwsBedrockCustomModelBaseId = &value
+	case "planModeOcaModelId":
+		settings.PlanModeOcaModelId = &value
+	case "actModeOcaModelId":
+		settings.ActModeOcaModelId = &value
+	default:
+		return fmt.Errorf("task-level model override not supported for field %s", fieldName)
+	}
+	return nil
+}
+
+// openRouterModelSummary converts OpenRouter model info into a ModelSummary
+func openRouterModelSummary(modelID string, info *clica.OpenRouterModelInfo) ModelSummary {
+	summary := ModelSummary{ID: modelID}
+	if info == nil {
+		return summary
+	}
+
+	summary.HasInfo = true
+	if info.ContextWindow != nil {
+		summary.ContextWindow = *info.ContextWindow
+	}
+	if info.MaxTokens != nil {
+		summary.MaxTokens = *info.MaxTokens
+	}
+	summary.InputPrice = info.InputPrice
+	summary.OutputPrice = info.OutputPrice
+	if info.SupportsImages != nil {
+		summary.SupportsImages = *info.SupportsImages
+	}
+	return summary
+}
+
+// staticModelPrice returns a price from the generated model definitions, nil if it's zero: the
+// definitions hold whole dollars, so a price below $1 is written as 0 and isn't a free model
+func staticModelPrice(price float64) *float64 {
+	if price == 0 {
+		return nil
+	}
+	return &price
+}
+
+// idOnlyModelSummaries wraps bare model IDs in ModelSummary values without info
+func idOnlyModelSummaries(modelIDs []string) []ModelSummary {
+	summaries := make([]ModelSummary, len(modelIDs))
+	for i, modelID := range modelIDs {
+		summaries[i] = ModelSummary{ID: modelID}
+	}
+	return summaries
+}
+
+// sortModelSummaries sorts models alphabetically by ID for consistent display
+func sortModelSummaries(summaries []ModelSummary) {
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ID < summaries[j].ID
+	})
+}
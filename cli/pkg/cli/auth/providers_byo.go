@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/huh"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/grpc-go/clica"
 )
 
@@ -50,7 +51,7 @@ func SelectBYOProvider() (clica.ApiProvider, error) {
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return 0, fmt.Errorf("failed to select provider: %w", err)
 	}
 
@@ -153,7 +154,7 @@ func PromptForAPIKey(provider clica.ApiProvider) (string, string, error) {
 
 	form := huh.NewForm(huh.NewGroup(apiKeyField))
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return "", "", fmt.Errorf("failed to get API key: %w", err)
 	}
 
@@ -170,7 +171,7 @@ func PromptForAPIKey(provider clica.ApiProvider) (string, string, error) {
 			),
 		)
 
-		if err := baseURLForm.Run(); err != nil {
+		if err := display.Form(baseURLForm).Run(); err != nil {
 			return "", "", fmt.Errorf("failed to get base URL: %w", err)
 		}
 
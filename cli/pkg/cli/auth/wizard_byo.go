@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/huh"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/global"
 	"github.com/clica/cli/pkg/cli/task"
 	"github.com/clica/grpc-go/clica"
@@ -51,7 +52,7 @@ func (pw *ProviderWizard) showMainMenu() (string, error) {
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return "", fmt.Errorf("failed to get menu choice: %w", err)
 	}
 
@@ -364,7 +365,7 @@ func (pw *ProviderWizard) selectFromAvailableModels(models []string) (string, er
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return "", fmt.Errorf("failed to select model: %w", err)
 	}
 
@@ -399,7 +400,7 @@ func (pw *ProviderWizard) manualModelEntry(provider clica.ApiProvider) (string,
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return "", nil, fmt.Errorf("failed to get model ID: %w", err)
 	}
 
@@ -456,7 +457,7 @@ func (pw *ProviderWizard) handleChangeModel() error {
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return fmt.Errorf("failed to select provider: %w", err)
 	}
 
@@ -692,7 +693,7 @@ func (pw *ProviderWizard) handleRemoveProvider() error {
 		),
 	)
 
-	if err := form.Run(); err != nil {
+	if err := display.Form(form).Run(); err != nil {
 		return fmt.Errorf("failed to select provider: %w", err)
 	}
 
@@ -716,7 +717,7 @@ func (pw *ProviderWizard) handleRemoveProvider() error {
 		),
 	)
 
-	if err := confirmForm.Run(); err != nil {
+	if err := display.Form(confirmForm).Run(); err != nil {
 		return fmt.Errorf("failed to get confirmation: %w", err)
 	}
 
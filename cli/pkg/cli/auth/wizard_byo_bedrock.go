@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/huh"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/task"
 	"github.com/clica/grpc-go/clica"
 	"google.golang.org/protobuf/proto"
@@ -52,7 +53,7 @@ func PromptForBedrockConfig(ctx context.Context, manager *task.Manager) (*Bedroc
 		),
 	)
 
-	if err := profileQuestion.Run(); err != nil {
+	if err := display.Form(profileQuestion).Run(); err != nil {
 		return nil, fmt.Errorf("failed to get authentication method: %w", err)
 	}
 
@@ -113,7 +114,7 @@ func PromptForBedrockConfig(ctx context.Context, manager *task.Manager) (*Bedroc
 		),
 	)
 
-	if err := configForm.Run(); err != nil {
+	if err := display.Form(configForm).Run(); err != nil {
 		return nil, fmt.Errorf("failed to get Bedrock configuration: %w", err)
 	}
 
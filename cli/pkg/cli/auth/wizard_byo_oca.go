@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/charmbracelet/huh"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/global"
 	"github.com/clica/cli/pkg/cli/task"
 	"github.com/clica/grpc-go/clica"
@@ -46,7 +47,7 @@ func PromptForOcaConfig(ctx context.Context, manager *task.Manager) (*OcaConfig,
 		),
 	)
 
-	if err := configForm.Run(); err != nil {
+	if err := display.Form(configForm).Run(); err != nil {
 		return nil, fmt.Errorf("failed to get OCA configuration: %w", err)
 	}
 
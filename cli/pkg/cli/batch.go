@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/clica/cli/pkg/cli/batch"
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/spf13/cobra"
+)
+
+func NewBatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Run many tasks in parallel",
+		Long:  `Run a batch of tasks, each on its own instance, from a YAML file.`,
+	}
+
+	cmd.AddCommand(newBatchRunCommand())
+
+	return cmd
+}
+
+func newBatchRunCommand() *cobra.Command {
+	var parallel int
+	var summaryPath string
+
+	cmd := &cobra.Command{
+		Use:   "run <tasks.yaml>",
+		Short: "Run the tasks of a batch file",
+		Long: `Run every task of a batch file on its own instance, a few at a time, and write a
+summary with the outcome, cost and last checkpoint of each task.
+
+A batch file looks like:
+
+  concurrency: 4          # tasks running at once (--parallel overrides it)
+  approval: policy        # yolo (approve everything) or policy
+  policy:                 # actions auto-approved in policy mode; others are denied
+    - read_files
+    - edit_files
+  timeout: 30m            # per task
+  mode: act               # default mode of all tasks
+  settings:               # settings of all tasks, as with -s
+    - model=...
+  tasks:
+    - id: api
+      prompt: Upgrade the logger to v2
+      workspace: services/api   # relative to the batch file
+      files: [go.mod]           # relative to the workspace
+    - id: web
+      prompt: Upgrade the logger to v2
+      workspace: services/web
+      approval: yolo
+
+Tasks end as succeeded, failed, needs_input (the model asked a question),
+timed_out or cancelled. The command fails if any task did not succeed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+			if parallel < 0 {
+				return fmt.Errorf("--parallel must be positive")
+			}
+
+			file := args[0]
+			spec, err := batch.LoadSpec(file)
+			if err != nil {
+				return err
+			}
+
+			// User, project and environment settings apply below the batch file's own,
+			// with the project config of each task's workspace
+			for _, t := range spec.Tasks {
+				defaults, err := resolveWorkspaceSettings(t.Workspace, nil)
+				if err != nil {
+					return fmt.Errorf("task %s: %w", t.ID, err)
+				}
+				t.Defaults = defaults
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			runner := batch.NewRunner(spec, parallel)
+
+			var summary *batch.Summary
+			if global.Config.OutputFormat == "json" {
+				summary = runner.Run(ctx, file)
+			} else {
+				done := make(chan struct{})
+				dashboardDone := make(chan struct{})
+				go func() {
+					batch.NewDashboard(runner, file).Run(done)
+					close(dashboardDone)
+				}()
+				summary = runner.Run(ctx, file)
+				close(done)
+				<-dashboardDone
+			}
+
+			data, err := json.MarshalIndent(summary, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal batch summary: %w", err)
+			}
+			if err := os.WriteFile(summaryPath, append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("failed to write batch summary: %w", err)
+			}
+
+			if global.Config.OutputFormat == "json" {
+				fmt.Println(string(data))
+			} else {
+				renderer := display.NewRenderer(global.Config.OutputFormat)
+				fmt.Println()
+				fmt.Printf("%d/%d tasks succeeded, total cost $%.4f\n", summary.Succeeded, len(summary.Tasks), summary.Cost)
+				fmt.Println(renderer.Dim("Summary written to " + summaryPath))
+			}
+
+			if summary.Failed > 0 {
+				return fmt.Errorf("%d of %d tasks did not succeed", summary.Failed, len(summary.Tasks))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&parallel, "parallel", "j", 0, "number of tasks to run at once (default: the batch file's concurrency, or 4)")
+	cmd.Flags().StringVar(&summaryPath, "summary", "batch-summary.json", "file to write the summary JSON to")
+
+	return cmd
+}
@@ -0,0 +1,173 @@
+package batch
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"golang.org/x/term"
+)
+
+const (
+	dashboardInterval = 500 * time.Millisecond
+	defaultWidth      = 100
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Dashboard shows the progress of a batch. On a terminal it redraws one line per task;
+// otherwise, and for plain or accessible output, it logs a line whenever a task changes phase.
+type Dashboard struct {
+	runner *Runner
+	file   string
+	out    io.Writer
+	live   bool
+
+	frame  int
+	lines  int               // lines drawn by the last redraw
+	phases map[string]string // last logged phase per task
+}
+
+// NewDashboard creates a dashboard for a runner, drawing live if stdout is a terminal. Screen
+// readers and plain output get the log instead, redrawing moves the cursor and animates a spinner.
+func NewDashboard(runner *Runner, file string) *Dashboard {
+	return &Dashboard{
+		runner: runner,
+		file:   file,
+		out:    os.Stdout,
+		live:   term.IsTerminal(int(os.Stdout.Fd())) && global.Config.OutputFormat != "plain" && !display.Accessible(),
+		phases: map[string]string{},
+	}
+}
+
+// Run updates the dashboard until done is closed, then draws the final state
+func (d *Dashboard) Run(done <-chan struct{}) {
+	ticker := time.NewTicker(dashboardInterval)
+	defer ticker.Stop()
+
+	for {
+		d.draw()
+		select {
+		case <-done:
+			d.draw()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dashboard) draw() {
+	results := d.runner.Snapshot()
+	if !d.live {
+		d.log(results)
+		return
+	}
+
+	width := defaultWidth
+	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
+		width = w
+	}
+
+	var b strings.Builder
+	if d.lines > 0 {
+		// Back to the top of the previous frame
+		fmt.Fprintf(&b, "\033[%dA\r\033[J", d.lines)
+	}
+	b.WriteString(fitLine(d.header(results), width) + "\n")
+	for _, res := range results {
+		b.WriteString(fitLine(d.taskLine(res), width) + "\n")
+	}
+	fmt.Fprint(d.out, b.String())
+
+	d.lines = len(results) + 1
+	d.frame++
+}
+
+// log prints a line for every task whose phase changed since the last call
+func (d *Dashboard) log(results []Result) {
+	for _, res := range results {
+		if d.phases[res.ID] == res.Phase {
+			continue
+		}
+		d.phases[res.ID] = res.Phase
+
+		if res.Phase == PhaseQueued {
+			continue
+		}
+		line := fmt.Sprintf("%s %s: %s", time.Now().Format("15:04:05"), res.ID, res.Phase)
+		if res.Phase == PhaseDone {
+			line = fmt.Sprintf("%s %s: %s (%s, $%.4f)", time.Now().Format("15:04:05"), res.ID, res.Outcome, elapsed(res), res.Cost)
+			if res.Error != "" {
+				line += ": " + res.Error
+			}
+		}
+		fmt.Fprintln(d.out, line)
+	}
+}
+
+// header summarizes the whole batch
+func (d *Dashboard) header(results []Result) string {
+	var done, running int
+	var cost float64
+	for _, res := range results {
+		switch res.Phase {
+		case PhaseDone:
+			done++
+		case PhaseQueued:
+		default:
+			running++
+		}
+		cost += res.Cost
+	}
+	return fmt.Sprintf("Batch %s: %d/%d done, %d running, $%.4f", d.file, done, len(results), running, cost)
+}
+
+// taskLine is the dashboard line of one task
+func (d *Dashboard) taskLine(res Result) string {
+	icon := spinnerFrames[d.frame%len(spinnerFrames)]
+	state := res.Phase
+	switch res.Phase {
+	case PhaseQueued:
+		icon = "·"
+	case PhaseDone:
+		icon = "✗"
+		if res.Outcome == OutcomeSucceeded {
+			icon = "✓"
+		}
+		state = res.Outcome
+	}
+
+	line := fmt.Sprintf("  %s %-20s %-16s %7s  $%.4f", icon, res.ID, state, elapsed(res), res.Cost)
+	if res.DeniedActions > 0 {
+		line += fmt.Sprintf("  %d denied", res.DeniedActions)
+	}
+	if res.Phase == PhaseDone && res.Error != "" {
+		return line + "  " + strings.Join(strings.Fields(res.Error), " ")
+	}
+	return line + "  " + strings.Join(strings.Fields(res.Prompt), " ")
+}
+
+// elapsed is how long a task has been running, or ran
+func elapsed(res Result) string {
+	if res.StartedAt.IsZero() {
+		return "-"
+	}
+	end := res.FinishedAt
+	if end.IsZero() {
+		end = time.Now()
+	}
+	return end.Sub(res.StartedAt).Round(time.Second).String()
+}
+
+// fitLine cuts a line to the terminal width so redraws don't wrap
+func fitLine(line string, width int) string {
+	runes := []rune(line)
+	if len(runes) < width {
+		return line
+	}
+	return string(runes[:width-1])
+}
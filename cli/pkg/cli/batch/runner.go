@@ -0,0 +1,348 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/clica/cli/pkg/cli/types"
+)
+
+// Task outcomes written to the summary
+const (
+	OutcomeSucceeded  = "succeeded"   // completed, or presented its plan in plan mode
+	OutcomeFailed     = "failed"      // the instance or the task failed
+	OutcomeNeedsInput = "needs_input" // the model asked a question nobody will answer
+	OutcomeTimedOut   = "timed_out"   // still running when the batch timeout passed
+	OutcomeCancelled  = "cancelled"   // the batch was interrupted
+)
+
+// Phases shown before and after a task runs; while it runs its task status is shown
+const (
+	PhaseQueued   = "queued"
+	PhaseStarting = "starting"
+	PhaseDone     = "done"
+)
+
+// BatchLabel marks the instances started for a batch, with the task ID as value
+const BatchLabel = "clica.batch"
+
+const (
+	pollInterval = time.Second
+	// maxStateFailures is how many state polls in a row may fail before the instance is given up on
+	maxStateFailures = 10
+	stopTimeout      = 10 * time.Second
+)
+
+// Result is the outcome of one batch task
+type Result struct {
+	ID            string    `json:"id"`
+	Prompt        string    `json:"prompt"`
+	Workspace     string    `json:"workspace"`
+	Mode          string    `json:"mode"`
+	Approval      string    `json:"approval"`
+	Instance      string    `json:"instance,omitempty"`
+	TaskID        string    `json:"task_id,omitempty"`
+	Outcome       string    `json:"outcome"`
+	Error         string    `json:"error,omitempty"`
+	Cost          float64   `json:"cost"`
+	TokensIn      int       `json:"tokens_in"`
+	TokensOut     int       `json:"tokens_out"`
+	Checkpoint    string    `json:"checkpoint,omitempty"` // hash of the last checkpoint
+	DeniedActions int       `json:"denied_actions,omitempty"`
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+	Seconds       float64   `json:"duration_seconds"`
+
+	Phase string `json:"-"` // queued, starting, a task status, or done
+}
+
+// Summary is the result of a whole batch
+type Summary struct {
+	File       string    `json:"file"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Succeeded  int       `json:"succeeded"`
+	Failed     int       `json:"failed"` // tasks with any outcome but succeeded
+	Cost       float64   `json:"cost"`
+	Tasks      []*Result `json:"tasks"`
+}
+
+// Runner runs the tasks of a batch, each on its own instance
+type Runner struct {
+	spec        *Spec
+	concurrency int
+
+	mu      sync.Mutex
+	results []*Result
+
+	// startMu serializes instance startup: free ports are picked before they are bound,
+	// so concurrent starts could pick the same ones
+	startMu sync.Mutex
+}
+
+// NewRunner creates a runner for a batch, running at most concurrency tasks at a time
+// (the batch file's concurrency if 0)
+func NewRunner(spec *Spec, concurrency int) *Runner {
+	if concurrency <= 0 {
+		concurrency = spec.Concurrency
+	}
+
+	results := make([]*Result, len(spec.Tasks))
+	for i, t := range spec.Tasks {
+		results[i] = &Result{
+			ID:        t.ID,
+			Prompt:    t.Prompt,
+			Workspace: t.Workspace,
+			Mode:      t.Mode,
+			Approval:  t.Approval,
+			Phase:     PhaseQueued,
+		}
+	}
+
+	return &Runner{spec: spec, concurrency: concurrency, results: results}
+}
+
+// Snapshot returns a copy of the current results, in batch file order
+func (r *Runner) Snapshot() []Result {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	snapshot := make([]Result, len(r.results))
+	for i, res := range r.results {
+		snapshot[i] = *res
+	}
+	return snapshot
+}
+
+// Run runs every task and returns the summary. Interrupting ctx cancels the running tasks;
+// queued tasks are reported as cancelled.
+func (r *Runner) Run(ctx context.Context, file string) *Summary {
+	summary := &Summary{File: file, StartedAt: time.Now()}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, r.concurrency)
+	for i, t := range r.spec.Tasks {
+		wg.Add(1)
+		go func(t *TaskSpec, res *Result) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				r.finish(res, OutcomeCancelled, ctx.Err())
+				return
+			}
+			defer func() { <-sem }()
+
+			r.runTask(ctx, t, res)
+		}(t, r.results[i])
+	}
+	wg.Wait()
+
+	summary.FinishedAt = time.Now()
+	for _, res := range r.Snapshot() {
+		res := res
+		if res.Outcome == OutcomeSucceeded {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+		summary.Cost += res.Cost
+		summary.Tasks = append(summary.Tasks, &res)
+	}
+	return summary
+}
+
+// runTask starts an instance for a task, runs the task to an outcome and stops the instance
+func (r *Runner) runTask(ctx context.Context, t *TaskSpec, res *Result) {
+	if ctx.Err() != nil {
+		r.finish(res, OutcomeCancelled, ctx.Err())
+		return
+	}
+
+	r.update(res, func() {
+		res.Phase = PhaseStarting
+		res.StartedAt = time.Now()
+	})
+
+	address, err := r.startInstance(ctx, t)
+	if err != nil {
+		r.finish(res, OutcomeFailed, err)
+		return
+	}
+	r.update(res, func() { res.Instance = address })
+	defer stopInstance(address)
+
+	manager, err := task.NewManagerForAddress(ctx, address)
+	if err != nil {
+		r.finish(res, OutcomeFailed, err)
+		return
+	}
+	defer manager.Cleanup()
+
+	if err := manager.SetMode(ctx, t.Mode, nil, nil, nil); err != nil {
+		r.finish(res, OutcomeFailed, err)
+		return
+	}
+
+	taskID, err := manager.CreateTask(ctx, t.Prompt, nil, t.Files, r.spec.TaskSettings(t))
+	if err != nil {
+		r.finish(res, OutcomeFailed, err)
+		return
+	}
+	r.update(res, func() {
+		res.TaskID = taskID
+		res.Phase = task.StatusStreaming
+	})
+
+	outcome, err := r.watchTask(ctx, manager, t, res)
+	r.finish(res, outcome, err)
+}
+
+// startInstance starts the instance a task runs on
+func (r *Runner) startInstance(ctx context.Context, t *TaskSpec) (string, error) {
+	r.startMu.Lock()
+	defer r.startMu.Unlock()
+
+	instance, err := global.Clients.StartNewInstanceWithOptions(ctx, global.InstanceOptions{
+		Workspace: t.Workspace,
+		Labels:    map[string]string{BatchLabel: t.ID},
+		NoDefault: true, // the batch stops it when the task ends
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start instance: %w", err)
+	}
+	return instance.Address, nil
+}
+
+// stopInstance stops a task's instance, even when the batch was interrupted
+func stopInstance(address string) {
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	global.KillInstanceByAddress(ctx, global.Clients.GetRegistry(), address)
+}
+
+// watchTask polls a task until it reaches an outcome, denying approvals the policy doesn't cover
+func (r *Runner) watchTask(ctx context.Context, manager *task.Manager, t *TaskSpec, res *Result) (string, error) {
+	deadline := time.NewTimer(r.spec.Timeout)
+	defer deadline.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	var deniedTs int64
+	for {
+		select {
+		case <-ctx.Done():
+			cancelTask(manager)
+			return OutcomeCancelled, ctx.Err()
+		case <-deadline.C:
+			cancelTask(manager)
+			return OutcomeTimedOut, fmt.Errorf("task still running after %s", r.spec.Timeout)
+		case <-ticker.C:
+		}
+
+		stateJSON, err := global.FetchInstanceState(ctx, res.Instance)
+		if err != nil {
+			if failures++; failures >= maxStateFailures {
+				return OutcomeFailed, fmt.Errorf("instance stopped responding: %w", err)
+			}
+			continue
+		}
+		failures = 0
+
+		status, err := task.TaskStatusFromState(stateJSON)
+		if err != nil || status == nil || status.TaskID != res.TaskID {
+			continue
+		}
+		messages, err := types.ExtractMessagesFromStateJSON(stateJSON)
+		if err != nil || len(messages) == 0 {
+			continue
+		}
+		last := messages[len(messages)-1]
+
+		r.update(res, func() {
+			res.Phase = status.Status
+			applyUsage(res, messages)
+		})
+
+		switch status.Status {
+		case task.StatusCompleted:
+			return OutcomeSucceeded, nil
+		case task.StatusFailed:
+			return OutcomeFailed, fmt.Errorf("task stopped: %s", lastErrorText(messages, last))
+		case task.StatusWaitingInput:
+			if t.Mode == "plan" && types.AskType(last.Ask) == types.AskTypePlanModeRespond {
+				return OutcomeSucceeded, nil
+			}
+			return OutcomeNeedsInput, fmt.Errorf("task asked: %s", last.Text)
+		case task.StatusWaitingApproval:
+			// Only reached for actions the policy doesn't auto-approve
+			if last.Timestamp == deniedTs {
+				continue
+			}
+			if err := manager.SendMessage(ctx, "Not allowed by the batch approval policy.", nil, nil, "false"); err != nil {
+				return OutcomeFailed, err
+			}
+			deniedTs = last.Timestamp
+			r.update(res, func() { res.DeniedActions++ })
+		}
+	}
+}
+
+// cancelTask stops a task that is given up on
+func cancelTask(manager *task.Manager) {
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	manager.CancelTask(ctx)
+}
+
+// applyUsage records a task's API cost and tokens and its last checkpoint
+func applyUsage(res *Result, messages []*types.ClicaMessage) {
+	usage := types.UsageFromMessages(messages)
+	res.Cost, res.TokensIn, res.TokensOut = usage.Cost, usage.TokensIn, usage.TokensOut
+	for _, msg := range messages {
+		if msg.LastCheckpointHash != "" {
+			res.Checkpoint = msg.LastCheckpointHash
+		}
+	}
+}
+
+// lastErrorText describes why a task stopped, from its last error message if it has one
+func lastErrorText(messages []*types.ClicaMessage, last *types.ClicaMessage) string {
+	if last.Text != "" {
+		return last.Text
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Type == types.MessageTypeSay && messages[i].Say == string(types.SayTypeError) {
+			return messages[i].Text
+		}
+	}
+	return last.Ask
+}
+
+// update changes a result under the runner's lock
+func (r *Runner) update(res *Result, fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn()
+}
+
+// finish records a task's outcome
+func (r *Runner) finish(res *Result, outcome string, err error) {
+	r.update(res, func() {
+		res.Outcome = outcome
+		if err != nil {
+			res.Error = err.Error()
+		}
+		res.Phase = PhaseDone
+		res.FinishedAt = time.Now()
+		if !res.StartedAt.IsZero() {
+			res.Seconds = res.FinishedAt.Sub(res.StartedAt).Round(time.Second).Seconds()
+		}
+	})
+}
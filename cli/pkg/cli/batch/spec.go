@@ -0,0 +1,191 @@
+package batch
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/task"
+	"gopkg.in/yaml.v3"
+)
+
+// Approval modes of a batch task
+const (
+	ApprovalYolo   = "yolo"   // approve every action
+	ApprovalPolicy = "policy" // approve the policy's actions, deny everything else
+)
+
+const (
+	defaultConcurrency = 4
+	defaultTimeout     = 30 * time.Minute
+)
+
+// policyActions are the auto-approval actions a policy may allow
+var policyActions = []string{
+	"read_files",
+	"edit_files",
+	"execute_safe_commands",
+	"execute_all_commands",
+	"use_browser",
+	"use_mcp",
+}
+
+// Spec is a parsed batch file
+type Spec struct {
+	Concurrency int           `yaml:"concurrency"`
+	Approval    string        `yaml:"approval"`
+	Policy      []string      `yaml:"policy"` // auto-approved actions in policy mode
+	Timeout     time.Duration `yaml:"timeout"`
+	Mode        string        `yaml:"mode"`     // default mode of all tasks
+	Settings    []string      `yaml:"settings"` // settings applied to all tasks, before their own
+	Tasks       []*TaskSpec   `yaml:"tasks"`
+}
+
+// TaskSpec is one task of a batch file
+type TaskSpec struct {
+	ID        string   `yaml:"id"`
+	Prompt    string   `yaml:"prompt"`
+	Workspace string   `yaml:"workspace"` // relative to the batch file
+	Mode      string   `yaml:"mode"`
+	Settings  []string `yaml:"settings"`
+	Files     []string `yaml:"files"` // relative to the workspace
+	Approval  string   `yaml:"approval"`
+
+	// Defaults are the user, project and environment settings of the task's workspace,
+	// below the batch file's own. Filled in by the caller, not read from the file.
+	Defaults []string `yaml:"-"`
+}
+
+// LoadSpec reads a batch file, applies defaults and validates it.
+// Workspaces are made absolute relative to the batch file's directory.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %w", err)
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse batch file %s: %w", path, err)
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve batch file path: %w", err)
+	}
+	if err := spec.normalize(filepath.Dir(absPath)); err != nil {
+		return nil, fmt.Errorf("invalid batch file %s: %w", path, err)
+	}
+	return &spec, nil
+}
+
+// normalize fills in defaults and checks every task
+func (s *Spec) normalize(baseDir string) error {
+	if len(s.Tasks) == 0 {
+		return fmt.Errorf("no tasks")
+	}
+	if s.Concurrency < 0 {
+		return fmt.Errorf("concurrency must be positive")
+	}
+	if s.Concurrency == 0 {
+		s.Concurrency = defaultConcurrency
+	}
+	if s.Timeout < 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	if s.Timeout == 0 {
+		s.Timeout = defaultTimeout
+	}
+	if s.Approval == "" {
+		s.Approval = ApprovalYolo
+	}
+	if s.Mode == "" {
+		s.Mode = "act"
+	}
+	if err := validateApproval(s.Approval); err != nil {
+		return err
+	}
+	for _, action := range s.Policy {
+		if !slices.Contains(policyActions, action) {
+			return fmt.Errorf("unknown policy action '%s' (valid: %s)", action, strings.Join(policyActions, ", "))
+		}
+	}
+
+	seen := map[string]bool{}
+	for i, t := range s.Tasks {
+		if t.ID == "" {
+			t.ID = fmt.Sprintf("task-%d", i+1)
+		}
+		if seen[t.ID] {
+			return fmt.Errorf("duplicate task id '%s'", t.ID)
+		}
+		seen[t.ID] = true
+
+		if strings.TrimSpace(t.Prompt) == "" {
+			return fmt.Errorf("task %s: prompt is required", t.ID)
+		}
+
+		if t.Workspace == "" {
+			t.Workspace = baseDir
+		} else if !filepath.IsAbs(t.Workspace) {
+			t.Workspace = filepath.Join(baseDir, t.Workspace)
+		}
+		info, err := os.Stat(t.Workspace)
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("task %s: workspace %s is not a directory", t.ID, t.Workspace)
+		}
+
+		for j, file := range t.Files {
+			if !filepath.IsAbs(file) {
+				t.Files[j] = filepath.Join(t.Workspace, file)
+			}
+		}
+
+		if t.Mode == "" {
+			t.Mode = s.Mode
+		}
+		if t.Mode != "act" && t.Mode != "plan" {
+			return fmt.Errorf("task %s: invalid mode '%s': must be 'act' or 'plan'", t.ID, t.Mode)
+		}
+
+		if t.Approval == "" {
+			t.Approval = s.Approval
+		}
+		if err := validateApproval(t.Approval); err != nil {
+			return fmt.Errorf("task %s: %w", t.ID, err)
+		}
+
+		if _, _, err := task.ParseTaskSettings(s.TaskSettings(t)); err != nil {
+			return fmt.Errorf("task %s: invalid settings: %w", t.ID, err)
+		}
+	}
+	return nil
+}
+
+// TaskSettings returns the settings a task is created with: the task's defaults, the batch
+// settings, the task's own settings and the approval settings, later ones taking precedence
+func (s *Spec) TaskSettings(t *TaskSpec) []string {
+	settings := append([]string{}, t.Defaults...)
+	settings = append(settings, s.Settings...)
+	settings = append(settings, t.Settings...)
+
+	if t.Approval == ApprovalYolo {
+		return append(settings, "yolo_mode_toggled=true")
+	}
+
+	settings = append(settings, "yolo_mode_toggled=false", "auto_approval_settings.enabled=true")
+	for _, action := range policyActions {
+		settings = append(settings, fmt.Sprintf("auto_approval_settings.actions.%s=%t", action, slices.Contains(s.Policy, action)))
+	}
+	return settings
+}
+
+func validateApproval(approval string) error {
+	if approval != ApprovalYolo && approval != ApprovalPolicy {
+		return fmt.Errorf("invalid approval '%s': must be '%s' or '%s'", approval, ApprovalYolo, ApprovalPolicy)
+	}
+	return nil
+}
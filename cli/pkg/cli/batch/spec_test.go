@@ -0,0 +1,109 @@
+package batch
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeBatchFile(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "tasks.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadSpec(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "api"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := writeBatchFile(t, dir, `approval: policy
+policy: [read_files]
+settings:
+  - mode=act
+tasks:
+  - prompt: Upgrade the logger
+    workspace: api
+    files: [go.mod]
+  - id: docs
+    prompt: Fix the typos
+    mode: plan
+    approval: yolo
+`)
+
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.Concurrency != defaultConcurrency || spec.Timeout != defaultTimeout || spec.Mode != "act" {
+		t.Errorf("got concurrency %d, timeout %s, mode %s, want the defaults", spec.Concurrency, spec.Timeout, spec.Mode)
+	}
+
+	api, docs := spec.Tasks[0], spec.Tasks[1]
+	if api.ID != "task-1" || api.Workspace != filepath.Join(dir, "api") || api.Mode != "act" || api.Approval != ApprovalPolicy {
+		t.Errorf("got %+v, want task-1 in api, in act mode with the policy", api)
+	}
+	if want := []string{filepath.Join(dir, "api", "go.mod")}; !reflect.DeepEqual(api.Files, want) {
+		t.Errorf("got files %q, want %q", api.Files, want)
+	}
+	if docs.ID != "docs" || docs.Workspace != dir || docs.Mode != "plan" || docs.Approval != ApprovalYolo {
+		t.Errorf("got %+v, want docs in the batch file's directory, in plan mode with yolo", docs)
+	}
+}
+
+func TestNormalizeRejects(t *testing.T) {
+	for name, tc := range map[string]struct {
+		spec Spec
+		want string
+	}{
+		"no tasks":             {Spec{}, "no tasks"},
+		"negative concurrency": {Spec{Concurrency: -1, Tasks: []*TaskSpec{{Prompt: "p"}}}, "concurrency"},
+		"negative timeout":     {Spec{Timeout: -time.Second, Tasks: []*TaskSpec{{Prompt: "p"}}}, "timeout"},
+		"unknown approval":     {Spec{Approval: "maybe", Tasks: []*TaskSpec{{Prompt: "p"}}}, "invalid approval"},
+		"unknown action":       {Spec{Policy: []string{"launch_rockets"}, Tasks: []*TaskSpec{{Prompt: "p"}}}, "unknown policy action"},
+		"duplicate id":         {Spec{Tasks: []*TaskSpec{{ID: "a", Prompt: "p"}, {ID: "a", Prompt: "p"}}}, "duplicate task id"},
+		"empty prompt":         {Spec{Tasks: []*TaskSpec{{Prompt: "  "}}}, "prompt is required"},
+		"missing workspace":    {Spec{Tasks: []*TaskSpec{{Prompt: "p", Workspace: "missing"}}}, "is not a directory"},
+		"invalid mode":         {Spec{Tasks: []*TaskSpec{{Prompt: "p", Mode: "debug"}}}, "invalid mode"},
+		"invalid settings":     {Spec{Settings: []string{"mdoe=act"}, Tasks: []*TaskSpec{{Prompt: "p"}}}, "invalid settings"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.spec.normalize(t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got error %v, want one containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestTaskSettings(t *testing.T) {
+	spec := &Spec{Settings: []string{"mode=act"}, Policy: []string{"read_files"}}
+
+	yolo := &TaskSpec{Approval: ApprovalYolo, Defaults: []string{"mode=plan"}, Settings: []string{"aws-region=eu-west-1"}}
+	if want := []string{"mode=plan", "mode=act", "aws-region=eu-west-1", "yolo_mode_toggled=true"}; !reflect.DeepEqual(spec.TaskSettings(yolo), want) {
+		t.Errorf("got %q, want %q", spec.TaskSettings(yolo), want)
+	}
+
+	policy := spec.TaskSettings(&TaskSpec{Approval: ApprovalPolicy})
+	for _, want := range []string{
+		"yolo_mode_toggled=false",
+		"auto_approval_settings.enabled=true",
+		"auto_approval_settings.actions.read_files=true",
+		"auto_approval_settings.actions.execute_all_commands=false",
+	} {
+		found := false
+		for _, setting := range policy {
+			found = found || setting == want
+		}
+		if !found {
+			t.Errorf("got %q, want %s", policy, want)
+		}
+	}
+}
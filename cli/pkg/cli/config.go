@@ -2,17 +2,31 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/charmbracelet/huh"
 	"github.com/clica/cli/pkg/cli/config"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/global"
 	"github.com/clica/cli/pkg/cli/task"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var configManager *config.Manager
 
 func ensureConfigManager(ctx context.Context, address string) error {
+	// Accept instance names wherever an address is expected
+	address, err := global.ResolveInstanceAddress(address)
+	if err != nil {
+		return err
+	}
+
 	if configManager == nil || (address != "" && configManager.GetCurrentInstance() != address) {
 		var err error
 		var instanceAddress string
@@ -39,11 +53,14 @@ func ensureConfigManager(ctx context.Context, address string) error {
 			return fmt.Errorf("failed to create config manager: %w", err)
 		}
 
-		// Always set the instance we're using as the default
-		registry := global.Clients.GetRegistry()
-		if err := registry.SetDefaultInstance(instanceAddress); err != nil {
-			// Log warning but don't fail - this is not critical
-			fmt.Printf("Warning: failed to set default instance: %v\n", err)
+		// Set the instance we're using as the default, unless it was only picked
+		// because its workspace contains the current directory
+		if address != "" || instanceAddress != global.WorkspaceInstance() {
+			registry := global.Clients.GetRegistry()
+			if err := registry.SetDefaultInstance(instanceAddress); err != nil {
+				// Log warning but don't fail - this is not critical
+				fmt.Printf("Warning: failed to set default instance: %v\n", err)
+			}
 		}
 	}
 	return nil
@@ -60,6 +77,12 @@ func NewConfigCommand() *cobra.Command {
 	cmd.AddCommand(newConfigListCommand())
 	cmd.AddCommand(newConfigGetCommand())
 	cmd.AddCommand(setCommand())
+	cmd.AddCommand(newConfigValidateCommand())
+	cmd.AddCommand(newConfigExplainCommand())
+	cmd.AddCommand(newConfigListKeysCommand())
+	cmd.AddCommand(newConfigExportCommand())
+	cmd.AddCommand(newConfigImportCommand())
+	cmd.AddCommand(newConfigDiffCommand())
 
 	return cmd
 }
@@ -147,3 +170,410 @@ func setCommand() *cobra.Command {
 	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to use")
 	return cmd
 }
+
+func newConfigValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "validate",
+		Aliases: []string{"v"},
+		Short:   "Validate config files and environment settings",
+		Long: `Validate the settings from the user config (~/.clica/config.yaml), the project config
+(.clica.yaml in the current directory or any parent) and CLICA_SETTING_* environment variables.
+Config files are YAML; TOML isn't supported.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolved, err := config.ResolveSettings(nil)
+			if err != nil {
+				return err
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+
+			if len(resolved.Files) == 0 {
+				fmt.Println(renderer.Dim("No config files found"))
+			}
+			for _, path := range resolved.Files {
+				fmt.Printf("Loaded %s\n", path)
+			}
+
+			if err := resolved.Validate(); err != nil {
+				fmt.Println(renderer.ErrorWithX("Invalid settings:"))
+				for _, line := range strings.Split(err.Error(), "\n") {
+					fmt.Printf("  %s\n", line)
+				}
+				return fmt.Errorf("configuration is invalid")
+			}
+
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("%d settings are valid", len(resolved.Layers))))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newConfigExplainCommand() *cobra.Command {
+	var settings []string
+
+	cmd := &cobra.Command{
+		Use:     "explain <key>",
+		Aliases: []string{"e"},
+		Short:   "Show where a setting's value comes from",
+		Long: `Show the effective value of a setting and every layer that sets it.
+Precedence is flag > env > project > user.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolved, err := config.ResolveSettings(settings)
+			if err != nil {
+				return err
+			}
+
+			entries := resolved.Explain(args[0])
+
+			if global.Config.OutputFormat == "json" {
+				data, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal settings: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(entries) == 0 {
+				fmt.Printf("%s is not set in any config file, environment variable or flag\n", args[0])
+				return nil
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			for i, entry := range entries {
+				if i == 0 {
+					fmt.Printf("%s = %s\n", renderer.Bold(entry.Key), renderer.Green(entry.Value))
+					fmt.Printf("  from %s (%s)\n", entry.Source, entry.Origin)
+					continue
+				}
+				fmt.Println(renderer.Dim(fmt.Sprintf("  overrides %s = %s from %s (%s)", entry.Key, entry.Value, entry.Source, entry.Origin)))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&settings, "setting", "s", nil, "settings to include as flags (key=value format)")
+	return cmd
+}
+
+func newConfigListKeysCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list-keys [filter]",
+		Aliases: []string{"keys"},
+		Short:   "List the keys accepted by -s flags and config files",
+		Long: `List every setting key accepted by -s flags, 'clica config set' and config files,
+with its type and a short description. Pass a filter to only show keys containing it.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keys := task.ListSettingKeys()
+			if len(args) == 1 {
+				filter := strings.ToLower(strings.ReplaceAll(args[0], "_", "-"))
+				var filtered []task.SettingKey
+				for _, key := range keys {
+					if strings.Contains(key.Key, filter) {
+						filtered = append(filtered, key)
+					}
+				}
+				keys = filtered
+			}
+
+			if global.Config.OutputFormat == "json" {
+				data, err := json.MarshalIndent(keys, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal setting keys: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(keys) == 0 && len(args) == 1 {
+				fmt.Printf("No setting keys match '%s'\n", args[0])
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "KEY\tTYPE\tDESCRIPTION")
+			for _, key := range keys {
+				description := key.Description
+				if len(key.Values) > 0 {
+					description = fmt.Sprintf("%s (one of: %s)", description, strings.Join(key.Values, ", "))
+				}
+				keyType := key.Type
+				if key.Secret {
+					keyType += ", secret"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\n", key.Key, keyType, description)
+			}
+			w.Flush()
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// exportPassphraseEnv is read before prompting for the passphrase that protects exported secrets
+const exportPassphraseEnv = "CLICA_EXPORT_PASSPHRASE"
+
+func newConfigExportCommand() *cobra.Command {
+	var (
+		address        string
+		outputPath     string
+		includeSecrets bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export instance settings to JSON",
+		Long: `Export the provider, model, auto-approval, browser and general settings of an instance
+as JSON, to stdout or a file. The result can be applied elsewhere with 'clica config import'.
+
+API keys and other secrets are excluded unless --include-secrets is set, in which case they
+are encrypted with a passphrase (read from ` + exportPassphraseEnv + ` or prompted for).
+
+Examples:
+  clica config export > settings.json
+  clica config export --include-secrets -o settings.json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			passphrase := ""
+			if includeSecrets {
+				var err error
+				passphrase, err = getExportPassphrase(true)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := ensureConfigManager(ctx, address); err != nil {
+				return err
+			}
+
+			export, err := configManager.ExportSettings(ctx, passphrase)
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(export, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal settings: %w", err)
+			}
+
+			if outputPath == "" {
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if err := os.WriteFile(outputPath, data, 0600); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outputPath, err)
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Exported %d settings from %s to %s", len(export.Settings), configManager.GetCurrentInstance(), outputPath)))
+			if export.HasSecrets() {
+				fmt.Println(renderer.Dim("Secrets are encrypted, the same passphrase is needed to import them"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to use")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "file to write the settings to (defaults to stdout)")
+	cmd.Flags().BoolVar(&includeSecrets, "include-secrets", false, "include API keys and other secrets, encrypted with a passphrase")
+
+	return cmd
+}
+
+func newConfigImportCommand() *cobra.Command {
+	var (
+		address     string
+		skipSecrets bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import instance settings from JSON",
+		Long: `Apply settings written by 'clica config export' to an instance. Use - to read from stdin.
+
+Encrypted secrets are decrypted with a passphrase read from ` + exportPassphraseEnv + ` or prompted for.
+Use --skip-secrets to import only the other settings.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", args[0], err)
+			}
+
+			export, err := config.ParseSettingsExport(data)
+			if err != nil {
+				return err
+			}
+
+			passphrase := ""
+			if export.HasSecrets() && !skipSecrets {
+				passphrase, err = getExportPassphrase(false)
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := ensureConfigManager(ctx, address); err != nil {
+				return err
+			}
+
+			count, err := configManager.ImportSettings(ctx, export, passphrase)
+			if err != nil {
+				return err
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Imported %d settings into %s", count, configManager.GetCurrentInstance())))
+			if export.HasSecrets() && skipSecrets {
+				fmt.Println(renderer.Dim("Encrypted secrets were skipped"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to use")
+	cmd.Flags().BoolVar(&skipSecrets, "skip-secrets", false, "don't import encrypted secrets")
+
+	return cmd
+}
+
+func newConfigDiffCommand() *cobra.Command {
+	var (
+		addresses   []string
+		showSecrets bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "diff --address <a> [--address <b>]",
+		Short: "Compare the settings of two instances",
+		Long: `Show the settings that differ between two instances. With a single --address,
+that instance is compared with the default instance. Secret values are censored
+unless --show-secrets is set.
+
+Examples:
+  clica config diff --address localhost:50052 --address localhost:50053
+  clica config diff --address localhost:50053
+  clica config diff --address api-refactor --address web`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			switch len(addresses) {
+			case 1:
+				addresses = []string{"", addresses[0]}
+			case 2:
+			default:
+				return fmt.Errorf("diff requires one or two --address flags")
+			}
+
+			var labels []string
+			var snapshots [][]string
+			for _, ref := range addresses {
+				address, err := global.ResolveInstanceAddress(ref)
+				if err != nil {
+					return err
+				}
+				manager, err := config.NewManager(ctx, address)
+				if err != nil {
+					return fmt.Errorf("failed to create config manager: %w", err)
+				}
+				snapshot, err := manager.SnapshotSettings(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to read settings from %s: %w", manager.GetCurrentInstance(), err)
+				}
+				labels = append(labels, manager.GetCurrentInstance())
+				snapshots = append(snapshots, snapshot)
+			}
+
+			diffs := config.DiffSettings(snapshots[0], snapshots[1])
+
+			if global.Config.OutputFormat == "json" {
+				if !showSecrets {
+					for i := range diffs {
+						if task.IsSecretSetting(diffs[i].Key) {
+							diffs[i].Left, diffs[i].Right = "********", "********"
+						}
+					}
+				}
+				data, err := json.MarshalIndent(diffs, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal diff: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			config.RenderSettingsDiff(labels[0], labels[1], diffs, !showSecrets)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&addresses, "address", nil, "instance address or name to compare (repeat for the second instance)")
+	cmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "show secret values instead of censoring them")
+
+	return cmd
+}
+
+// getExportPassphrase reads the passphrase for exported secrets from the environment,
+// or prompts for it. When confirm is set the passphrase must be entered twice.
+func getExportPassphrase(confirm bool) (string, error) {
+	if passphrase := os.Getenv(exportPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("a passphrase is required for secrets: set %s or run interactively", exportPassphraseEnv)
+	}
+
+	var passphrase, confirmation string
+	fields := []huh.Field{
+		huh.NewInput().
+			Title("Passphrase for secrets").
+			EchoMode(huh.EchoModePassword).
+			Value(&passphrase).
+			Validate(func(s string) error {
+				if s == "" {
+					return fmt.Errorf("passphrase cannot be empty")
+				}
+				return nil
+			}),
+	}
+	if confirm {
+		fields = append(fields, huh.NewInput().
+			Title("Confirm passphrase").
+			EchoMode(huh.EchoModePassword).
+			Value(&confirmation).
+			Validate(func(s string) error {
+				if s != passphrase {
+					return fmt.Errorf("passphrases don't match")
+				}
+				return nil
+			}))
+	}
+
+	if err := display.Form(huh.NewForm(huh.NewGroup(fields...))).Run(); err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+
+	return passphrase, nil
+}
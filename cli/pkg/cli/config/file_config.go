@@ -0,0 +1,450 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/handlers"
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/clica/cli/pkg/cli/theme"
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFileNames are the file names searched for from the working directory upward.
+// Config files are YAML; TOML isn't supported.
+var ProjectConfigFileNames = []string{".clica.yaml", ".clica.yml"}
+
+// SettingsEnvPrefix is the prefix for settings given through environment variables.
+// Underscores map to dashes and double underscores to dots, e.g.
+// CLICA_SETTING_AUTO_APPROVAL_SETTINGS__ENABLED=true -> auto-approval-settings.enabled=true
+const SettingsEnvPrefix = "CLICA_SETTING_"
+
+// MessageHandlersPrefix is the settings namespace of subprocess message handlers.
+// These settings configure the CLI itself and aren't sent to instances.
+const MessageHandlersPrefix = "message-handlers."
+
+// ThemeSetting selects the color theme when --theme isn't given. Like message handlers,
+// it configures the CLI itself.
+const ThemeSetting = "theme"
+
+// SettingSource identifies the configuration layer a setting came from
+type SettingSource int
+
+// Sources in increasing order of precedence
+const (
+	SourceUser SettingSource = iota
+	SourceProject
+	SourceEnv
+	SourceFlag
+)
+
+// String returns a human-readable name for the source
+func (s SettingSource) String() string {
+	switch s {
+	case SourceUser:
+		return "user config"
+	case SourceProject:
+		return "project config"
+	case SourceEnv:
+		return "environment"
+	case SourceFlag:
+		return "flag"
+	default:
+		return "unknown"
+	}
+}
+
+// LayeredSetting is a single key=value from one configuration layer
+type LayeredSetting struct {
+	Key    string        `json:"key"` // kebab-case key, dotted for nested settings
+	Value  string        `json:"value"`
+	Source SettingSource `json:"-"`
+	Origin string        `json:"origin"` // file path, environment variable or flag
+}
+
+// String returns the setting in -s flag format
+func (s LayeredSetting) String() string {
+	return s.Key + "=" + s.Value
+}
+
+// ResolvedSettings holds settings from all configuration layers.
+// Precedence is flag > env > project > user.
+type ResolvedSettings struct {
+	Layers []LayeredSetting // all settings, lowest precedence first
+	Files  []string         // config files that were loaded
+}
+
+// ResolveSettings loads the user and project config files and environment variables
+// and layers the given -s flag settings on top.
+func ResolveSettings(flagSettings []string) (*ResolvedSettings, error) {
+	cwd, _ := os.Getwd()
+	return ResolveSettingsIn(cwd, flagSettings)
+}
+
+// ResolveSettingsIn is ResolveSettings with the project config looked up from dir instead
+// of the current directory. An empty dir skips the project config.
+func ResolveSettingsIn(dir string, flagSettings []string) (*ResolvedSettings, error) {
+	resolved := &ResolvedSettings{}
+
+	// User config (~/.clica/config.yaml)
+	if path := FindUserConfigFile(); path != "" {
+		settings, err := loadSettingsFile(path, SourceUser)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Layers = append(resolved.Layers, settings...)
+		resolved.Files = append(resolved.Files, path)
+	}
+
+	// Project config (.clica.yaml in dir or any parent)
+	if dir != "" {
+		if path := FindProjectConfigFile(dir); path != "" {
+			settings, err := loadSettingsFile(path, SourceProject)
+			if err != nil {
+				return nil, err
+			}
+			resolved.Layers = append(resolved.Layers, settings...)
+			resolved.Files = append(resolved.Files, path)
+		}
+	}
+
+	// Environment variables
+	resolved.Layers = append(resolved.Layers, loadSettingsEnv(os.Environ())...)
+
+	// -s flags
+	for _, flag := range flagSettings {
+		key, value, ok := strings.Cut(flag, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid setting format '%s': expected key=value", flag)
+		}
+		resolved.Layers = append(resolved.Layers, LayeredSetting{
+			Key:    normalizeSettingKey(key),
+			Value:  strings.TrimSpace(value),
+			Source: SourceFlag,
+			Origin: "-s flag",
+		})
+	}
+
+	if global.Config != nil && global.Config.Verbose && len(resolved.Files) > 0 {
+		fmt.Printf("[DEBUG] Loaded config files: %v\n", resolved.Files)
+	}
+
+	return resolved, nil
+}
+
+// FindProjectConfigFile walks from startDir up to the filesystem root and returns
+// the first project config file found, or "" if there is none.
+func FindProjectConfigFile(startDir string) string {
+	dir := startDir
+	for {
+		for _, name := range ProjectConfigFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// FindUserConfigFile returns the user-level config file (~/.clica/config.yaml), or "" if it doesn't exist
+func FindUserConfigFile() string {
+	if global.Config == nil {
+		return ""
+	}
+	for _, name := range []string{"config.yaml", "config.yml"} {
+		path := filepath.Join(global.Config.ConfigPath, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Effective returns the winning value for each key, sorted by key
+func (r *ResolvedSettings) Effective() []LayeredSetting {
+	winners := make(map[string]LayeredSetting)
+	for _, setting := range r.Layers {
+		// Later layers have higher precedence
+		winners[setting.Key] = setting
+	}
+
+	result := make([]LayeredSetting, 0, len(winners))
+	for _, setting := range winners {
+		result = append(result, setting)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// Flags returns the effective task settings in -s flag format for ParseTaskSettings
+func (r *ResolvedSettings) Flags() []string {
+	effective := r.Effective()
+	flags := make([]string, 0, len(effective))
+	for _, setting := range effective {
+		if !isCLISetting(setting.Key) {
+			flags = append(flags, setting.String())
+		}
+	}
+	return flags
+}
+
+// Section returns the effective settings under a key prefix, keyed without the prefix
+func (r *ResolvedSettings) Section(prefix string) map[string]string {
+	section := make(map[string]string)
+	for _, setting := range r.Effective() {
+		if key, ok := strings.CutPrefix(setting.Key, prefix); ok {
+			section[key] = setting.Value
+		}
+	}
+	return section
+}
+
+// MessageHandlers returns the subprocess message handlers configured under message-handlers in
+// the user config. Handlers run commands, so only the user config may set them: the settings
+// found in a cloned repository's project config, the environment or a flag are returned as
+// ignored instead.
+func (r *ResolvedSettings) MessageHandlers() ([]handlers.SubprocessConfig, []LayeredSetting, error) {
+	section := make(map[string]string)
+	var ignored []LayeredSetting
+	for _, setting := range r.Layers {
+		key, ok := strings.CutPrefix(setting.Key, MessageHandlersPrefix)
+		if !ok {
+			continue
+		}
+		if setting.Source != SourceUser {
+			ignored = append(ignored, setting)
+			continue
+		}
+		section[key] = setting.Value
+	}
+
+	configs, err := handlers.ParseSubprocessConfigs(section)
+	return configs, ignored, err
+}
+
+// IgnoredMessageHandlerError describes a message handler setting outside the user config
+func IgnoredMessageHandlerError(setting LayeredSetting) error {
+	return fmt.Errorf("%s (%s): message handlers can only be set in the user config", setting.Key, setting.Origin)
+}
+
+// Get returns the effective value for a key
+func (r *ResolvedSettings) Get(key string) (LayeredSetting, bool) {
+	key = normalizeSettingKey(key)
+	for i := len(r.Layers) - 1; i >= 0; i-- {
+		if r.Layers[i].Key == key {
+			return r.Layers[i], true
+		}
+	}
+	return LayeredSetting{}, false
+}
+
+// Explain returns every value set for a key, highest precedence first.
+// The first entry is the effective value; the rest are overridden.
+func (r *ResolvedSettings) Explain(key string) []LayeredSetting {
+	key = normalizeSettingKey(key)
+	var result []LayeredSetting
+	for i := len(r.Layers) - 1; i >= 0; i-- {
+		if r.Layers[i].Key == key {
+			result = append(result, r.Layers[i])
+		}
+	}
+	return result
+}
+
+// Validate checks every setting in every layer against the settings parser.
+// All problems are reported together, each with the place it was set.
+func (r *ResolvedSettings) Validate() error {
+	var errs []error
+	for _, setting := range r.Layers {
+		if isCLISetting(setting.Key) {
+			continue
+		}
+		if _, _, err := task.ParseTaskSettings([]string{setting.String()}); err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", setting.Key, setting.Origin, err))
+		}
+	}
+	_, ignored, err := r.MessageHandlers()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, setting := range ignored {
+		errs = append(errs, IgnoredMessageHandlerError(setting))
+	}
+	if setting, ok := r.Get(ThemeSetting); ok {
+		configDir := ""
+		if global.Config != nil {
+			configDir = global.Config.ConfigPath
+		}
+		if _, err := theme.Load(setting.Value, configDir); err != nil {
+			errs = append(errs, fmt.Errorf("%s (%s): %w", setting.Key, setting.Origin, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// isCLISetting reports whether a setting configures the CLI rather than tasks
+func isCLISetting(key string) bool {
+	return key == ThemeSetting || strings.HasPrefix(key, MessageHandlersPrefix)
+}
+
+// LoadTheme loads the theme named by --theme, or else by the theme setting of the
+// config files and environment. Config files that fail to load leave the default theme.
+func LoadTheme(flagValue string) (*theme.Theme, error) {
+	ref := flagValue
+	if ref == "" {
+		if settings, err := ResolveSettings(nil); err == nil {
+			if setting, ok := settings.Get(ThemeSetting); ok {
+				ref = setting.Value
+			}
+		}
+	}
+
+	configDir := ""
+	if global.Config != nil {
+		configDir = global.Config.ConfigPath
+	}
+	return theme.Load(ref, configDir)
+}
+
+// loadSettingsFile reads a YAML config file and flattens it into settings
+func loadSettingsFile(path string, source SettingSource) ([]LayeredSetting, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var content map[string]interface{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	settings, err := flattenConfigFile("", content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	for i := range settings {
+		settings[i].Source = source
+		settings[i].Origin = path
+	}
+
+	// Sort by key, map iteration order would make list and explain output vary between runs
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+
+	return settings, nil
+}
+
+// isMapSetting reports whether a key refers to a map field, see flattenConfigFile
+var isMapSetting = task.IsMapSetting
+
+// flattenConfigFile converts nested YAML maps into dotted kebab-case keys. YAML maps for map
+// fields are values rather than nesting and are passed as comma-separated key:value pairs.
+func flattenConfigFile(prefix string, data map[string]interface{}) ([]LayeredSetting, error) {
+	var settings []LayeredSetting
+	for key, value := range data {
+		fullKey := prefix + normalizeSettingKey(key)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if isMapSetting(fullKey) {
+				pairs, err := mapSettingValue(fullKey, v)
+				if err != nil {
+					return nil, err
+				}
+				settings = append(settings, LayeredSetting{Key: fullKey, Value: pairs})
+				continue
+			}
+			nested, err := flattenConfigFile(fullKey+".", v)
+			if err != nil {
+				return nil, err
+			}
+			settings = append(settings, nested...)
+		case string:
+			settings = append(settings, LayeredSetting{Key: fullKey, Value: v})
+		case bool:
+			settings = append(settings, LayeredSetting{Key: fullKey, Value: strconv.FormatBool(v)})
+		case int:
+			settings = append(settings, LayeredSetting{Key: fullKey, Value: strconv.Itoa(v)})
+		case float64:
+			settings = append(settings, LayeredSetting{Key: fullKey, Value: strconv.FormatFloat(v, 'f', -1, 64)})
+		case []interface{}:
+			// Lists are passed to the settings parser as comma-separated values
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				switch item.(type) {
+				case string, bool, int, float64:
+					items = append(items, fmt.Sprint(item))
+				default:
+					return nil, fmt.Errorf("unsupported list item for '%s': only lists of plain values are supported", fullKey)
+				}
+			}
+			settings = append(settings, LayeredSetting{Key: fullKey, Value: strings.Join(items, ",")})
+		case nil:
+			// Empty values are ignored so keys can be left as placeholders
+		default:
+			return nil, fmt.Errorf("unsupported value for '%s'", fullKey)
+		}
+	}
+	return settings, nil
+}
+
+// mapSettingValue formats the entries of a map field as key:value pairs, sorted by key
+func mapSettingValue(key string, entries map[string]interface{}) (string, error) {
+	pairs := make([]string, 0, len(entries))
+	for k, v := range entries {
+		switch v.(type) {
+		case string, bool, int, float64:
+			pairs = append(pairs, fmt.Sprintf("%s:%v", k, v))
+		default:
+			return "", fmt.Errorf("unsupported map value for '%s.%s': only maps of plain values are supported", key, k)
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ","), nil
+}
+
+// loadSettingsEnv extracts CLICA_SETTING_* environment variables
+func loadSettingsEnv(environ []string) []LayeredSetting {
+	var settings []LayeredSetting
+	for _, entry := range environ {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || !strings.HasPrefix(name, SettingsEnvPrefix) {
+			continue
+		}
+
+		key := strings.TrimPrefix(name, SettingsEnvPrefix)
+		key = strings.ReplaceAll(key, "__", ".")
+		settings = append(settings, LayeredSetting{
+			Key:    normalizeSettingKey(key),
+			Value:  value,
+			Source: SourceEnv,
+			Origin: name,
+		})
+	}
+
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings
+}
+
+// normalizeSettingKey converts a key in snake, kebab or upper case to lowercase kebab-case
+func normalizeSettingKey(key string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(key), "_", "-"))
+}
@@ -33,7 +33,7 @@ func NewManager(ctx context.Context, address string) (*Manager, error) {
 	// Get the actual address being used
 	clientAddress := address
 	if address == "" && global.Clients != nil {
-		clientAddress = global.Clients.GetRegistry().GetDefaultInstance()
+		clientAddress = global.CurrentInstanceAddress()
 	}
 
 	return &Manager{
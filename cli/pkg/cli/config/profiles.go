@@ -0,0 +1,294 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/clica/cli/pkg/common"
+	"github.com/clica/grpc-go/clica"
+)
+
+// Profile is a named snapshot of provider, model, auto-approval and browser settings.
+// Settings are stored in the same key=value format accepted by -s flags.
+type Profile struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Settings  []string  `json:"settings"`
+}
+
+// profileNamePattern restricts profile names to safe file names
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// ValidateProfileName checks that a profile name can be used as a file name
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s': use letters, numbers, '.', '-' and '_'", name)
+	}
+	return nil
+}
+
+// GetProfilesDir returns the directory where profiles are stored
+func GetProfilesDir() string {
+	return filepath.Join(global.Config.ConfigPath, common.SETTINGS_SUBFOLDER, "profiles")
+}
+
+// profilePath returns the file path for a named profile
+func profilePath(name string) string {
+	return filepath.Join(GetProfilesDir(), name+".json")
+}
+
+// ProfileExists reports whether a profile with the given name is stored
+func ProfileExists(name string) bool {
+	_, err := os.Stat(profilePath(name))
+	return err == nil
+}
+
+// SaveProfile writes a profile to the profiles directory.
+// Profiles may contain API keys, so they are only readable by the current user.
+func SaveProfile(profile *Profile) error {
+	if err := ValidateProfileName(profile.Name); err != nil {
+		return err
+	}
+
+	if _, _, err := task.ParseTaskSettings(profile.Settings); err != nil {
+		return fmt.Errorf("profile '%s' contains invalid settings: %w", profile.Name, err)
+	}
+
+	if err := os.MkdirAll(GetProfilesDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create profiles directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile: %w", err)
+	}
+
+	if err := os.WriteFile(profilePath(profile.Name), data, 0600); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	return nil
+}
+
+// LoadProfile reads a named profile from the profiles directory
+func LoadProfile(name string) (*Profile, error) {
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(profilePath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("profile '%s' not found", name)
+		}
+		return nil, fmt.Errorf("failed to read profile: %w", err)
+	}
+
+	return ParseProfile(data)
+}
+
+// ParseProfile decodes and validates a profile from JSON
+func ParseProfile(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to parse profile: %w", err)
+	}
+
+	if _, _, err := task.ParseTaskSettings(profile.Settings); err != nil {
+		return nil, fmt.Errorf("profile contains invalid settings: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// ListProfiles returns all stored profiles sorted by name
+func ListProfiles() ([]*Profile, error) {
+	entries, err := os.ReadDir(GetProfilesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Profile{}, nil
+		}
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	var profiles []*Profile
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		profile, err := LoadProfile(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			if global.Config.Verbose {
+				fmt.Printf("Warning: skipping profile %s: %v\n", entry.Name(), err)
+			}
+			continue
+		}
+		profiles = append(profiles, profile)
+	}
+
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+
+	return profiles, nil
+}
+
+// DeleteProfile removes a stored profile
+func DeleteProfile(name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	if err := os.Remove(profilePath(name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("profile '%s' not found", name)
+		}
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+
+	return nil
+}
+
+// WithoutSecrets returns a copy of the profile with API keys and other secrets removed
+func (p *Profile) WithoutSecrets() *Profile {
+	stripped := &Profile{Name: p.Name, CreatedAt: p.CreatedAt}
+	for _, setting := range p.Settings {
+		key, _, _ := strings.Cut(setting, "=")
+		if !task.IsSecretSetting(key) {
+			stripped.Settings = append(stripped.Settings, setting)
+		}
+	}
+	return stripped
+}
+
+// GetSetting returns the value of a setting in the profile, if present
+func (p *Profile) GetSetting(key string) (string, bool) {
+	for _, setting := range p.Settings {
+		k, v, _ := strings.Cut(setting, "=")
+		if strings.TrimSpace(k) == key {
+			return strings.TrimSpace(v), true
+		}
+	}
+	return "", false
+}
+
+// SnapshotProfile captures the provider, model, auto-approval and browser settings
+// currently configured on the instance. Fields that can't be expressed as -s flags
+// (e.g. model info objects) are skipped.
+func (m *Manager) SnapshotProfile(ctx context.Context, name string) (*Profile, error) {
+	stateData, err := m.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings []string
+
+	// Provider and model configuration (includes API keys)
+	if apiConfig, ok := stateData["apiConfiguration"].(map[string]interface{}); ok {
+		settings = append(settings, flattenProfileSettings("", apiConfig)...)
+	}
+
+	// Auto-approval and browser settings
+	for _, field := range []string{"autoApprovalSettings", "browserSettings"} {
+		if value, ok := stateData[field].(map[string]interface{}); ok {
+			settings = append(settings, flattenProfileSettings(camelToKebab(field)+".", value)...)
+		}
+	}
+
+	return &Profile{
+		Name:      name,
+		CreatedAt: time.Now(),
+		Settings:  filterValidSettings(settings),
+	}, nil
+}
+
+// ApplyProfile applies a profile's settings and secrets to the instance via UpdateSettingsCli
+func (m *Manager) ApplyProfile(ctx context.Context, profile *Profile) error {
+	settings, secrets, err := task.ParseTaskSettings(profile.Settings)
+	if err != nil {
+		return fmt.Errorf("failed to parse profile settings: %w", err)
+	}
+	if settings == nil && secrets == nil {
+		return nil
+	}
+
+	_, err = m.client.State.UpdateSettingsCli(ctx, &clica.UpdateSettingsRequestCli{
+		Metadata: &clica.Metadata{},
+		Settings: settings,
+		Secrets:  secrets,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply profile '%s': %w", profile.Name, err)
+	}
+
+	if global.Config.Verbose {
+		fmt.Printf("Applied profile '%s' to %s\n", profile.Name, m.clientAddress)
+	}
+	return nil
+}
+
+// filterValidSettings keeps only the settings the parser accepts, so a snapshot
+// can always be applied, and sorts them by key
+func filterValidSettings(settings []string) []string {
+	var valid []string
+	for _, setting := range settings {
+		if _, _, err := task.ParseTaskSettings([]string{setting}); err != nil {
+			if global.Config.Verbose {
+				key, _, _ := strings.Cut(setting, "=")
+				fmt.Printf("[DEBUG] Skipping setting %s: %v\n", key, err)
+			}
+			continue
+		}
+		valid = append(valid, setting)
+	}
+	sort.Strings(valid)
+	return valid
+}
+
+// flattenProfileSettings converts a state object into kebab-case key=value settings.
+// Nested objects become dotted keys; empty values and lists are skipped.
+func flattenProfileSettings(prefix string, data map[string]interface{}) []string {
+	var settings []string
+	for key, value := range data {
+		fullKey := prefix + camelToKebab(key)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			// Browser viewport is flattened into viewport-width/viewport-height
+			if key == "viewport" {
+				for dim, dimValue := range v {
+					if n, ok := dimValue.(float64); ok {
+						settings = append(settings, fmt.Sprintf("%sviewport-%s=%s", prefix, camelToKebab(dim), strconv.FormatFloat(n, 'f', -1, 64)))
+					}
+				}
+				continue
+			}
+			settings = append(settings, flattenProfileSettings(fullKey+".", v)...)
+		case string:
+			if v == "" {
+				continue
+			}
+			// Provider IDs use dashes in state but underscores in the settings parser
+			if strings.HasSuffix(key, "ApiProvider") {
+				v = strings.ReplaceAll(v, "-", "_")
+			}
+			settings = append(settings, fmt.Sprintf("%s=%s", fullKey, v))
+		case bool:
+			settings = append(settings, fmt.Sprintf("%s=%t", fullKey, v))
+		case float64:
+			settings = append(settings, fmt.Sprintf("%s=%s", fullKey, strconv.FormatFloat(v, 'f', -1, 64)))
+		}
+	}
+	return settings
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"strings"
+
+	"github.com/clica/cli/pkg/cli/task"
 )
 
 // sensitiveKeywords defines field name patterns that should be censored
@@ -196,3 +198,30 @@ func renderAutoApprovalSettings(value interface{}, censor bool) error {
 
 	return nil
 }
+
+// RenderSettingsDiff renders key-level differences between two instances
+func RenderSettingsDiff(leftLabel, rightLabel string, diffs []SettingDiff, censor bool) {
+	if len(diffs) == 0 {
+		fmt.Printf("No differences between %s and %s\n", leftLabel, rightLabel)
+		return
+	}
+
+	fmt.Printf("--- %s\n", leftLabel)
+	fmt.Printf("+++ %s\n", rightLabel)
+	for _, diff := range diffs {
+		fmt.Printf("%s:\n", diff.Key)
+		fmt.Printf("  - %s\n", formatDiffValue(diff.Key, diff.Left, diff.HasLeft, censor))
+		fmt.Printf("  + %s\n", formatDiffValue(diff.Key, diff.Right, diff.HasRight, censor))
+	}
+}
+
+// formatDiffValue formats one side of a setting diff, censoring secrets
+func formatDiffValue(key, value string, present, censor bool) string {
+	if !present {
+		return "(not set)"
+	}
+	if censor && task.IsSecretSetting(key) {
+		return "********"
+	}
+	return formatValue(value, key, censor)
+}
@@ -0,0 +1,344 @@
+package config
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/clica/grpc-go/clica"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// SettingsExportVersion is the current version of the settings export format
+const SettingsExportVersion = 1
+
+// exportedStateFields are the top-level state fields included in exports and diffs,
+// in addition to apiConfiguration, autoApprovalSettings and browserSettings
+var exportedStateFields = []string{
+	"telemetrySetting",
+	"planActSeparateModelsSetting",
+	"enableCheckpointsSetting",
+	"shellIntegrationTimeout",
+	"terminalOutputLineLimit",
+	"mode",
+	"preferredLanguage",
+	"openaiReasoningEffort",
+	"strictPlanModeEnabled",
+	"useAutoCondense",
+	"customPrompt",
+	"defaultTerminalProfile",
+	"yoloModeToggled",
+	"autoCondenseThreshold",
+	"maxConsecutiveMistakes",
+	"subagentsEnabled",
+	"subagentTerminalOutputLineLimit",
+}
+
+// SettingsExport is the file format written by 'clica config export'.
+// Settings use the key=value format accepted by -s flags. Secrets are only
+// included when requested, and are always encrypted with a passphrase.
+type SettingsExport struct {
+	Version          int       `json:"version"`
+	ExportedAt       time.Time `json:"exported_at"`
+	Source           string    `json:"source,omitempty"`
+	Settings         []string  `json:"settings"`
+	EncryptedSecrets string    `json:"encrypted_secrets,omitempty"`
+}
+
+// SettingDiff is a key whose value differs between two instances.
+// A missing value is reported as an empty string with the matching Has flag unset.
+type SettingDiff struct {
+	Key      string `json:"key"`
+	Left     string `json:"left,omitempty"`
+	Right    string `json:"right,omitempty"`
+	HasLeft  bool   `json:"has_left"`
+	HasRight bool   `json:"has_right"`
+}
+
+// secretsEnvelope is the encrypted form of exported secrets
+type secretsEnvelope struct {
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Iterations int    `json:"iterations"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// exportKeyIterations is the PBKDF2 iteration count for new exports
+const exportKeyIterations = 600000
+
+// maxExportKeyIterations bounds the iteration count read from an export file, so a crafted
+// file can't make the import derive a key for hours
+const maxExportKeyIterations = 10 * exportKeyIterations
+
+// SnapshotSettings returns the instance's settings as sorted key=value pairs.
+// Only settings the parser accepts are returned, so the result can always be applied.
+func (m *Manager) SnapshotSettings(ctx context.Context) ([]string, error) {
+	stateData, err := m.GetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings []string
+
+	// Provider and model configuration (includes API keys)
+	if apiConfig, ok := stateData["apiConfiguration"].(map[string]interface{}); ok {
+		settings = append(settings, flattenProfileSettings("", apiConfig)...)
+	}
+
+	// Auto-approval and browser settings
+	for _, field := range []string{"autoApprovalSettings", "browserSettings"} {
+		if value, ok := stateData[field].(map[string]interface{}); ok {
+			settings = append(settings, flattenProfileSettings(camelToKebab(field)+".", value)...)
+		}
+	}
+
+	// Simple top-level settings
+	topLevel := make(map[string]interface{})
+	for _, field := range exportedStateFields {
+		if value, ok := stateData[field]; ok {
+			topLevel[field] = value
+		}
+	}
+	settings = append(settings, flattenProfileSettings("", topLevel)...)
+
+	return filterValidSettings(settings), nil
+}
+
+// ExportSettings snapshots the instance's settings. Secrets are left out unless
+// a passphrase is given, in which case they are encrypted with it.
+func (m *Manager) ExportSettings(ctx context.Context, passphrase string) (*SettingsExport, error) {
+	snapshot, err := m.SnapshotSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, secrets := splitSecretSettings(snapshot)
+
+	export := &SettingsExport{
+		Version:    SettingsExportVersion,
+		ExportedAt: time.Now(),
+		Source:     m.clientAddress,
+		Settings:   settings,
+	}
+
+	if passphrase != "" && len(secrets) > 0 {
+		encrypted, err := encryptSecrets(secrets, passphrase)
+		if err != nil {
+			return nil, err
+		}
+		export.EncryptedSecrets = encrypted
+	}
+
+	return export, nil
+}
+
+// ParseSettingsExport decodes and validates an export file
+func ParseSettingsExport(data []byte) (*SettingsExport, error) {
+	var export SettingsExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("failed to parse settings export: %w", err)
+	}
+
+	if export.Version > SettingsExportVersion {
+		return nil, fmt.Errorf("settings export version %d is newer than supported version %d, please update clica", export.Version, SettingsExportVersion)
+	}
+
+	if _, _, err := task.ParseTaskSettings(export.Settings); err != nil {
+		return nil, fmt.Errorf("settings export contains invalid settings: %w", err)
+	}
+
+	return &export, nil
+}
+
+// HasSecrets reports whether the export carries encrypted secrets
+func (e *SettingsExport) HasSecrets() bool {
+	return e.EncryptedSecrets != ""
+}
+
+// ImportSettings applies an export to the instance via UpdateSettingsCli.
+// Encrypted secrets are decrypted with the passphrase; with an empty passphrase they are skipped.
+// Returns the number of settings applied.
+func (m *Manager) ImportSettings(ctx context.Context, export *SettingsExport, passphrase string) (int, error) {
+	all := append([]string{}, export.Settings...)
+
+	if export.HasSecrets() && passphrase != "" {
+		secrets, err := decryptSecrets(export.EncryptedSecrets, passphrase)
+		if err != nil {
+			return 0, err
+		}
+		all = append(all, secrets...)
+	}
+
+	settings, secrets, err := task.ParseTaskSettings(all)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse settings: %w", err)
+	}
+	if settings == nil && secrets == nil {
+		return 0, nil
+	}
+
+	_, err = m.client.State.UpdateSettingsCli(ctx, &clica.UpdateSettingsRequestCli{
+		Metadata: &clica.Metadata{},
+		Settings: settings,
+		Secrets:  secrets,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to import settings: %w", err)
+	}
+
+	return len(all), nil
+}
+
+// DiffSettings compares two snapshots key by key, returning differences sorted by key
+func DiffSettings(left, right []string) []SettingDiff {
+	leftValues := settingsToMap(left)
+	rightValues := settingsToMap(right)
+
+	keys := make(map[string]bool)
+	for key := range leftValues {
+		keys[key] = true
+	}
+	for key := range rightValues {
+		keys[key] = true
+	}
+
+	var diffs []SettingDiff
+	for key := range keys {
+		leftValue, hasLeft := leftValues[key]
+		rightValue, hasRight := rightValues[key]
+		if hasLeft == hasRight && leftValue == rightValue {
+			continue
+		}
+		diffs = append(diffs, SettingDiff{
+			Key:      key,
+			Left:     leftValue,
+			Right:    rightValue,
+			HasLeft:  hasLeft,
+			HasRight: hasRight,
+		})
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+	return diffs
+}
+
+// settingsToMap converts key=value pairs into a map
+func settingsToMap(settings []string) map[string]string {
+	values := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		key, value, _ := strings.Cut(setting, "=")
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return values
+}
+
+// splitSecretSettings separates secret settings (API keys etc.) from the rest
+func splitSecretSettings(all []string) (settings, secrets []string) {
+	for _, setting := range all {
+		key, _, _ := strings.Cut(setting, "=")
+		if task.IsSecretSetting(key) {
+			secrets = append(secrets, setting)
+		} else {
+			settings = append(settings, setting)
+		}
+	}
+	return settings, secrets
+}
+
+// encryptSecrets encrypts secret settings with AES-256-GCM using a key derived from the passphrase
+func encryptSecrets(secrets []string, passphrase string) (string, error) {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newExportCipher(passphrase, salt, exportKeyIterations)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	envelope, err := json.Marshal(secretsEnvelope{
+		Salt:       salt,
+		Nonce:      nonce,
+		Iterations: exportKeyIterations,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal encrypted secrets: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(envelope), nil
+}
+
+// decryptSecrets reverses encryptSecrets
+func decryptSecrets(encoded, passphrase string) ([]string, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encrypted secrets: %w", err)
+	}
+
+	var envelope secretsEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted secrets: %w", err)
+	}
+
+	gcm, err := newExportCipher(passphrase, envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid encrypted secrets: bad nonce")
+	}
+
+	plaintext, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets: wrong passphrase or corrupted file")
+	}
+
+	var secrets []string
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse decrypted secrets: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// newExportCipher derives an AES-256 key from the passphrase and returns a GCM cipher
+func newExportCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations <= 0 || iterations > maxExportKeyIterations {
+		return nil, fmt.Errorf("invalid encrypted secrets: iteration count %d is outside 1-%d", iterations, maxExportKeyIterations)
+	}
+
+	block, err := aes.NewCipher(pbkdf2.Key([]byte(passphrase), salt, iterations, 32, sha256.New))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return gcm, nil
+}
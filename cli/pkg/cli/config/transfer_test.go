@@ -0,0 +1,120 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSettingsExportRoundTrip(t *testing.T) {
+	settings, secrets := splitSecretSettings([]string{
+		"api-key=sk-ant-123",
+		"mode=act",
+		"open-router-api-key=sk-or-456",
+		"auto-approval-settings.actions.read-files=true",
+	})
+	if want := []string{"mode=act", "auto-approval-settings.actions.read-files=true"}; !reflect.DeepEqual(settings, want) {
+		t.Fatalf("got settings %q, want %q", settings, want)
+	}
+
+	encrypted, err := encryptSecrets(secrets, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(encrypted, "sk-ant") {
+		t.Fatal("secrets are exported in the clear")
+	}
+
+	data, err := json.Marshal(&SettingsExport{
+		Version:          SettingsExportVersion,
+		ExportedAt:       time.Now(),
+		Settings:         settings,
+		EncryptedSecrets: encrypted,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	export, err := ParseSettingsExport(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !export.HasSecrets() || !reflect.DeepEqual(export.Settings, settings) {
+		t.Fatalf("got %+v, want the exported settings and secrets", export)
+	}
+
+	decrypted, err := decryptSecrets(export.EncryptedSecrets, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decrypted, secrets) {
+		t.Errorf("got secrets %q, want %q", decrypted, secrets)
+	}
+}
+
+func TestDecryptSecretsWrongPassphrase(t *testing.T) {
+	encrypted, err := encryptSecrets([]string{"api-key=sk-ant-123"}, "correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = decryptSecrets(encrypted, "battery staple")
+	if err == nil || !strings.Contains(err.Error(), "wrong passphrase") {
+		t.Errorf("got error %v, want a wrong passphrase error", err)
+	}
+}
+
+func TestDecryptSecretsIterationBounds(t *testing.T) {
+	for _, iterations := range []int{0, -1, maxExportKeyIterations + 1, 1 << 31} {
+		envelope, err := json.Marshal(secretsEnvelope{
+			Salt:       make([]byte, 16),
+			Nonce:      make([]byte, 12),
+			Iterations: iterations,
+			Ciphertext: make([]byte, 32),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		start := time.Now()
+		_, err = decryptSecrets(base64.StdEncoding.EncodeToString(envelope), "correct horse")
+		if err == nil || !strings.Contains(err.Error(), "iteration count") {
+			t.Errorf("%d iterations: got error %v, want the iteration count rejected", iterations, err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("%d iterations: rejecting took %s", iterations, elapsed)
+		}
+	}
+}
+
+func TestParseSettingsExportRejects(t *testing.T) {
+	for name, data := range map[string]string{
+		"invalid json":     `{"version": 1, "settings": [`,
+		"newer version":    `{"version": 99, "settings": []}`,
+		"invalid settings": `{"version": 1, "settings": ["mdoe=act"]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseSettingsExport([]byte(data)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestDiffSettings(t *testing.T) {
+	diffs := DiffSettings(
+		[]string{"mode=act", "aws-region=us-east-1", "yolo-mode-toggled=false"},
+		[]string{"mode=plan", "aws-region=us-east-1", "custom-prompt=be brief"},
+	)
+	want := []SettingDiff{
+		{Key: "custom-prompt", Right: "be brief", HasRight: true},
+		{Key: "mode", Left: "act", Right: "plan", HasLeft: true, HasRight: true},
+		{Key: "yolo-mode-toggled", Left: "false", HasLeft: true},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("got %+v\nwant %+v", diffs, want)
+	}
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/spf13/cobra"
+)
+
+func NewDaemonCommand() *cobra.Command {
+	opts := global.DefaultSupervisorOptions()
+	var noRestart bool
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Supervise Clica instances started by this CLI",
+		Long: `Run a supervisor in the foreground that watches the instances started by this CLI.
+
+The supervisor:
+  - restarts a crashed or hung clica-core with exponential backoff
+  - stops the paired clica-host when its core can't be brought back
+  - shuts down instances idle longer than --idle-ttl (never during a task)
+
+Only one daemon runs at a time. Run it in the background with e.g.
+  nohup clica daemon --idle-ttl 30m > ~/.clica/logs/daemon.log 2>&1 &`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Config == nil {
+				return fmt.Errorf("config not initialized")
+			}
+
+			opts.Restart = !noRestart
+			if opts.PollInterval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			fmt.Printf("Supervising Clica instances (PID %d, interval %s", os.Getpid(), opts.PollInterval)
+			if opts.IdleTTL > 0 {
+				fmt.Printf(", idle TTL %s", opts.IdleTTL)
+			}
+			fmt.Println(")")
+
+			if err := global.RunSupervisor(ctx, opts); err != nil {
+				return err
+			}
+
+			fmt.Println("Supervisor stopped")
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.IdleTTL, "idle-ttl", opts.IdleTTL, "shut down instances idle longer than this (e.g. 30m, 0 to disable)")
+	cmd.Flags().DurationVar(&opts.PollInterval, "interval", opts.PollInterval, "how often instances are checked")
+	cmd.Flags().IntVar(&opts.MaxRestarts, "max-restarts", opts.MaxRestarts, "restarts before giving up on a crashing core")
+	cmd.Flags().BoolVar(&noRestart, "no-restart", false, "don't restart crashed cores, only clean up after them")
+
+	cmd.AddCommand(newDaemonStatusCommand())
+
+	return cmd
+}
+
+func newDaemonStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the daemon and the instances it supervises",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			daemonPID := global.GetDaemonPID()
+			instances, err := global.ListManagedInstances()
+			if err != nil {
+				return err
+			}
+
+			if global.Config.OutputFormat == "json" {
+				type managedInstanceStatus struct {
+					*global.ManagedInstance
+					LastActivity time.Time `json:"last_activity"`
+				}
+				status := struct {
+					DaemonPID int                     `json:"daemon_pid,omitempty"`
+					Instances []managedInstanceStatus `json:"instances"`
+				}{DaemonPID: daemonPID, Instances: []managedInstanceStatus{}}
+				for _, instance := range instances {
+					status.Instances = append(status.Instances, managedInstanceStatus{instance, global.InstanceLastActivity(instance)})
+				}
+
+				data, err := json.MarshalIndent(status, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal status: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			if daemonPID != 0 {
+				fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Daemon running (PID %d)", daemonPID)))
+			} else {
+				fmt.Println(renderer.Dim("Daemon not running. Start it with 'clica daemon'."))
+			}
+			fmt.Println()
+
+			if len(instances) == 0 {
+				fmt.Println("No supervised instances.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ADDRESS\tCORE PID\tHOST PID\tRESTARTS\tSTARTED\tIDLE")
+			for _, instance := range instances {
+				fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\t%s\n",
+					instance.Address,
+					instance.CorePID,
+					instance.HostPID,
+					instance.Restarts,
+					instance.StartedAt.Format("2006-01-02 15:04"),
+					time.Since(global.InstanceLastActivity(instance)).Round(time.Second),
+				)
+			}
+			w.Flush()
+
+			return nil
+		},
+	}
+
+	return cmd
+}
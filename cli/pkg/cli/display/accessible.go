@@ -0,0 +1,142 @@
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/theme"
+	"github.com/clica/cli/pkg/cli/types"
+)
+
+// Accessible reports whether output is for screen readers and braille displays (--accessible):
+// segments are printed once with role labels, without cursor movement, animation, box drawing or emoji
+func Accessible() bool {
+	return global.Config != nil && global.Config.Accessible
+}
+
+// Form styles form with the current theme. In accessible mode it asks its questions one line at
+// a time, reading answers from the same stdin reader as the task's accessible prompts.
+func Form(form *huh.Form) *huh.Form {
+	form = form.WithTheme(theme.Current().Huh()).WithAccessible(Accessible())
+	if Accessible() {
+		form = form.WithInput(output.StdinReader())
+	}
+	return form
+}
+
+// ScreenReaderMarkdown returns markdown that is printed without rendering as screen readers
+// should get it: cleaned with ScreenReaderText in accessible mode, unchanged otherwise
+func ScreenReaderMarkdown(markdown string) string {
+	if !Accessible() {
+		return markdown
+	}
+	return ScreenReaderText(markdown)
+}
+
+var (
+	headingMarker = regexp.MustCompile(`^\s*#{1,6}\s+`)
+	italicLine    = regexp.MustCompile(`^(\s*)\*([^*\s][^*]*)\*\s*$`)
+	// Box drawing, block elements, symbols, dingbats and emoji, with the space after them
+	decorations = regexp.MustCompile(`[\x{2500}-\x{259F}\x{2600}-\x{27BF}\x{2B00}-\x{2BFF}\x{1F000}-\x{1FAFF}\x{FE0F}\x{200D}] ?`)
+)
+
+// ScreenReaderText removes what screen readers read out as noise from a markdown document:
+// heading, bold and code markers, code fence lines, box drawing characters and emoji. Code
+// inside a fence, such as command output or file contents, is kept exactly as it is.
+func ScreenReaderText(text string) string {
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	inFence := false
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence {
+			line = headingMarker.ReplaceAllString(line, "")
+			line = italicLine.ReplaceAllString(line, "$1$2")
+			line = strings.ReplaceAll(line, "**", "")
+			line = strings.ReplaceAll(line, "`", "")
+			line = decorations.ReplaceAllString(line, "")
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// SegmentLabel returns the role label a segment of sayType is announced with in accessible mode,
+// e.g. "Assistant said:" or "Tool request: edit file api/users.go"
+func SegmentLabel(sayType string, msg *types.ClicaMessage) string {
+	switch sayType {
+	case string(types.SayTypeReasoning):
+		return "Assistant thought:"
+	case string(types.SayTypeText):
+		return "Assistant said:"
+	case string(types.SayTypeCompletionResult):
+		return "Task completed:"
+	case string(types.SayTypeCommand):
+		return "Command output:"
+	case string(types.SayTypeTool):
+		var tool types.ToolMessage
+		if err := json.Unmarshal([]byte(msg.Text), &tool); err != nil {
+			return "Tool used:"
+		}
+		return "Tool used: " + describeTool(&tool)
+	case "ask":
+		return askLabel(msg)
+	default:
+		return strings.ToUpper(sayType) + ":"
+	}
+}
+
+func askLabel(msg *types.ClicaMessage) string {
+	switch msg.Ask {
+	case string(types.AskTypeFollowup):
+		return "Assistant asked:"
+	case string(types.AskTypePlanModeRespond):
+		return "Assistant proposed a plan:"
+	case string(types.AskTypeTool), string(types.AskTypeCommand), string(types.AskTypeBrowserActionLaunch), string(types.AskTypeUseMcpServer):
+		return "Tool request: " + DescribeApproval(msg)
+	default:
+		return fmt.Sprintf("Assistant asked (%s):", msg.Ask)
+	}
+}
+
+// DescribeApproval describes what an approval request asks for in plain words, e.g.
+// "edit file api/users.go" or "run command go test ./..."
+func DescribeApproval(msg *types.ClicaMessage) string {
+	switch msg.Ask {
+	case string(types.AskTypeTool):
+		var tool types.ToolMessage
+		if err := json.Unmarshal([]byte(msg.Text), &tool); err == nil {
+			return describeTool(&tool)
+		}
+	case string(types.AskTypeCommand):
+		return "run command " + commandText(msg.Text)
+	case string(types.AskTypeBrowserActionLaunch):
+		return "launch a browser at " + strings.TrimSpace(msg.Text)
+	case string(types.AskTypeUseMcpServer):
+		if req, err := types.ParseMcpRequest(msg.Text); err == nil {
+			return describeMcpRequest(req)
+		}
+		return "use an MCP server"
+	}
+	return "use a tool"
+}
+
+func describeMcpRequest(req *types.McpRequest) string {
+	if req.IsResourceRead() {
+		return fmt.Sprintf("read %s from the %s MCP server", req.URI, req.ServerName)
+	}
+	return fmt.Sprintf("use %s on the %s MCP server", req.ToolName, req.ServerName)
+}
+
+// commandText strips the marker the core appends to commands that need explicit approval
+func commandText(text string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(text), "REQ_APP"))
+}
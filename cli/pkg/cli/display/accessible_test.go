@@ -0,0 +1,29 @@
+package display
+
+import "testing"
+
+func TestScreenReaderText(t *testing.T) {
+	for name, tc := range map[string]struct {
+		markdown string
+		want     string
+	}{
+		"markers": {
+			"## Plan ✅\n**Step one**: run `go test`\n*Done*",
+			"Plan \nStep one: run go test\nDone",
+		},
+		"fenced code is kept as is": {
+			"Output:\n```shell\n## not a heading **bold** `tick` │ ✅\n```\nafter `code`",
+			"Output:\n## not a heading **bold** `tick` │ ✅\nafter code",
+		},
+		"fence spanning the document": {
+			"```diff\n- **old**\n\n+ **new**\n```",
+			"- **old**\n\n+ **new**",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := ScreenReaderText(tc.markdown); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
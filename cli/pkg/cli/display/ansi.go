@@ -7,12 +7,26 @@ import (
 	"golang.org/x/term"
 )
 
+// fakeTerminalWidth is the width of the terminal stdout is treated as, see UseFakeTerminal
+var fakeTerminalWidth int
+
+// UseFakeTerminal renders as if stdout were a terminal of the given width, so tests can
+// compare rich output written to a file. Returns a function that restores detection.
+func UseFakeTerminal(width int) (restore func()) {
+	previous := fakeTerminalWidth
+	fakeTerminalWidth = width
+	return func() { fakeTerminalWidth = previous }
+}
+
 func isTTY() bool {
+	if fakeTerminalWidth > 0 {
+		return true
+	}
 	return term.IsTerminal(int(os.Stdout.Fd()))
 }
 
 func ClearLine() {
-	if !isTTY() {
+	if !isTTY() || Accessible() {
 		return
 	}
 	fmt.Print("\r\033[K")
@@ -20,7 +34,7 @@ func ClearLine() {
 
 // ClearToEnd clears from cursor to end of screen
 func ClearToEnd() {
-	if !isTTY() {
+	if !isTTY() || Accessible() {
 		return
 	}
 	fmt.Print("\033[J")
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/clica/cli/pkg/cli/theme"
 )
 
 // BannerInfo contains information to display in the session banner
@@ -21,24 +22,19 @@ type BannerInfo struct {
 
 // RenderSessionBanner renders a nice banner showing version, model, and workspace info
 func RenderSessionBanner(info BannerInfo) string {
-	// Bright white for title
-	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("15")). // Bright white
+	t := theme.Current()
+
+	// Title color, bright white by default
+	titleStyle := t.Style(t.Title).
 		Bold(true)
 
-	// Dim gray for regular text (same as huh placeholder)
-	dimStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "248", Dark: "238"})
+	// Subtle gray for regular text (same as huh placeholder)
+	dimStyle := t.Style(t.Subtle)
 
 	// Border color matches mode
-	borderColor := lipgloss.Color("3") // Yellow for plan
-	if info.Mode == "act" {
-		borderColor = lipgloss.Color("39") // Blue for act
-	}
-
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(borderColor).
+		BorderForeground(t.ModeColor(info.Mode).Lipgloss()).
 		Padding(1, 4)
 
 	var lines []string
@@ -49,15 +45,26 @@ func RenderSessionBanner(info BannerInfo) string {
 		versionStr = "v" + versionStr
 	}
 
+	// One fact per line, without the box, for screen readers
+	if Accessible() {
+		lines := []string{"clica cli preview " + versionStr}
+		if info.Mode != "" {
+			lines = append(lines, info.Mode+" mode")
+		}
+		if info.Provider != "" && info.ModelID != "" {
+			lines = append(lines, "Model: "+info.Provider+"/"+info.ModelID)
+		}
+		if info.Workdir != "" {
+			lines = append(lines, "Workspace: "+info.Workdir)
+		}
+		return strings.Join(lines, "\n")
+	}
+
 	// First line: "clica cli vX.X.X" on left, "plan mode" on right
 	leftSide := titleStyle.Render("clica cli preview") + " " + dimStyle.Render(versionStr)
 
 	if info.Mode != "" {
-		modeColor := lipgloss.Color("3") // Yellow for plan
-		if info.Mode == "act" {
-			modeColor = lipgloss.Color("39") // Blue for act
-		}
-		modeStyle := lipgloss.NewStyle().Foreground(modeColor).Bold(true)
+		modeStyle := t.Style(t.ModeColor(info.Mode)).Bold(true)
 		rightSide := modeStyle.Render(info.Mode + " mode")
 
 		// Calculate spacing to push mode to the right
@@ -0,0 +1,329 @@
+package display
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// Markers of the SEARCH/REPLACE blocks file edits are sent as, current and legacy
+var (
+	searchBlockStart = regexp.MustCompile(`^([-]{3,}|[<]{3,}) SEARCH>?$`)
+	searchBlockEnd   = regexp.MustCompile(`^[=]{3,}$`)
+	replaceBlockEnd  = regexp.MustCompile(`^([+]{3,}|[>]{3,}) REPLACE>?$`)
+)
+
+const (
+	// maxDiffCells bounds the LCS table; larger changes are shown as a delete and an insert
+	maxDiffCells = 1 << 22
+	// maxDiffFileSize is the largest file read to find line numbers
+	maxDiffFileSize = 5 << 20
+)
+
+// workspaceRoot is the workspace of the instance whose edits are shown. Edit paths are relative
+// to it, and the CLI may run in another directory or drive an instance of another workspace.
+var workspaceRoot atomic.Value // string
+
+// SetWorkspaceRoot sets the directory relative edit paths are read from, "" for the working directory
+func SetWorkspaceRoot(dir string) {
+	workspaceRoot.Store(dir)
+}
+
+// diffOpKind is the kind of a diff operation
+type diffOpKind int
+
+const (
+	diffEqual diffOpKind = iota
+	diffDelete
+	diffInsert
+)
+
+// diffOp is one step of a diff, with indexes into the old and new sequences
+type diffOp struct {
+	kind   diffOpKind
+	oldIdx int // -1 for inserts
+	newIdx int // -1 for deletes
+}
+
+// diffHunk is one changed region of a file
+type diffHunk struct {
+	old      []string
+	new      []string
+	oldStart int // line number of old[0] in the file, 0 if unknown
+	newStart int // line number of new[0] in the edited file, 0 if unknown
+	ops      []diffOp
+}
+
+// diffBlock is one SEARCH/REPLACE block of an edit
+type diffBlock struct {
+	search  []string
+	replace []string
+}
+
+// parseSearchReplace splits edit content into its SEARCH/REPLACE blocks.
+// Returns false if the content has none, i.e. it is the full new file.
+func parseSearchReplace(content string) ([]diffBlock, bool) {
+	var blocks []diffBlock
+	var current *diffBlock
+	inReplace := false
+
+	for _, line := range splitLines(content) {
+		trimmed := strings.TrimRight(line, " \r")
+		switch {
+		case searchBlockStart.MatchString(trimmed):
+			blocks = append(blocks, diffBlock{})
+			current = &blocks[len(blocks)-1]
+			inReplace = false
+		case current == nil:
+			// Text before the first block
+		case searchBlockEnd.MatchString(trimmed) && !inReplace:
+			inReplace = true
+		case replaceBlockEnd.MatchString(trimmed):
+			current = nil
+		case inReplace:
+			current.replace = append(current.replace, line)
+		default:
+			current.search = append(current.search, line)
+		}
+	}
+
+	return blocks, len(blocks) > 0
+}
+
+// buildHunks turns an edit of a file into hunks, with line numbers from the file on disk when it
+// can be read. Returns nil if there is nothing to show, e.g. a full file identical to the one on disk.
+func buildHunks(path, content string) []*diffHunk {
+	file, fileOK := readFileLines(path)
+
+	blocks, isSearchReplace := parseSearchReplace(content)
+	if !isSearchReplace {
+		// Full new content, compared with the file as it is
+		if !fileOK {
+			return nil
+		}
+		newLines := splitLines(content)
+		if strings.Join(file, "\n") == strings.Join(newLines, "\n") {
+			return nil
+		}
+		return []*diffHunk{{old: file, new: newLines, oldStart: 1, newStart: 1, ops: diffSequences(file, newLines)}}
+	}
+
+	var hunks []*diffHunk
+	delta := 0 // lines added minus removed by earlier blocks
+	searchFrom := 0
+	for _, block := range blocks {
+		hunk := &diffHunk{old: block.search, new: block.replace, ops: diffSequences(block.search, block.replace)}
+
+		if fileOK && len(block.search) > 0 {
+			if i := locateLines(file, block.search, searchFrom); i >= 0 {
+				// File not edited yet, as for approvals
+				hunk.oldStart, hunk.newStart = i+1, i+1+delta
+				searchFrom = i + len(block.search)
+			} else if i := locateLines(file, block.replace, 0); i >= 0 && len(block.replace) > 0 {
+				// File already edited, as for auto-approved edits
+				hunk.newStart, hunk.oldStart = i+1, i+1-delta
+			}
+		}
+		delta += len(block.replace) - len(block.search)
+		hunks = append(hunks, hunk)
+	}
+	return hunks
+}
+
+// readFileLines reads a file for line numbers, relative to the workspace root
+func readFileLines(path string) ([]string, bool) {
+	if path == "" {
+		return nil, false
+	}
+	if root, _ := workspaceRoot.Load().(string); root != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() || info.Size() > maxDiffFileSize {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return splitLines(string(data)), true
+}
+
+// locateLines returns the index in file where lines occur, searching from an index, or -1.
+// Trailing whitespace is ignored, as when edits are applied.
+func locateLines(file, lines []string, from int) int {
+	if len(lines) == 0 {
+		return -1
+	}
+	for _, start := range []int{from, 0} {
+		for i := start; i+len(lines) <= len(file); i++ {
+			match := true
+			for j, line := range lines {
+				if strings.TrimRight(file[i+j], " \t\r") != strings.TrimRight(line, " \t\r") {
+					match = false
+					break
+				}
+			}
+			if match {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitLines splits text into lines without a trailing empty line
+func splitLines(text string) []string {
+	text = strings.TrimSuffix(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// diffSequences computes a shortest edit script between two sequences with a longest
+// common subsequence, after trimming their common prefix and suffix
+func diffSequences[T comparable](a, b []T) []diffOp {
+	var ops []diffOp
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		ops = append(ops, diffOp{kind: diffEqual, oldIdx: prefix, newIdx: prefix})
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(midA)*len(midB) > maxDiffCells {
+		for i := range midA {
+			ops = append(ops, diffOp{kind: diffDelete, oldIdx: prefix + i, newIdx: -1})
+		}
+		for j := range midB {
+			ops = append(ops, diffOp{kind: diffInsert, oldIdx: -1, newIdx: prefix + j})
+		}
+	} else {
+		ops = append(ops, lcsOps(midA, midB, prefix)...)
+	}
+
+	for k := suffix; k > 0; k-- {
+		ops = append(ops, diffOp{kind: diffEqual, oldIdx: len(a) - k, newIdx: len(b) - k})
+	}
+	return ops
+}
+
+// lcsOps diffs two sequences with a longest common subsequence table, offsetting indexes.
+// Deletes come before inserts within a change.
+func lcsOps[T comparable](a, b []T, offset int) []diffOp {
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:]
+	width := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*width)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+
+	var ops, inserts []diffOp
+	flush := func() {
+		ops = append(ops, inserts...)
+		inserts = inserts[:0]
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			ops = append(ops, diffOp{kind: diffEqual, oldIdx: offset + i, newIdx: offset + j})
+			i++
+			j++
+		case j >= len(b) || (i < len(a) && lcs[(i+1)*width+j] >= lcs[i*width+j+1]):
+			ops = append(ops, diffOp{kind: diffDelete, oldIdx: offset + i, newIdx: -1})
+			i++
+		default:
+			inserts = append(inserts, diffOp{kind: diffInsert, oldIdx: -1, newIdx: offset + j})
+			j++
+		}
+	}
+	flush()
+	return ops
+}
+
+// tokenizeWords splits a line into words, runs of whitespace and single other characters
+func tokenizeWords(line string) []string {
+	var tokens []string
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// wordChanges marks the runes of a changed line pair that differ, for intra-line highlighting.
+// Returns nil masks when the lines have too little in common for highlighting to help.
+func wordChanges(oldLine, newLine string) (oldMask, newMask []bool) {
+	oldTokens, newTokens := tokenizeWords(oldLine), tokenizeWords(newLine)
+	oldMask = make([]bool, len([]rune(oldLine)))
+	newMask = make([]bool, len([]rune(newLine)))
+
+	oldPos, newPos := tokenOffsets(oldTokens), tokenOffsets(newTokens)
+	common := 0
+	for _, op := range diffSequences(oldTokens, newTokens) {
+		switch op.kind {
+		case diffEqual:
+			common += len([]rune(oldTokens[op.oldIdx]))
+		case diffDelete:
+			markRunes(oldMask, oldPos[op.oldIdx], oldTokens[op.oldIdx])
+		case diffInsert:
+			markRunes(newMask, newPos[op.newIdx], newTokens[op.newIdx])
+		}
+	}
+
+	// Mostly rewritten lines read better without highlighting
+	if longest := max(len(oldMask), len(newMask)); longest == 0 || common*5 < longest*2 {
+		return nil, nil
+	}
+	return oldMask, newMask
+}
+
+// tokenOffsets returns the rune offset of each token
+func tokenOffsets(tokens []string) []int {
+	offsets := make([]int, len(tokens))
+	pos := 0
+	for i, token := range tokens {
+		offsets[i] = pos
+		pos += len([]rune(token))
+	}
+	return offsets
+}
+
+func markRunes(mask []bool, start int, token string) {
+	for k := range []rune(token) {
+		mask[start+k] = true
+	}
+}
@@ -0,0 +1,424 @@
+package display
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/clica/cli/pkg/cli/theme"
+)
+
+const (
+	// diffContextLines is how many unchanged lines are kept around each change
+	diffContextLines = 3
+	// sideBySideMinWidth is the narrowest terminal diffs are shown side by side in
+	sideBySideMinWidth = 120
+	// diffTabWidth is how many spaces a tab is expanded to
+	diffTabWidth = 4
+)
+
+// defaultDiffSyntax is the chroma style of diffs for themes without a syntax style
+const defaultDiffSyntax = "monokai"
+
+var diffGutterStyle = lipgloss.NewStyle().Faint(true)
+
+// diffRow is one displayed line of a diff. Unified diffs fill one side per row; side-by-side
+// diffs pair deleted and inserted lines. fold is the number of unchanged lines a row stands for.
+type diffRow struct {
+	oldIdx  int // -1 if none
+	newIdx  int // -1 if none
+	changed bool
+	fold    int
+}
+
+// diffSide is one side of a hunk prepared for display
+type diffSide struct {
+	lines  []string
+	colors [][]string // syntax color of each rune, "" for the default
+	masks  map[int][]bool
+	start  int
+}
+
+// RenderEditDiff renders an edit of a file, given as SEARCH/REPLACE blocks or as the full new
+// content, as a diff: side by side when width allows, unified otherwise. With color, changed
+// words are highlighted and code is syntax highlighted for the file's language. Line numbers
+// come from the file on disk when it can be read. Returns "" if there is nothing to show.
+func RenderEditDiff(path, content string, color bool, width int) string {
+	hunks := buildHunks(path, content)
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	t := theme.Current()
+	var style *chroma.Style
+	var lexer chroma.Lexer
+	if color {
+		syntax := t.Syntax
+		if syntax == "" {
+			syntax = defaultDiffSyntax
+		}
+		style = styles.Get(syntax)
+		lexer = diffLexer(path)
+	}
+
+	// One gutter width for all hunks keeps them aligned
+	numWidth := 0
+	for _, hunk := range hunks {
+		if hunk.oldStart > 0 && hunk.newStart > 0 {
+			last := max(hunk.oldStart+len(hunk.old), hunk.newStart+len(hunk.new)) - 1
+			numWidth = max(numWidth, len(strconv.Itoa(last)))
+		}
+	}
+
+	sideBySide := width >= sideBySideMinWidth
+	var output strings.Builder
+	for i, hunk := range hunks {
+		old := prepareDiffSide(hunk.old, hunk.oldStart, lexer, style)
+		new := prepareDiffSide(hunk.new, hunk.newStart, lexer, style)
+		if color {
+			markWordChanges(hunk.ops, old, new)
+		}
+
+		header := hunkHeader(hunk, i, len(hunks))
+		if header != "" {
+			if color {
+				header = t.Style(t.Accent).Render(header)
+			}
+			output.WriteString(header)
+			output.WriteString("\n")
+		}
+
+		for _, row := range diffRows(hunk.ops, sideBySide) {
+			var line string
+			switch {
+			case row.fold > 0:
+				line = fmt.Sprintf("⋯ %d unchanged lines", row.fold)
+				if color {
+					line = diffGutterStyle.Render(line)
+				}
+			case sideBySide:
+				pane := (width - 3) / 2
+				left := renderDiffCell(old, row.oldIdx, row.changed, '-', numWidth, pane, color)
+				right := renderDiffCell(new, row.newIdx, row.changed, '+', numWidth, pane, color)
+				if !color {
+					right = strings.TrimRight(right, " ")
+				}
+				separator := " │ "
+				if color {
+					separator = diffGutterStyle.Render(separator)
+				}
+				line = left + separator + right
+			default:
+				line = renderUnifiedLine(old, new, row, numWidth, width, color)
+			}
+			output.WriteString(line)
+			output.WriteString("\n")
+		}
+	}
+
+	return strings.TrimSuffix(output.String(), "\n")
+}
+
+// diffLexer picks a lexer for a file from its extension
+func diffLexer(path string) chroma.Lexer {
+	var lexer chroma.Lexer
+	if lang := detectLanguage(strings.ToLower(filepath.Ext(path))); lang != "" {
+		lexer = lexers.Get(lang)
+	}
+	if lexer == nil {
+		lexer = lexers.Match(filepath.Base(path))
+	}
+	if lexer == nil {
+		return nil
+	}
+	return chroma.Coalesce(lexer)
+}
+
+// prepareDiffSide expands tabs in one side of a hunk and highlights its syntax. The side is
+// highlighted as a whole so tokens spanning lines, like block comments, are colored right.
+func prepareDiffSide(lines []string, start int, lexer chroma.Lexer, style *chroma.Style) *diffSide {
+	side := &diffSide{
+		lines:  make([]string, len(lines)),
+		colors: make([][]string, len(lines)),
+		masks:  make(map[int][]bool),
+		start:  start,
+	}
+	for i, line := range lines {
+		side.lines[i] = strings.ReplaceAll(line, "\t", strings.Repeat(" ", diffTabWidth))
+	}
+
+	if lexer == nil || style == nil || len(lines) == 0 {
+		return side
+	}
+	iterator, err := lexer.Tokenise(nil, strings.Join(side.lines, "\n")+"\n")
+	if err != nil {
+		return side
+	}
+	for i, tokens := range chroma.SplitTokensIntoLines(iterator.Tokens()) {
+		if i >= len(side.lines) {
+			break
+		}
+		var colors []string
+		for _, token := range tokens {
+			colour := ""
+			if entry := style.Get(token.Type); entry.Colour.IsSet() {
+				colour = entry.Colour.String()
+			}
+			for _, r := range token.Value {
+				if r != '\n' {
+					colors = append(colors, colour)
+				}
+			}
+		}
+		side.colors[i] = colors
+	}
+	return side
+}
+
+// lineNumber returns the file line number of a line as text, or "" when unknown
+func (s *diffSide) lineNumber(idx int) string {
+	if s.start <= 0 || idx < 0 {
+		return ""
+	}
+	return strconv.Itoa(s.start + idx)
+}
+
+// markWordChanges pairs the deleted and inserted lines of each change and marks the words that differ
+func markWordChanges(ops []diffOp, old, new *diffSide) {
+	var deletes, inserts []int
+	flush := func() {
+		for k := 0; k < len(deletes) && k < len(inserts); k++ {
+			oldMask, newMask := wordChanges(old.lines[deletes[k]], new.lines[inserts[k]])
+			if oldMask != nil {
+				old.masks[deletes[k]] = oldMask
+				new.masks[inserts[k]] = newMask
+			}
+		}
+		deletes, inserts = deletes[:0], inserts[:0]
+	}
+
+	for _, op := range ops {
+		switch op.kind {
+		case diffDelete:
+			if len(inserts) > 0 {
+				flush()
+			}
+			deletes = append(deletes, op.oldIdx)
+		case diffInsert:
+			inserts = append(inserts, op.newIdx)
+		default:
+			flush()
+		}
+	}
+	flush()
+}
+
+// diffRows lays out the ops of a hunk as rows, folding unchanged lines far from any change
+func diffRows(ops []diffOp, sideBySide bool) []diffRow {
+	// Keep unchanged lines within diffContextLines of a change
+	visible := make([]bool, len(ops))
+	for i, op := range ops {
+		if op.kind == diffEqual {
+			continue
+		}
+		for k := max(i-diffContextLines, 0); k <= min(i+diffContextLines, len(ops)-1); k++ {
+			visible[k] = true
+		}
+	}
+
+	var rows []diffRow
+	var deletes, inserts []int
+	flush := func() {
+		if sideBySide {
+			for k := 0; k < max(len(deletes), len(inserts)); k++ {
+				row := diffRow{oldIdx: -1, newIdx: -1, changed: true}
+				if k < len(deletes) {
+					row.oldIdx = deletes[k]
+				}
+				if k < len(inserts) {
+					row.newIdx = inserts[k]
+				}
+				rows = append(rows, row)
+			}
+		} else {
+			for _, idx := range deletes {
+				rows = append(rows, diffRow{oldIdx: idx, newIdx: -1, changed: true})
+			}
+			for _, idx := range inserts {
+				rows = append(rows, diffRow{oldIdx: -1, newIdx: idx, changed: true})
+			}
+		}
+		deletes, inserts = deletes[:0], inserts[:0]
+	}
+
+	for i := 0; i < len(ops); i++ {
+		op := ops[i]
+		switch op.kind {
+		case diffDelete:
+			if len(inserts) > 0 {
+				flush()
+			}
+			deletes = append(deletes, op.oldIdx)
+			continue
+		case diffInsert:
+			inserts = append(inserts, op.newIdx)
+			continue
+		}
+
+		flush()
+		if visible[i] {
+			rows = append(rows, diffRow{oldIdx: op.oldIdx, newIdx: op.newIdx})
+			continue
+		}
+		hidden := 0
+		for i < len(ops) && ops[i].kind == diffEqual && !visible[i] {
+			hidden++
+			i++
+		}
+		i--
+		if hidden == 1 {
+			// A fold marker would take as much room as the line
+			rows = append(rows, diffRow{oldIdx: op.oldIdx, newIdx: op.newIdx})
+		} else {
+			rows = append(rows, diffRow{oldIdx: -1, newIdx: -1, fold: hidden})
+		}
+	}
+	flush()
+	return rows
+}
+
+// hunkHeader returns "@@ -a,b +c,d @@" for a hunk with known line numbers, otherwise
+// which edit of several it is, or "" for a single edit
+func hunkHeader(hunk *diffHunk, index, count int) string {
+	if hunk.oldStart > 0 && hunk.newStart > 0 {
+		return fmt.Sprintf("@@ -%d,%d +%d,%d @@", hunk.oldStart, len(hunk.old), hunk.newStart, len(hunk.new))
+	}
+	if count > 1 {
+		return fmt.Sprintf("edit %d of %d", index+1, count)
+	}
+	return ""
+}
+
+// renderUnifiedLine renders a row of a unified diff: both line numbers, the marker and the text
+func renderUnifiedLine(old, new *diffSide, row diffRow, numWidth, width int, color bool) string {
+	side, idx, marker := old, row.oldIdx, ' '
+	switch {
+	case row.changed && row.oldIdx >= 0:
+		marker = '-'
+	case row.changed:
+		side, idx, marker = new, row.newIdx, '+'
+	}
+
+	gutter := ""
+	if numWidth > 0 {
+		gutter = fmt.Sprintf("%*s %*s ", numWidth, old.lineNumber(row.oldIdx), numWidth, new.lineNumber(row.newIdx))
+	}
+	return renderDiffLine(side, idx, marker, gutter, width, row.changed, color)
+}
+
+// renderDiffCell renders one side of a side-by-side row, padded to the pane width
+func renderDiffCell(side *diffSide, idx int, changed bool, marker rune, numWidth, pane int, color bool) string {
+	if idx < 0 {
+		return strings.Repeat(" ", pane)
+	}
+	if !changed {
+		marker = ' '
+	}
+
+	gutter := ""
+	if numWidth > 0 {
+		gutter = fmt.Sprintf("%*s ", numWidth, side.lineNumber(idx))
+	}
+	line := renderDiffLine(side, idx, marker, gutter, pane, changed, color)
+	if pad := pane - ansi.StringWidth(line); pad > 0 {
+		line += strings.Repeat(" ", pad)
+	}
+	return line
+}
+
+// renderDiffLine renders the gutter, marker and text of a line, cut to width. Changed lines get
+// the background of their side, with a stronger one on the words that changed.
+func renderDiffLine(side *diffSide, idx int, marker rune, gutter string, width int, changed, color bool) string {
+	text := side.lines[idx]
+	textWidth := width - ansi.StringWidth(gutter) - 2
+
+	if !color {
+		line := gutter + string(marker) + " " + text
+		if width > 0 && ansi.StringWidth(line) > width {
+			line = ansi.Truncate(line, width, "…")
+		}
+		return line
+	}
+
+	// Changed lines use the theme's error and success colors for their markers
+	t := theme.Current()
+	var lineBg, wordBg lipgloss.TerminalColor
+	markStyle := lipgloss.NewStyle()
+	switch {
+	case changed && marker == '-':
+		lineBg, wordBg, markStyle = t.DiffDelete.Lipgloss(), t.DiffDeleteWord.Lipgloss(), t.Style(t.Error)
+	case changed && marker == '+':
+		lineBg, wordBg, markStyle = t.DiffInsert.Lipgloss(), t.DiffInsertWord.Lipgloss(), t.Style(t.Success)
+	}
+	if lineBg != nil {
+		markStyle = markStyle.Background(lineBg)
+	}
+
+	var body strings.Builder
+	runes := []rune(text)
+	colors, mask := side.colors[idx], side.masks[idx]
+	for start := 0; start < len(runes); {
+		colour, emphasis := runeStyle(colors, mask, start)
+		end := start + 1
+		for end < len(runes) {
+			c, e := runeStyle(colors, mask, end)
+			if c != colour || e != emphasis {
+				break
+			}
+			end++
+		}
+
+		segment := lipgloss.NewStyle()
+		if colour != "" {
+			segment = segment.Foreground(lipgloss.Color(colour))
+		}
+		if emphasis && wordBg != nil {
+			segment = segment.Background(wordBg)
+		} else if lineBg != nil {
+			segment = segment.Background(lineBg)
+		}
+		body.WriteString(segment.Render(string(runes[start:end])))
+		start = end
+	}
+
+	rendered := body.String()
+	if textWidth > 0 && ansi.StringWidth(rendered) > textWidth {
+		rendered = ansi.Truncate(rendered, textWidth, "…")
+	}
+	if lineBg != nil && textWidth > 0 {
+		// Fill the rest of the line so changes read as blocks
+		if pad := textWidth - ansi.StringWidth(rendered); pad > 0 {
+			rendered += lipgloss.NewStyle().Background(lineBg).Render(strings.Repeat(" ", pad))
+		}
+	}
+
+	if gutter != "" {
+		gutter = diffGutterStyle.Render(gutter)
+	}
+	return gutter + markStyle.Render(string(marker)+" ") + rendered
+}
+
+// runeStyle returns the syntax color of a rune and whether it is in a changed word
+func runeStyle(colors []string, mask []bool, i int) (string, bool) {
+	colour := ""
+	if i < len(colors) {
+		colour = colors[i]
+	}
+	return colour, i < len(mask) && mask[i]
+}
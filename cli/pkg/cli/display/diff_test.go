@@ -0,0 +1,151 @@
+package display
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// applyOps rebuilds both sequences of single characters from an edit script, checking it
+// covers them in order
+func applyOps(t *testing.T, a, b []string, ops []diffOp) {
+	t.Helper()
+	var gotA, gotB []string
+	for _, op := range ops {
+		switch op.kind {
+		case diffEqual:
+			if a[op.oldIdx] != b[op.newIdx] {
+				t.Fatalf("equal op pairs %q with %q", a[op.oldIdx], b[op.newIdx])
+			}
+			gotA, gotB = append(gotA, a[op.oldIdx]), append(gotB, b[op.newIdx])
+		case diffDelete:
+			gotA = append(gotA, a[op.oldIdx])
+		case diffInsert:
+			gotB = append(gotB, b[op.newIdx])
+		}
+	}
+	if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+		t.Fatalf("ops rebuild %q and %q, want %q and %q", gotA, gotB, a, b)
+	}
+}
+
+func TestDiffSequences(t *testing.T) {
+	for name, tc := range map[string]struct {
+		a, b  string
+		equal int
+	}{
+		"identical":         {"abc", "abc", 3},
+		"empty":             {"", "", 0},
+		"insert only":       {"", "xy", 0},
+		"delete only":       {"xy", "", 0},
+		"changed middle":    {"abcde", "abXde", 4},
+		"common prefix":     {"abc", "abcd", 3},
+		"moved line":        {"abcd", "bcda", 3},
+		"interleaved":       {"axbycz", "abc", 3},
+		"nothing in common": {"abc", "xyz", 0},
+	} {
+		t.Run(name, func(t *testing.T) {
+			a, b := strings.Split(tc.a, ""), strings.Split(tc.b, "")
+			ops := diffSequences(a, b)
+			applyOps(t, a, b, ops)
+
+			equal := 0
+			for _, op := range ops {
+				if op.kind == diffEqual {
+					equal++
+				}
+			}
+			if equal != tc.equal {
+				t.Errorf("got %d equal elements, want a longest common subsequence of %d", equal, tc.equal)
+			}
+		})
+	}
+}
+
+func TestLcsOpsDeletesBeforeInserts(t *testing.T) {
+	ops := lcsOps([]string{"a", "b"}, []string{"x", "y"}, 0)
+	var kinds []diffOpKind
+	for _, op := range ops {
+		kinds = append(kinds, op.kind)
+	}
+	if want := []diffOpKind{diffDelete, diffDelete, diffInsert, diffInsert}; !reflect.DeepEqual(kinds, want) {
+		t.Errorf("got %v, want %v", kinds, want)
+	}
+}
+
+func TestWordChanges(t *testing.T) {
+	oldMask, newMask := wordChanges("return foo(bar)", "return foo(baz)")
+	if got := maskedText("return foo(bar)", oldMask); got != "bar" {
+		t.Errorf("got old change %q, want bar", got)
+	}
+	if got := maskedText("return foo(baz)", newMask); got != "baz" {
+		t.Errorf("got new change %q, want baz", got)
+	}
+
+	if oldMask, newMask := wordChanges("entirely different", "nothing alike here"); oldMask != nil || newMask != nil {
+		t.Error("got masks for a rewritten line, want none")
+	}
+}
+
+func maskedText(line string, mask []bool) string {
+	var b strings.Builder
+	for i, r := range []rune(line) {
+		if mask[i] {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func TestParseSearchReplace(t *testing.T) {
+	content := `preamble
+------- SEARCH
+old one
+=======
+new one
++++++++ REPLACE
+<<<<<<< SEARCH
+old two
+=======
+=======
+>>>>>>> REPLACE
+`
+	blocks, ok := parseSearchReplace(content)
+	if !ok {
+		t.Fatal("found no blocks")
+	}
+	want := []diffBlock{
+		{search: []string{"old one"}, replace: []string{"new one"}},
+		// A second divider inside the replacement is content
+		{search: []string{"old two"}, replace: []string{"======="}},
+	}
+	if !reflect.DeepEqual(blocks, want) {
+		t.Errorf("got %+v, want %+v", blocks, want)
+	}
+
+	if _, ok := parseSearchReplace("package main\n"); ok {
+		t.Error("full file content parsed as SEARCH/REPLACE blocks")
+	}
+}
+
+func TestBuildHunksReadsFromWorkspaceRoot(t *testing.T) {
+	defer SetWorkspaceRoot("")
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n\nfunc a() {}\nfunc b() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	edit := "------- SEARCH\nfunc b() {}\n=======\nfunc c() {}\n+++++++ REPLACE\n"
+
+	SetWorkspaceRoot(root)
+	hunks := buildHunks("main.go", edit)
+	if len(hunks) != 1 || hunks[0].oldStart != 4 || hunks[0].newStart != 4 {
+		t.Fatalf("got %+v, want one hunk at line 4", hunks)
+	}
+
+	SetWorkspaceRoot(t.TempDir())
+	if hunks := buildHunks("main.go", edit); len(hunks) != 1 || hunks[0].oldStart != 0 {
+		t.Errorf("got %+v, want a hunk without line numbers outside the workspace", hunks)
+	}
+}
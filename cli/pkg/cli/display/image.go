@@ -0,0 +1,204 @@
+package display
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+
+	// Decoders for the screenshot formats browser sessions produce
+	_ "image/jpeg"
+
+	_ "golang.org/x/image/webp"
+)
+
+// ImageProtocol is a terminal graphics protocol for showing images inline
+type ImageProtocol string
+
+const (
+	ImageProtocolNone  ImageProtocol = ""
+	ImageProtocolKitty ImageProtocol = "kitty"
+	ImageProtocolITerm ImageProtocol = "iterm2"
+	ImageProtocolSixel ImageProtocol = "sixel"
+)
+
+const (
+	// kittyChunkSize is the largest base64 payload kitty accepts per escape sequence
+	kittyChunkSize = 4096
+	// sixelMaxWidth caps sixel images in pixels, since sixel has no cell-based sizing
+	sixelMaxWidth = 800
+)
+
+// DecodeDataURL decodes a base64 image data URL such as "data:image/webp;base64,..."
+func DecodeDataURL(dataURL string) (image.Image, error) {
+	header, payload, ok := strings.Cut(dataURL, ",")
+	if !ok || !strings.HasPrefix(header, "data:image/") || !strings.HasSuffix(header, ";base64") {
+		return nil, fmt.Errorf("not a base64 image data URL")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image data: %w", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	return img, nil
+}
+
+// EncodePNG encodes an image as PNG
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// InlineImage returns the escape sequence that shows an image inline with the given protocol,
+// at most cols terminal columns wide
+func InlineImage(protocol ImageProtocol, img image.Image, cols int) (string, error) {
+	switch protocol {
+	case ImageProtocolKitty, ImageProtocolITerm:
+		data, err := EncodePNG(img)
+		if err != nil {
+			return "", err
+		}
+		if protocol == ImageProtocolKitty {
+			return kittyImage(data, cols), nil
+		}
+		return itermImage(data, cols), nil
+	case ImageProtocolSixel:
+		return sixelImage(img, sixelMaxWidth), nil
+	}
+	return "", fmt.Errorf("unsupported image protocol '%s'", protocol)
+}
+
+// kittyImage encodes PNG data with the kitty graphics protocol, split into chunks
+func kittyImage(data []byte, cols int) string {
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	var b strings.Builder
+	for i := 0; i < len(encoded); i += kittyChunkSize {
+		end := min(i+kittyChunkSize, len(encoded))
+		more := 0
+		if end < len(encoded) {
+			more = 1
+		}
+		if i == 0 {
+			// a=T transmits and displays, f=100 is PNG, c scales to a column width
+			fmt.Fprintf(&b, "\033_Ga=T,f=100,c=%d,m=%d;%s\033\\", cols, more, encoded[i:end])
+		} else {
+			fmt.Fprintf(&b, "\033_Gm=%d;%s\033\\", more, encoded[i:end])
+		}
+	}
+	return b.String()
+}
+
+// itermImage encodes image data with the iTerm2 inline images protocol
+func itermImage(data []byte, cols int) string {
+	return fmt.Sprintf("\033]1337;File=inline=1;size=%d;width=%d;preserveAspectRatio=1:%s\a",
+		len(data), cols, base64.StdEncoding.EncodeToString(data))
+}
+
+// sixelImage encodes an image as sixel graphics with a fixed 6x6x6 color cube,
+// scaled down to maxWidth pixels
+func sixelImage(img image.Image, maxWidth int) string {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > maxWidth {
+		height = height * maxWidth / width
+		width = maxWidth
+	}
+	if width == 0 || height == 0 {
+		return ""
+	}
+
+	// Palette index of every pixel, sampled nearest-neighbour
+	pixels := make([]int, width*height)
+	used := make([]bool, 216)
+	for y := 0; y < height; y++ {
+		srcY := bounds.Min.Y + y*bounds.Dy()/height
+		for x := 0; x < width; x++ {
+			srcX := bounds.Min.X + x*bounds.Dx()/width
+			r, g, b, _ := img.At(srcX, srcY).RGBA()
+			index := cubeLevel(r)*36 + cubeLevel(g)*6 + cubeLevel(b)
+			pixels[y*width+x] = index
+			used[index] = true
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\033Pq\"1;1;%d;%d", width, height)
+	for index, ok := range used {
+		if ok {
+			fmt.Fprintf(&b, "#%d;2;%d;%d;%d", index, index/36*20, index/6%6*20, index%6*20)
+		}
+	}
+
+	bits := make([]byte, width)
+	for top := 0; top < height; top += 6 {
+		bandColors := map[int]bool{}
+		for y := top; y < min(top+6, height); y++ {
+			for x := 0; x < width; x++ {
+				bandColors[pixels[y*width+x]] = true
+			}
+		}
+
+		first := true
+		for index := range 216 {
+			if !bandColors[index] {
+				continue
+			}
+			for x := range bits {
+				bits[x] = 0
+			}
+			for y := top; y < min(top+6, height); y++ {
+				for x := 0; x < width; x++ {
+					if pixels[y*width+x] == index {
+						bits[x] |= 1 << (y - top)
+					}
+				}
+			}
+
+			if !first {
+				b.WriteByte('$') // back to the start of the band for the next color
+			}
+			first = false
+			fmt.Fprintf(&b, "#%d", index)
+			writeSixelRuns(&b, bits)
+		}
+		b.WriteByte('-')
+	}
+
+	b.WriteString("\033\\")
+	return b.String()
+}
+
+// writeSixelRuns writes one color's sixels for a band, run-length encoded
+func writeSixelRuns(b *strings.Builder, bits []byte) {
+	for x := 0; x < len(bits); {
+		run := 1
+		for x+run < len(bits) && bits[x+run] == bits[x] {
+			run++
+		}
+		char := rune(63 + bits[x])
+		if run > 3 {
+			fmt.Fprintf(b, "!%d%c", run, char)
+		} else {
+			for i := 0; i < run; i++ {
+				b.WriteRune(char)
+			}
+		}
+		x += run
+	}
+}
+
+// cubeLevel maps a 16-bit color component to one of the 6 levels of the color cube
+func cubeLevel(v uint32) int {
+	return int((v*5 + 0x7fff) / 0xffff)
+}
@@ -8,6 +8,9 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/clica/cli/pkg/cli/theme"
+	"github.com/muesli/termenv"
 	"golang.org/x/term"
 )
 
@@ -31,19 +34,22 @@ type MarkdownRenderer struct {
 const USETERMINALWORDWRAP = true
 
 
-// seems like a reliable way to check for terminals
-// for now i'm keeping everything as auto
-// eventually we can define a custom glamour style for ghostty / iterm
-// https://github.com/charmbracelet/glamour/blob/master/styles/README.md)
-func detectTerminalTheme() string {
-	switch os.Getenv("TERM_PROGRAM") {
-	case "iTerm.app", "Ghostty":
-		return "dark"
+// glamourOptions loads the theme's markdown style, a glamour style name or JSON file
+// (https://github.com/charmbracelet/glamour/blob/master/styles/README.md),
+// then overrides margins, text color and code colors from the theme
+func glamourOptions(terminalWrap bool) []glamour.TermRendererOption {
+	options := []glamour.TermRendererOption{
+		glamour.WithStylePath(theme.Current().Markdown),
+		glamour.WithStylesFromJSONBytes([]byte(glamourStyleJSON(terminalWrap))),
 	}
-	if os.Getenv("GHOSTTY_VERSION") != "" {
-		return "dark"
+	if theme.NoColor() {
+		options = append(options, glamour.WithColorProfile(termenv.Ascii))
+	} else if lipgloss.ColorProfile() == termenv.TrueColor {
+		// Highlight code in 24-bit color: the 256-color approximation picks between
+		// equally close colors at random, so the same code block could change color
+		options = append(options, glamour.WithChromaFormatter("terminal16m"))
 	}
-	return "dark"
+	return options
 }
 
 func glamourStyleJSON(terminalWrap bool) string {
@@ -51,17 +57,25 @@ func glamourStyleJSON(terminalWrap bool) string {
 		"document": {
 			"block_prefix": "\n",
 			"block_suffix": "\n",
-			"color": "252",
+			"color": %q,
 			"margin": %s
 		},
 		"code_block": {
-			"margin": 0
+			"margin": 0%s
 		}
 	}`
+	t := theme.Current()
+
+	// A syntax style replaces the markdown style's own code colors
+	codeBlock := ""
+	if t.Syntax != "" {
+		codeBlock = fmt.Sprintf(`, "theme": %q, "chroma": null`, t.Syntax)
+	}
+
 	if terminalWrap {
-		return fmt.Sprintf(tmpl, "0")
+		return fmt.Sprintf(tmpl, t.Text.String(), "0", codeBlock)
 	}
-	return fmt.Sprintf(tmpl, "2")
+	return fmt.Sprintf(tmpl, t.Text.String(), "2", codeBlock)
 }
 
 
@@ -78,8 +92,7 @@ func NewMarkdownRenderer() (*MarkdownRenderer, error) {
 	}
 
 	r, err := glamour.NewTermRenderer(
-		glamour.WithStandardStyle(detectTerminalTheme()),              	 					 // Load full auto style first
-		glamour.WithStylesFromJSONBytes([]byte(glamourStyleJSON(USETERMINALWORDWRAP))),  	 // Then override just margins
+		glamour.WithOptions(glamourOptions(USETERMINALWORDWRAP)...), // Theme style, then margin overrides
 		glamour.WithWordWrap(wordWrap),                        
 		glamour.WithPreservedNewLines(),
 	)
@@ -96,6 +109,9 @@ func NewMarkdownRenderer() (*MarkdownRenderer, error) {
 // terminalWidthOr returns the terminal width or the provided fallback.
 // It first tries term.GetSize, then falls back to $COLUMNS if set.
 func terminalWidthOr(fallback int) int {
+	if fakeTerminalWidth > 0 {
+		return fakeTerminalWidth
+	}
 	if w, _, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 {
 		return w
 	}
@@ -111,8 +127,7 @@ func terminalWidthOr(fallback int) int {
 // Useful for tables and other content that should fit within terminal bounds.
 func NewMarkdownRendererWithWidth(width int) (*MarkdownRenderer, error) {
 	r, err := glamour.NewTermRenderer(
-		glamour.WithStandardStyle(detectTerminalTheme()),
-		glamour.WithStylesFromJSONBytes([]byte(glamourStyleJSON(false))),
+		glamour.WithOptions(glamourOptions(false)...),
 		glamour.WithWordWrap(width),
 		glamour.WithPreservedNewLines(),
 	)
@@ -0,0 +1,248 @@
+package display
+
+import (
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/clica/cli/pkg/cli/output"
+	"golang.org/x/term"
+)
+
+// MarkdownStream prints markdown that arrives a piece at a time, as the full text so far.
+// Blocks (paragraphs, lists, fenced code, ...) are committed as soon as they close: rendered
+// and printed once, and never printed again. Only the open block at the end is live, redrawn in
+// place as it grows, so a code fence closing can't reflow anything already on screen.
+type MarkdownStream struct {
+	render     func(string) string // renders complete markdown, nil to print the source as is
+	live       bool                // show the open block and redraw it in place
+	source     string              // the text committed so far
+	commits    []int               // the length of source after each commit
+	printed    bool                // a block was printed, the next one is separated by a blank line
+	liveSource string              // the open block shown, rendered again when the terminal is resized
+	liveLines  []string            // lines of the open block on screen
+}
+
+// NewMarkdownStream creates a stream that renders blocks with render, or prints the source if
+// render is nil. With live set, the open block is shown and redrawn, which needs a terminal.
+func NewMarkdownStream(render func(string) string, live bool) *MarkdownStream {
+	return &MarkdownStream{
+		render: render,
+		live:   live && render != nil,
+	}
+}
+
+// Update takes the full text so far, commits the blocks that closed since the last update and
+// redraws the open block
+func (ms *MarkdownStream) Update(text string) {
+	ms.rewind(text)
+
+	if end := completeBlocksEnd(text, len(ms.source)); end > len(ms.source) {
+		ms.clearLive()
+		ms.commit(text[len(ms.source):end])
+		ms.source = text[:end]
+		ms.commits = append(ms.commits, end)
+	}
+
+	if ms.canDrawLive() {
+		ms.drawLive(text[len(ms.source):])
+	}
+}
+
+// Finish commits the rest of text, the open block included
+func (ms *MarkdownStream) Finish(text string) {
+	ms.clearLive()
+	ms.rewind(text)
+
+	ms.commit(text[len(ms.source):])
+	ms.source = text
+
+	// Source printed as is ends like the text, make sure the next output starts on its own line
+	if ms.render == nil && ms.printed && !strings.HasSuffix(text, "\n") {
+		output.Println("")
+	}
+}
+
+// Redraw renders the open block again at the terminal's new size, after it was resized
+func (ms *MarkdownStream) Redraw() {
+	if len(ms.liveLines) == 0 || !ms.canDrawLive() {
+		return
+	}
+	ms.drawLive(ms.liveSource)
+}
+
+// canDrawLive reports whether the open block may be drawn: moving the cursor over the input box
+// or into captured TUI output would clear rows that aren't the stream's
+func (ms *MarkdownStream) canDrawLive() bool {
+	return ms.live && !output.IsInputVisible() && !output.IsCapturing()
+}
+
+// rewind handles text that no longer starts with the committed blocks because it was rewritten.
+// The committed source goes back to the last commit text still starts with, so only the blocks
+// after it are printed again, as text has them now.
+func (ms *MarkdownStream) rewind(text string) {
+	if strings.HasPrefix(text, ms.source) {
+		return
+	}
+
+	ms.clearLive()
+	for len(ms.commits) > 0 && !strings.HasPrefix(text, ms.source[:ms.commits[len(ms.commits)-1]]) {
+		ms.commits = ms.commits[:len(ms.commits)-1]
+	}
+	ms.source = ""
+	if len(ms.commits) > 0 {
+		ms.source = text[:ms.commits[len(ms.commits)-1]]
+	}
+}
+
+// commit prints complete blocks
+func (ms *MarkdownStream) commit(blocks string) {
+	if strings.TrimSpace(blocks) == "" {
+		return
+	}
+
+	if ms.render == nil {
+		output.Print(blocks)
+		ms.printed = true
+		return
+	}
+
+	if ms.printed {
+		output.Println("")
+	}
+	output.Println(ms.render(blocks))
+	ms.printed = true
+}
+
+// drawLive replaces the open block on screen with block
+func (ms *MarkdownStream) drawLive(block string) {
+	var lines []string
+	if strings.TrimSpace(block) != "" {
+		rendered := ms.render(block)
+		if ms.printed {
+			rendered = "\n" + rendered
+		}
+		lines = strings.Split(rendered, "\n")
+	}
+
+	// Lines scrolled off the screen can't be cleared, keep the live block within it
+	_, height := terminalSizeOr(80, 24)
+	for len(lines) > 0 && screenRows(lines) > height-1 {
+		lines = lines[1:]
+	}
+
+	ms.clearLive()
+	if len(lines) > 0 {
+		output.Print(strings.Join(lines, "\n") + "\n")
+	}
+	ms.liveSource, ms.liveLines = block, lines
+}
+
+// clearLive removes the open block from the screen. The rows it takes are counted at the current
+// width, the terminal rewrapped the lines if it was resized since they were printed.
+func (ms *MarkdownStream) clearLive() {
+	if len(ms.liveLines) == 0 {
+		return
+	}
+	output.Printf("\033[%dA\r\033[J", screenRows(ms.liveLines))
+	ms.liveLines = nil
+}
+
+// screenRows returns the terminal rows lines take, wrapped at the terminal width
+func screenRows(lines []string) int {
+	width, _ := terminalSizeOr(80, 24)
+	rows := 0
+	for _, line := range lines {
+		rows += max(1, (lipgloss.Width(line)+width-1)/width)
+	}
+	return rows
+}
+
+// terminalSizeOr returns the terminal width and height, or the fallbacks if they're unknown
+func terminalSizeOr(fallbackWidth, fallbackHeight int) (int, int) {
+	if fakeTerminalWidth > 0 {
+		return fakeTerminalWidth, fallbackHeight
+	}
+	if w, h, err := term.GetSize(int(os.Stdout.Fd())); err == nil && w > 0 && h > 0 {
+		return w, h
+	}
+	return terminalWidthOr(fallbackWidth), fallbackHeight
+}
+
+// completeBlocksEnd returns the offset in text up to which, starting at from, there are only
+// complete markdown blocks. A block is complete when a blank line and the start of an unindented
+// line follow it, when it's a heading line, or when it's a fenced code block that was closed.
+// Indented lines after a blank line continue the block, e.g. a list item's second paragraph.
+func completeBlocksEnd(text string, from int) int {
+	end := from
+	afterBlank := 0  // offset after the blank lines that followed a block, 0 if none did
+	inBlock := false // a block started since the last boundary
+	fence := ""      // the opening fence of the fenced code block we're in
+	for start := from; start < len(text); {
+		lineEnd := len(text)
+		complete := false
+		if i := strings.IndexByte(text[start:], '\n'); i >= 0 {
+			lineEnd, complete = start+i+1, true
+		}
+		line := strings.TrimRight(text[start:lineEnd], "\r\n")
+
+		switch {
+		case fence != "":
+			if complete && isClosingFence(line, fence) {
+				fence = ""
+				end, inBlock = lineEnd, false
+			}
+
+		case strings.TrimSpace(line) == "":
+			if complete && inBlock {
+				afterBlank = lineEnd
+			}
+
+		default:
+			if afterBlank > 0 {
+				if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+					end, inBlock = afterBlank, false
+				}
+				afterBlank = 0
+			}
+			if !complete {
+				break
+			}
+			if f := openingFence(line); f != "" {
+				// A fence interrupts a paragraph, which is complete
+				if inBlock {
+					end = start
+				}
+				fence, inBlock = f, true
+			} else if strings.HasPrefix(line, "#") && openingHeading(line) {
+				end, inBlock = lineEnd, false
+			} else {
+				inBlock = true
+			}
+		}
+
+		start = lineEnd
+	}
+	return end
+}
+
+// openingFence returns the fence a fenced code block starting at the left margin opens with
+func openingFence(line string) string {
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(line, marker) {
+			return line[:len(line)-len(strings.TrimLeft(line, marker[:1]))]
+		}
+	}
+	return ""
+}
+
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// openingHeading reports whether line is an ATX heading such as "## Usage"
+func openingHeading(line string) bool {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	return level <= 6 && (len(line) == level || line[level] == ' ' || line[level] == '\t')
+}
@@ -0,0 +1,84 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/clica/cli/pkg/cli/output"
+)
+
+func TestCompleteBlocksEnd(t *testing.T) {
+	for name, tc := range map[string]struct {
+		text string
+		from int
+		want string // the complete blocks, text up to the returned offset
+	}{
+		"empty":                       {"", 0, ""},
+		"open paragraph":              {"Hello\nworld", 0, ""},
+		"paragraph and blank line":    {"Hello\n\n", 0, ""},
+		"paragraph closed":            {"Hello\n\nNext", 0, "Hello\n\n"},
+		"from a committed block":      {"A\n\nB\n\nC", 3, "A\n\nB\n\n"},
+		"heading":                     {"# Title\nText", 0, "# Title\n"},
+		"heading being typed":         {"## Usa", 0, ""},
+		"hashtag":                     {"#hashtag\nmore", 0, ""},
+		"too deep for a heading":      {"####### x\n", 0, ""},
+		"open fence":                  {"```go\nfmt.Println()\n\nx := 1\n", 0, ""},
+		"closed fence":                {"```go\ncode\n```\nafter", 0, "```go\ncode\n```\n"},
+		"closing fence being typed":   {"```go\ncode\n``", 0, ""},
+		"tilde fence":                 {"~~~\n```\n~~~\nafter", 0, "~~~\n```\n~~~\n"},
+		"longer fence":                {"````\n```\n````\n", 0, "````\n```\n````\n"},
+		"shorter fence doesn't close": {"````\ncode\n```\n", 0, ""},
+		"fence interrupts paragraph":  {"Intro\n```\ncode", 0, "Intro\n"},
+		"list continuation":           {"- item\n\n  second paragraph\n\nNext", 0, "- item\n\n  second paragraph\n\n"},
+		"tab continuation":            {"- item\n\n\tmore", 0, ""},
+		"CRLF paragraph":              {"Hello\r\n\r\nNext", 0, "Hello\r\n\r\n"},
+		"CRLF heading":                {"# Title\r\nText", 0, "# Title\r\n"},
+		"CRLF fence":                  {"```\r\ncode\r\n```\r\nafter", 0, "```\r\ncode\r\n```\r\n"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.text[:completeBlocksEnd(tc.text, tc.from)]; got != tc.want {
+				t.Errorf("got complete blocks %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownStreamRewrittenText(t *testing.T) {
+	ms := NewMarkdownStream(nil, false)
+	printed := output.Capture(func() {
+		ms.Update("One\n\nTw")
+		ms.Update("One\n\nTwo\n\nThr")
+	})
+	if printed != "One\n\nTwo\n\n" {
+		t.Fatalf("got %q printed, want the first two paragraphs", printed)
+	}
+
+	// Only the blocks from the first one that changed are printed again
+	if got := output.Capture(func() { ms.Finish("One\n\nTwice\n\nThree") }); got != "Twice\n\nThree\n" {
+		t.Errorf("got %q printed, want the text after the unchanged first paragraph", got)
+	}
+}
+
+func TestMarkdownStreamRedraw(t *testing.T) {
+	restore := UseFakeTerminal(20)
+	defer func() { restore() }()
+
+	ms := NewMarkdownStream(func(markdown string) string {
+		return fmt.Sprintf("%d: %s", terminalWidthOr(0), markdown)
+	}, true)
+	if got := output.Capture(func() { ms.drawLive("open block") }); got != "20: open block\n" {
+		t.Fatalf("got %q drawn, want the block rendered at 20 columns", got)
+	}
+
+	// Captured TUI output isn't the stream's to clear
+	if got := output.Capture(ms.Redraw); got != "" {
+		t.Errorf("got %q redrawn while capturing, want nothing", got)
+	}
+
+	restore()
+	restore = UseFakeTerminal(40)
+	if got := output.Capture(func() { ms.drawLive(ms.liveSource) }); !strings.HasSuffix(got, "40: open block\n") {
+		t.Errorf("got %q redrawn, want the block rendered at 40 columns", got)
+	}
+}
@@ -0,0 +1,168 @@
+package display
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/clica/cli/pkg/cli/types"
+)
+
+// McpResponseMaxLines is how many lines of each part of an MCP response are shown
+// before it is truncated, unless the full response is requested
+const McpResponseMaxLines = 20
+
+// RenderMcpRequest renders an MCP tool call or resource read with its arguments,
+// as an approval request ("wants to") or an execution ("is")
+func (tr *ToolRenderer) RenderMcpRequest(req *types.McpRequest, verbTense string) string {
+	var header string
+	if req.IsResourceRead() {
+		action := "is reading"
+		if verbTense == "wants to" {
+			action = "wants to read"
+		}
+		header = fmt.Sprintf("### Clica %s `%s` from the `%s` MCP server", action, req.URI, req.ServerName)
+		if mimeType := req.MimeType(); mimeType != "" {
+			header += fmt.Sprintf(" (%s)", mimeType)
+		}
+	} else {
+		action := "is using"
+		if verbTense == "wants to" {
+			action = "wants to use"
+		}
+		header = fmt.Sprintf("### Clica %s `%s` on the `%s` MCP server", action, req.ToolName, req.ServerName)
+	}
+	if Accessible() {
+		header = "Tool used: " + describeMcpRequest(req)
+		if verbTense == "wants to" {
+			header = "Tool request: " + describeMcpRequest(req)
+		}
+	}
+
+	var output strings.Builder
+	output.WriteString("\n")
+	output.WriteString(tr.renderMarkdown(header))
+	output.WriteString("\n")
+
+	if args := strings.TrimSpace(req.Arguments); args != "" && args != "{}" {
+		output.WriteString("\n")
+		output.WriteString(tr.RenderJSON(args))
+		output.WriteString("\n")
+	}
+
+	return output.String()
+}
+
+// RenderMcpResponse renders the text and resource parts of an MCP response; images are left
+// to the caller. req is the request the response answers, if known. Long parts are cut to
+// McpResponseMaxLines unless full is set.
+func (tr *ToolRenderer) RenderMcpResponse(resp *types.McpResponse, req *types.McpRequest, full bool) string {
+	var header string
+	switch {
+	case resp.IsError:
+		header = "### MCP tool error"
+	case req != nil && req.IsResourceRead():
+		header = fmt.Sprintf("### Resource `%s`", req.URI)
+		if mimeType := req.MimeType(); mimeType != "" {
+			header += fmt.Sprintf(" (%s)", mimeType)
+		}
+	case req != nil:
+		header = fmt.Sprintf("### `%s` response", req.ToolName)
+	default:
+		header = "### MCP server response"
+	}
+
+	var output strings.Builder
+	output.WriteString("\n")
+	output.WriteString(tr.renderMarkdown(header))
+	output.WriteString("\n")
+
+	for _, part := range resp.Content {
+		var rendered string
+		switch part.Type {
+		case "text":
+			mimeType := ""
+			if req != nil && req.IsResourceRead() {
+				mimeType = req.MimeType()
+			}
+			rendered = tr.renderMcpText(part.Text, mimeType, uriLanguage(uriOf(req)))
+		case "resource":
+			title := fmt.Sprintf("Resource `%s`", part.URI)
+			if part.MimeType != "" {
+				title += fmt.Sprintf(" (%s)", part.MimeType)
+			}
+			rendered = tr.renderMarkdown("**"+title+"**") + "\n" + tr.renderMcpText(part.Text, part.MimeType, uriLanguage(part.URI))
+		default:
+			continue
+		}
+
+		output.WriteString("\n")
+		output.WriteString(truncateLines(strings.Trim(rendered, "\n"), McpResponseMaxLines, full))
+		output.WriteString("\n")
+	}
+
+	return output.String()
+}
+
+// RenderJSON pretty-prints JSON, highlighted as a code block in rich mode.
+// Text that isn't valid JSON is returned as is.
+func (tr *ToolRenderer) RenderJSON(text string) string {
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, []byte(strings.TrimSpace(text)), "", "  "); err != nil {
+		return text
+	}
+
+	if tr.outputFormat == "plain" || !isTTY() {
+		return pretty.String()
+	}
+	return strings.Trim(tr.renderMarkdown("```json\n"+pretty.String()+"\n```"), "\n")
+}
+
+// renderMcpText renders response text by its content: JSON is pretty-printed, markdown and
+// plain text are rendered as markdown, and other text types as code in language
+func (tr *ToolRenderer) renderMcpText(text, mimeType, language string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return ""
+	}
+
+	if (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return tr.RenderJSON(trimmed)
+	}
+
+	if tr.outputFormat == "plain" || !isTTY() {
+		return trimmed
+	}
+
+	switch {
+	case mimeType == "", mimeType == "text/plain", mimeType == "text/markdown":
+		return strings.Trim(tr.renderMarkdown(trimmed), "\n")
+	default:
+		return strings.Trim(tr.renderMarkdown(fmt.Sprintf("```%s\n%s\n```", language, trimmed)), "\n")
+	}
+}
+
+// truncateLines cuts text to maxLines lines with a note about how many were hidden, unless full is set
+func truncateLines(text string, maxLines int, full bool) string {
+	lines := strings.Split(text, "\n")
+	if full || len(lines) <= maxLines {
+		return text
+	}
+	hidden := len(lines) - maxLines
+	return strings.Join(lines[:maxLines], "\n") + fmt.Sprintf("\n... %d more lines (run with --verbose to show everything)", hidden)
+}
+
+// uriOf returns the URI a request reads, or ""
+func uriOf(req *types.McpRequest) string {
+	if req == nil {
+		return ""
+	}
+	return req.URI
+}
+
+// uriLanguage returns the code block language for a resource URI, from its extension
+func uriLanguage(uri string) string {
+	return strings.TrimPrefix(path.Ext(uri), ".")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/clica/cli/pkg/cli/global"
 	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/theme"
 	"github.com/clica/cli/pkg/cli/types"
 	"github.com/clica/grpc-go/clica"
 )
@@ -39,15 +40,16 @@ func NewRenderer(outputFormat string) *Renderer {
 		outputFormat: outputFormat,
 	}
 
-	// Initialize lipgloss styles (will respect the global color profile)
-	r.dimStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-	r.greenStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
-	r.redStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
-	r.yellowStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
-	r.blueStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
-	r.whiteStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("7"))
+	// Initialize lipgloss styles from the theme (will respect the global color profile)
+	t := theme.Current()
+	r.dimStyle = t.Style(t.Dim)
+	r.greenStyle = t.Style(t.Success)
+	r.redStyle = t.Style(t.Error)
+	r.yellowStyle = t.Style(t.Warning)
+	r.blueStyle = t.Style(t.Accent)
+	r.whiteStyle = t.Style(t.Text)
 	r.boldStyle = lipgloss.NewStyle().Bold(true)
-	r.successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true)
+	r.successStyle = t.Style(t.Success).Bold(true)
 
 	return r
 }
@@ -84,6 +86,23 @@ func formatNumber(n int) string {
 func (r *Renderer) formatUsageInfo(tokensIn, tokensOut, cacheReads, cacheWrites int, cost float64) string {
     parts := make([]string, 0, 4)
 
+    if Accessible() {
+        // Spelled out, screen readers read the arrows as "upwards arrow"
+        if tokensIn != 0 {
+            parts = append(parts, fmt.Sprintf("%s tokens in", formatNumber(tokensIn)))
+        }
+        if tokensOut != 0 {
+            parts = append(parts, fmt.Sprintf("%s tokens out", formatNumber(tokensOut)))
+        }
+        if cacheReads != 0 {
+            parts = append(parts, fmt.Sprintf("%s cache reads", formatNumber(cacheReads)))
+        }
+        if cacheWrites != 0 {
+            parts = append(parts, fmt.Sprintf("%s cache writes", formatNumber(cacheWrites)))
+        }
+        return strings.Join(append(parts, fmt.Sprintf("cost $%.4f", cost)), ", ")
+    }
+
     if tokensIn != 0 {
         parts = append(parts, fmt.Sprintf("↑ %s", formatNumber(tokensIn)))
     }
@@ -179,11 +198,18 @@ func (r *Renderer) RenderDebug(format string, args ...interface{}) error {
 	return nil
 }
 
+// ClearLine clears the current line. Screen readers would lose what was on it, so accessible output never moves the cursor.
 func (r *Renderer) ClearLine() {
+	if Accessible() {
+		return
+	}
 	output.Print("\r\033[K")
 }
 
 func (r *Renderer) MoveCursorUp(n int) {
+	if Accessible() {
+		return
+	}
 	output.Printf("\033[%dA", n)
 }
 
@@ -235,7 +261,7 @@ func (r *Renderer) RenderMarkdown(markdown string) string {
 	// 1. Output format is explicitly "plain"
 	// 2. Not in a TTY (piped output, file redirect, CI, etc.)
 	if r.outputFormat == "plain" || !isTTY() {
-		return markdown
+		return ScreenReaderMarkdown(markdown)
 	}
 
 	if r.mdRenderer == nil {
@@ -22,6 +22,7 @@ type StreamingSegment struct {
 	outputFormat   string
 	msg            *types.ClicaMessage
 	toolParser     *ToolResultParser
+	stream         *MarkdownStream // prints a text body block by block while it streams, see newBodyStream
 }
 
 func NewStreamingSegment(sayType, prefix string, mdRenderer *MarkdownRenderer, shouldMarkdown bool, msg *types.ClicaMessage, outputFormat string) *StreamingSegment {
@@ -36,17 +37,44 @@ func NewStreamingSegment(sayType, prefix string, mdRenderer *MarkdownRenderer, s
 		toolParser:     NewToolResultParser(mdRenderer),
 	}
 
-	// Render rich header immediately when creating segment (if in rich mode and TTY)
-	if shouldMarkdown && outputFormat != "plain" && isTTY() {
+	// Render rich header immediately when creating segment (if in rich mode and TTY).
+	// Accessible output labels the segment once it's complete instead.
+	if shouldMarkdown && outputFormat != "plain" && isTTY() && !Accessible() {
 		header := ss.generateRichHeader()
 		rendered, _ := mdRenderer.Render(header)
 		output.Println("")
 		output.Print(rendered)
 	}
+	ss.stream = ss.newBodyStream()
 
 	return ss
 }
 
+// newBodyStream returns the stream a text body is printed with as its blocks complete, or nil for
+// bodies printed whole on Freeze: tool and ask bodies are JSON, and accessible output announces the
+// segment with its label before the body, so it waits for the complete text.
+func (ss *StreamingSegment) newBodyStream() *MarkdownStream {
+	switch ss.sayType {
+	case string(types.SayTypeReasoning), string(types.SayTypeText), string(types.SayTypeCompletionResult):
+	default:
+		return nil
+	}
+	if Accessible() {
+		return nil
+	}
+
+	if ss.shouldMarkdown && ss.outputFormat != "plain" && isTTY() {
+		return NewMarkdownStream(func(markdown string) string {
+			rendered, err := ss.mdRenderer.Render(markdown)
+			if err != nil {
+				return markdown
+			}
+			return rendered
+		}, true)
+	}
+	return NewMarkdownStream(nil, false)
+}
+
 func (ss *StreamingSegment) AppendText(text string) {
 	ss.mu.Lock()
 	defer ss.mu.Unlock()
@@ -58,8 +86,33 @@ func (ss *StreamingSegment) AppendText(text string) {
 	// Replace buffer with FULL text - msg.Text contains complete accumulated content
 	ss.buffer.Reset()
 	ss.buffer.WriteString(text)
-	
-	// No rendering during streaming - we'll render once on Freeze()
+
+	// Text bodies print their complete blocks now, other bodies render once on Freeze()
+	if ss.stream != nil {
+		ss.stream.Update(text)
+	}
+}
+
+// Complete sets the complete text of the segment and freezes it
+func (ss *StreamingSegment) Complete(text string) {
+	ss.mu.Lock()
+	if !ss.frozen {
+		ss.buffer.Reset()
+		ss.buffer.WriteString(text)
+	}
+	ss.mu.Unlock()
+
+	ss.Freeze()
+}
+
+// Redraw draws the open block of a streaming text body again, after the terminal was resized
+func (ss *StreamingSegment) Redraw() {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if ss.stream != nil && !ss.frozen {
+		ss.stream.Redraw()
+	}
 }
 
 
@@ -73,7 +126,13 @@ func (ss *StreamingSegment) Freeze() {
 
 	ss.frozen = true
 	currentBuffer := ss.buffer.String()
-	
+
+	// A streaming body has printed its complete blocks, only the rest is left
+	if ss.stream != nil {
+		ss.stream.Finish(currentBuffer)
+		return
+	}
+
 	// Render and print the final markdown
 	ss.renderFinal(currentBuffer)
 }
@@ -119,6 +178,8 @@ func (ss *StreamingSegment) renderFinal(currentBuffer string) {
 			if err == nil {
 				bodyContent = rendered
 			}
+		} else {
+			bodyContent = ScreenReaderMarkdown(bodyContent)
 		}
 	} else {
 		// For other types (reasoning, text, etc.), render markdown as-is
@@ -130,10 +191,17 @@ func (ss *StreamingSegment) renderFinal(currentBuffer string) {
 				bodyContent = currentBuffer
 			}
 		} else {
-			bodyContent = currentBuffer
+			bodyContent = ScreenReaderMarkdown(currentBuffer)
 		}
 	}
 
+	// Screen readers announce the segment's role before its content, labelled from the complete message
+	if Accessible() && currentBuffer != "" && (bodyContent != "" || ss.sayType == "ask") {
+		final := *ss.msg
+		final.Text = currentBuffer
+		output.Printf("\n%s\n", SegmentLabel(ss.sayType, &final))
+	}
+
 	// Print the body content
 	if bodyContent != "" {
 		if !strings.HasSuffix(bodyContent, "\n") {
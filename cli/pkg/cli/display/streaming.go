@@ -2,8 +2,11 @@ package display
 
 import (
 	"fmt"
+	"os"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
 
 	"github.com/clica/cli/pkg/cli/types"
 )
@@ -16,6 +19,7 @@ type StreamingDisplay struct {
 	dedupe        *MessageDeduplicator
 	activeSegment *StreamingSegment
 	mdRenderer    *MarkdownRenderer
+	resize        chan os.Signal
 }
 
 // NewStreamingDisplay creates a new streaming display manager
@@ -25,12 +29,33 @@ func NewStreamingDisplay(state *types.ConversationState, renderer *Renderer) *St
 		panic(fmt.Sprintf("Failed to initialize markdown renderer: %v", err))
 	}
 
-	return &StreamingDisplay{
+	s := &StreamingDisplay{
 		state:      state,
 		renderer:   renderer,
 		dedupe:     NewMessageDeduplicator(),
 		mdRenderer: mdRenderer,
 	}
+	if isTTY() && !Accessible() {
+		s.watchResize()
+	}
+	return s
+}
+
+// watchResize redraws the open block of the active segment when the terminal is resized
+func (s *StreamingDisplay) watchResize() {
+	resize := make(chan os.Signal, 1)
+	signal.Notify(resize, syscall.SIGWINCH)
+	s.resize = resize
+	go func() {
+		for range resize {
+			s.mu.RLock()
+			segment := s.activeSegment
+			s.mu.RUnlock()
+			if segment != nil {
+				segment.Redraw()
+			}
+		}
+	}()
 }
 
 // HandlePartialMessage processes partial messages with streaming support
@@ -43,16 +68,19 @@ func (s *StreamingDisplay) HandlePartialMessage(msg *types.ClicaMessage) error {
 		return nil
 	}
 
-	// Segment-based header-only streaming
-	// Partial stream only shows headers immediately, state stream will handle content bodies
+	// Segment-based streaming
+	// Partial stream shows headers immediately, text bodies as their blocks complete and other bodies once complete
 	sayType := msg.Say
 	if msg.Type == types.MessageTypeAsk {
 		sayType = "ask"
 	}
 
 	// Detect segment boundary
-	if s.activeSegment != nil && s.activeSegment.sayType != sayType {
-		// Just cleanup, don't freeze (no body to print)
+	if s.activeSegment != nil && (s.activeSegment.sayType != sayType || s.activeSegment.msg.Timestamp != msg.Timestamp) {
+		// A streaming text body is finished with what it has, other bodies are left to the state stream
+		if s.activeSegment.stream != nil {
+			s.activeSegment.Freeze()
+		}
 		s.activeSegment = nil
 	}
 
@@ -62,28 +90,25 @@ func (s *StreamingDisplay) HandlePartialMessage(msg *types.ClicaMessage) error {
 		prefix := s.getPrefix(sayType)
 		// NewStreamingSegment prints the header immediately
 		s.activeSegment = NewStreamingSegment(sayType, prefix, s.mdRenderer, shouldMd, msg, s.renderer.outputFormat)
-		// Header printed, done - don't append text or freeze
-		return nil
 	}
 
-	// For subsequent partial messages, do nothing (header already shown)
+	// Partial messages carry the full text so far, text bodies print the blocks that completed
 	if msg.Partial {
+		s.activeSegment.AppendText(msg.Text)
 		return nil
 	}
 
 	// When message is complete (partial=false), render the content body
 	if s.activeSegment != nil {
 		// Had an active segment from partial messages - freeze to render body
-		s.activeSegment.AppendText(msg.Text)
-		s.activeSegment.Freeze()
+		s.activeSegment.Complete(msg.Text)
 		s.activeSegment = nil
 	} else if !msg.Partial {
 		// Message arrived complete without partial phase - create segment and render immediately
 		shouldMd := s.shouldRenderMarkdown(sayType)
 		prefix := s.getPrefix(sayType)
 		segment := NewStreamingSegment(sayType, prefix, s.mdRenderer, shouldMd, msg, s.renderer.outputFormat)
-		segment.AppendText(msg.Text)
-		segment.Freeze()
+		segment.Complete(msg.Text)
 	}
 
 	return nil
@@ -128,6 +153,11 @@ func (s *StreamingDisplay) FreezeActiveSegment() {
 // Cleanup cleans up streaming display resources
 func (s *StreamingDisplay) Cleanup() {
 	s.FreezeActiveSegment()
+	if s.resize != nil {
+		signal.Stop(s.resize)
+		close(s.resize)
+		s.resize = nil
+	}
 	if s.dedupe != nil {
 		s.dedupe.Stop()
 	}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/clica/cli/pkg/cli/clerror"
+	"github.com/clica/cli/pkg/cli/output"
 )
 
 // ErrorSeverity represents the severity level of an error
@@ -67,7 +68,7 @@ func (sr *SystemMessageRenderer) RenderError(severity ErrorSeverity, title, body
 
 	markdown := strings.Join(parts, "\n")
 	rendered := sr.renderer.RenderMarkdown(markdown)
-	fmt.Printf("\n%s\n", rendered)
+	output.Printf("\n%s\n", rendered)
 
 	return nil
 }
@@ -124,7 +125,7 @@ func (sr *SystemMessageRenderer) RenderBalanceError(err *clerror.ClicaError) err
 
 	markdown := strings.Join(parts, "\n")
 	rendered := sr.renderer.RenderMarkdown(markdown)
-	fmt.Printf("\n%s\n", rendered)
+	output.Printf("\n%s\n", rendered)
 
 	return nil
 }
@@ -159,7 +160,7 @@ func (sr *SystemMessageRenderer) RenderAuthError(err *clerror.ClicaError) error
 
 	markdown := strings.Join(parts, "\n")
 	rendered := sr.renderer.RenderMarkdown(markdown)
-	fmt.Printf("\n%s\n", rendered)
+	output.Printf("\n%s\n", rendered)
 
 	return nil
 }
@@ -191,7 +192,7 @@ func (sr *SystemMessageRenderer) RenderRateLimitError(err *clerror.ClicaError) e
 
 	markdown := strings.Join(parts, "\n")
 	rendered := sr.renderer.RenderMarkdown(markdown)
-	fmt.Printf("\n%s\n", rendered)
+	output.Printf("\n%s\n", rendered)
 
 	return nil
 }
@@ -237,7 +238,7 @@ func (sr *SystemMessageRenderer) RenderAPIError(err *clerror.ClicaError) error {
 
 	markdown := strings.Join(parts, "\n")
 	rendered := sr.renderer.RenderMarkdown(markdown)
-	fmt.Printf("\n%s\n", rendered)
+	output.Printf("\n%s\n", rendered)
 
 	return nil
 }
@@ -246,7 +247,7 @@ func (sr *SystemMessageRenderer) RenderAPIError(err *clerror.ClicaError) error {
 func (sr *SystemMessageRenderer) RenderWarning(title, message string) error {
 	markdown := fmt.Sprintf("### **[WARNING]** %s\n\n%s", title, message)
 	rendered := sr.renderer.RenderMarkdown(markdown)
-	fmt.Printf("\n%s\n", rendered)
+	output.Printf("\n%s\n", rendered)
 	return nil
 }
 
@@ -254,7 +255,7 @@ func (sr *SystemMessageRenderer) RenderWarning(title, message string) error {
 func (sr *SystemMessageRenderer) RenderInfo(title, message string) error {
 	markdown := fmt.Sprintf("### **[INFO]** %s\n\n%s", title, message)
 	rendered := sr.renderer.RenderMarkdown(markdown)
-	fmt.Printf("\n%s\n", rendered)
+	output.Printf("\n%s\n", rendered)
 	return nil
 }
 
@@ -262,6 +263,6 @@ func (sr *SystemMessageRenderer) RenderInfo(title, message string) error {
 func (sr *SystemMessageRenderer) RenderCheckpoint(timestamp string, id int64) error {
 	markdown := fmt.Sprintf("## [%s] Checkpoint created `%d`", timestamp, id)
 	rendered := sr.renderer.RenderMarkdown(markdown)
-	fmt.Printf(rendered)
+	output.Print(rendered)
 	return nil
 }
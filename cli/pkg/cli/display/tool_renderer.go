@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/clica/cli/pkg/cli/theme"
 	"github.com/clica/cli/pkg/cli/types"
 )
 
@@ -78,6 +79,13 @@ func (tr *ToolRenderer) RenderToolApprovalHeader(tool *types.ToolMessage) string
 
 // generateToolHeader generates the markdown header for a tool message
 func (tr *ToolRenderer) generateToolHeader(tool *types.ToolMessage, verbTense string) string {
+	if Accessible() {
+		if verbTense == "wants to" {
+			return "Tool request: " + describeTool(tool)
+		}
+		return "Tool used: " + describeTool(tool)
+	}
+
 	var verb string
 	var action string
 
@@ -178,6 +186,39 @@ func (tr *ToolRenderer) generateToolHeader(tool *types.ToolMessage, verbTense st
 	}
 }
 
+// describeTool describes what a tool does in plain words, e.g. "edit file api/users.go"
+func describeTool(tool *types.ToolMessage) string {
+	switch tool.Tool {
+	case string(types.ToolTypeEditedExistingFile):
+		return "edit file " + tool.Path
+	case string(types.ToolTypeNewFileCreated):
+		return "write file " + tool.Path
+	case string(types.ToolTypeReadFile):
+		return "read file " + tool.Path
+	case string(types.ToolTypeListFilesTopLevel):
+		return "list files in " + tool.Path
+	case string(types.ToolTypeListFilesRecursive):
+		return "recursively list files in " + tool.Path
+	case string(types.ToolTypeSearchFiles):
+		switch {
+		case tool.Regex != "" && tool.Path != "":
+			return fmt.Sprintf("search for %s in %s", tool.Regex, tool.Path)
+		case tool.Regex != "":
+			return "search for " + tool.Regex
+		default:
+			return "search files"
+		}
+	case string(types.ToolTypeWebFetch):
+		return "fetch " + tool.Path
+	case string(types.ToolTypeListCodeDefinitionNames):
+		return "list code definitions in " + tool.Path
+	case string(types.ToolTypeSummarizeTask):
+		return "condense the conversation"
+	default:
+		return "use tool " + tool.Tool
+	}
+}
+
 // GenerateToolContentPreview generates content preview for approval requests
 func (tr *ToolRenderer) GenerateToolContentPreview(tool *types.ToolMessage) string {
 	if tool.Content == "" {
@@ -187,8 +228,7 @@ func (tr *ToolRenderer) GenerateToolContentPreview(tool *types.ToolMessage) stri
 	switch tool.Tool {
 	case string(types.ToolTypeEditedExistingFile):
 		// Show diff for edits
-		diffMarkdown := fmt.Sprintf("```diff\n%s\n```", tool.Content)
-		return tr.renderMarkdown(diffMarkdown)
+		return tr.renderEditDiff(tool)
 
 	case string(types.ToolTypeNewFileCreated):
 		// Show content preview for new files (truncated)
@@ -241,8 +281,7 @@ func (tr *ToolRenderer) GenerateToolContentBody(tool *types.ToolMessage) string
 
 	case string(types.ToolTypeEditedExistingFile):
 		// Show the diff
-		diffMarkdown := fmt.Sprintf("```diff\n%s\n```", tool.Content)
-		return tr.renderMarkdown(diffMarkdown)
+		return tr.renderEditDiff(tool)
 
 	case string(types.ToolTypeNewFileCreated):
 		// Show file content preview
@@ -274,17 +313,22 @@ func (tr *ToolRenderer) RenderCommandApprovalRequest(command string, autoApprova
 		autoApprovalConflict = true
 	}
 
-	// Generate header
-	header := fmt.Sprintf("### Clica wants to run `%s`", command)
-	rendered := tr.renderMarkdown(header)
-	output.WriteString(rendered)
-	output.WriteString("\n")
+	if Accessible() {
+		// The label already reads out the command
+		output.WriteString("Tool request: run command " + command + "\n")
+	} else {
+		// Generate header
+		header := fmt.Sprintf("### Clica wants to run `%s`", command)
+		rendered := tr.renderMarkdown(header)
+		output.WriteString(rendered)
+		output.WriteString("\n")
 
-	// Show command in code block
-	cmdBlock := fmt.Sprintf("```shell\n%s\n```", command)
-	cmdRendered := tr.renderMarkdown(cmdBlock)
-	output.WriteString("\n")
-	output.WriteString(cmdRendered)
+		// Show command in code block
+		cmdBlock := fmt.Sprintf("```shell\n%s\n```", command)
+		cmdRendered := tr.renderMarkdown(cmdBlock)
+		output.WriteString("\n")
+		output.WriteString(cmdRendered)
+	}
 
 	// Add warning if needed
 	if autoApprovalConflict {
@@ -298,6 +342,9 @@ func (tr *ToolRenderer) RenderCommandApprovalRequest(command string, autoApprova
 func (tr *ToolRenderer) RenderCommandExecution(command string) string {
 	command = strings.TrimSpace(command)
 	header := fmt.Sprintf("### Clica is running `%s`", command)
+	if Accessible() {
+		header = "Tool used: run command " + command
+	}
 	rendered := tr.renderMarkdown(header)
 	return "\n" + rendered + "\n"
 }
@@ -339,11 +386,23 @@ func (tr *ToolRenderer) RenderUserResponse(approved bool, feedback string) strin
 	return fmt.Sprintf("%s %s\n", symbol, status)
 }
 
+// renderEditDiff renders a file edit as a diff laid out for the terminal width,
+// falling back to the raw edit as a diff code block when it can't be diffed
+func (tr *ToolRenderer) renderEditDiff(tool *types.ToolMessage) string {
+	color := tr.outputFormat != "plain" && isTTY() && !theme.NoColor()
+	if diff := RenderEditDiff(tool.Path, tool.Content, color, terminalWidthOr(100)); diff != "" {
+		return "\n" + diff + "\n"
+	}
+
+	diffMarkdown := fmt.Sprintf("```diff\n%s\n```", tool.Content)
+	return tr.renderMarkdown(diffMarkdown)
+}
+
 // renderMarkdown renders markdown if not in plain mode and in a TTY
 func (tr *ToolRenderer) renderMarkdown(markdown string) string {
 	// Skip markdown rendering if plain mode or not in TTY
 	if tr.outputFormat == "plain" || !isTTY() {
-		return markdown
+		return ScreenReaderMarkdown(markdown)
 	}
 
 	if tr.mdRenderer == nil {
@@ -360,6 +419,9 @@ func (tr *ToolRenderer) renderMarkdown(markdown string) string {
 
 // GenerateAskFollowupHeader generates the header for followup questions
 func (tr *ToolRenderer) GenerateAskFollowupHeader() string {
+	if Accessible() {
+		return "Assistant asked:\n"
+	}
 	return "### Clica has a question\n"
 }
 
@@ -401,6 +463,9 @@ func (tr *ToolRenderer) GenerateAskFollowupBody(messageText string) string {
 
 // GeneratePlanModeRespondHeader generates the header for plan mode responses
 func (tr *ToolRenderer) GeneratePlanModeRespondHeader() string {
+	if Accessible() {
+		return "Assistant proposed a plan:\n"
+	}
 	return "### Clica has a plan\n"
 }
 
@@ -31,7 +31,7 @@ func (p *ToolResultParser) ParseReadFile(content, path string) string {
 
 	// Get file extension for syntax highlighting
 	ext := filepath.Ext(path)
-	lang := p.detectLanguage(ext)
+	lang := detectLanguage(ext)
 
 	var preview strings.Builder
 
@@ -189,7 +189,7 @@ func (p *ToolResultParser) formatFileMatches(file string, matches []string) stri
 	
 	// Parse file path and extension for syntax highlighting
 	ext := filepath.Ext(file)
-	lang := p.detectLanguage(ext)
+	lang := detectLanguage(ext)
 	
 	result.WriteString(fmt.Sprintf("**%s** (%d %s)\n", file, len(matches), p.pluralize(len(matches), "match", "matches")))
 	result.WriteString(fmt.Sprintf("```%s\n", lang))
@@ -301,7 +301,7 @@ func (p *ToolResultParser) ParseWebFetch(content, url string) string {
 }
 
 // detectLanguage returns syntax highlighting language based on file extension
-func (p *ToolResultParser) detectLanguage(ext string) string {
+func detectLanguage(ext string) string {
 	langMap := map[string]string{
 		".ts":   "typescript",
 		".tsx":  "tsx",
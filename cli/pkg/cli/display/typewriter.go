@@ -43,9 +43,9 @@ func NewTypewriterPrinter(config *TypewriterConfig) *TypewriterPrinter {
 	}
 }
 
-// Print prints text with typewriter effect
+// Print prints text with typewriter effect, or all at once for screen readers
 func (tp *TypewriterPrinter) Print(text string) {
-	if !tp.config.Enabled {
+	if !tp.config.Enabled || Accessible() {
 		fmt.Print(text)
 		return
 	}
@@ -35,8 +35,32 @@ func (c *ClicaClients) Initialize(ctx context.Context) error {
 	return nil
 }
 
+// NoDefaultLabel marks instances started with NoDefault in their metadata
+const NoDefaultLabel = "clica.no-default"
+
+// InstanceOptions configures a newly started instance
+type InstanceOptions struct {
+	Name      string            // unique name usable wherever an address is accepted
+	Workspace string            // absolute workspace directory, empty to use the current directory
+	Labels    map[string]string // free-form key=value labels
+	Pool      bool              // start a warm pool member for Workspace
+	NoDefault bool              // never make the instance the default, e.g. when another command owns it
+}
+
 // StartNewInstance starts a new Clica instance and waits for clica-core to self-register
 func (c *ClicaClients) StartNewInstance(ctx context.Context) (*common.CoreInstanceInfo, error) {
+	return c.StartNewInstanceWithOptions(ctx, InstanceOptions{})
+}
+
+// StartNewInstanceWithOptions starts a new Clica instance with a name, workspace and labels
+func (c *ClicaClients) StartNewInstanceWithOptions(ctx context.Context, opts InstanceOptions) (*common.CoreInstanceInfo, error) {
+	// Check the name before starting anything
+	if opts.Name != "" {
+		if address, err := c.registry.ResolveInstance(opts.Name); err == nil {
+			return nil, fmt.Errorf("name '%s' is already used by instance %s", opts.Name, address)
+		}
+	}
+
 	// Find available ports
 	corePort, hostPort, err := common.FindAvailablePortPair()
 	if err != nil {
@@ -48,7 +72,7 @@ func (c *ClicaClients) StartNewInstance(ctx context.Context) (*common.CoreInstan
 	}
 
 	// Start clica-host first
-	hostCmd, err := startClineHost(hostPort, corePort)
+	hostCmd, err := startClineHost(hostPort, corePort, opts.Workspace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start clica-host: %w", err)
 	}
@@ -108,6 +132,25 @@ func (c *ClicaClients) StartNewInstance(ctx context.Context) (*common.CoreInstan
 		fmt.Printf("  Process PID: %d\n", coreCmd.Process.Pid)
 	}
 
+	// Track the processes so 'clica daemon' can supervise them
+	recordManagedInstance(&ManagedInstance{
+		Address:   instance.Address,
+		CorePort:  corePort,
+		HostPort:  hostPort,
+		CorePID:   coreCmd.Process.Pid,
+		HostPID:   hostCmd.Process.Pid,
+		Workspace: opts.Workspace,
+		StartedAt: time.Now(),
+		Pool:      opts.Pool,
+	})
+
+	c.saveInstanceMetadata(instance.Address, opts)
+
+	// Pool instances are only used through a claim, never as the default
+	if opts.Pool || opts.NoDefault {
+		return instance, nil
+	}
+
 	// If this is the first instance, set it as default
 	instances := c.registry.ListInstances()
 	if err := c.registry.EnsureDefaultInstance(instances); err != nil {
@@ -135,7 +178,7 @@ func (c *ClicaClients) StartNewInstanceAtPort(ctx context.Context, corePort int)
 	}
 
 	// Start clica-host first
-	hostCmd, err := startClineHost(hostPort, corePort)
+	hostCmd, err := startClineHost(hostPort, corePort, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to start clica-host: %w", err)
 	}
@@ -195,6 +238,18 @@ func (c *ClicaClients) StartNewInstanceAtPort(ctx context.Context, corePort int)
 		fmt.Printf("  Process PID: %d\n", coreCmd.Process.Pid)
 	}
 
+	// Track the processes so 'clica daemon' can supervise them
+	recordManagedInstance(&ManagedInstance{
+		Address:   instance.Address,
+		CorePort:  corePort,
+		HostPort:  hostPort,
+		CorePID:   coreCmd.Process.Pid,
+		HostPID:   hostCmd.Process.Pid,
+		StartedAt: time.Now(),
+	})
+
+	c.saveInstanceMetadata(instance.Address, InstanceOptions{})
+
 	// If this is the first instance, set it as default
 	instances := c.registry.ListInstances()
 	if err := c.registry.EnsureDefaultInstance(instances); err != nil {
@@ -206,6 +261,35 @@ func (c *ClicaClients) StartNewInstanceAtPort(ctx context.Context, corePort int)
 	return instance, nil
 }
 
+// saveInstanceMetadata records the name, workspace and labels of a freshly started instance.
+// Always written, even when empty, so a new instance never inherits the metadata of an
+// earlier instance that used the same port.
+func (c *ClicaClients) saveInstanceMetadata(address string, opts InstanceOptions) {
+	labels := opts.Labels
+	if opts.Pool || opts.NoDefault {
+		labels = make(map[string]string, len(opts.Labels)+2)
+		for k, v := range opts.Labels {
+			labels[k] = v
+		}
+		if opts.Pool {
+			labels[PoolLabel] = "true"
+		}
+		if opts.NoDefault {
+			labels[NoDefaultLabel] = "true"
+		}
+	}
+
+	err := c.registry.SetInstanceMetadata(common.InstanceMetadata{
+		Address:   address,
+		Name:      opts.Name,
+		Workspace: opts.Workspace,
+		Labels:    labels,
+	})
+	if err != nil {
+		fmt.Printf("Warning: Failed to save instance metadata: %v\n", err)
+	}
+}
+
 // GetRegistry returns the client registry
 func (c *ClicaClients) GetRegistry() *ClientRegistry {
 	return c.registry
@@ -242,7 +326,7 @@ func (c *ClicaClients) EnsureInstanceAtAddress(ctx context.Context, address stri
 	return fmt.Errorf("cannot start remote instance at %s", normalized)
 }
 
-func startClineHost(hostPort, corePort int) (*exec.Cmd, error) {
+func startClineHost(hostPort, corePort int, workspace string) (*exec.Cmd, error) {
 	if Config.Verbose {
 		fmt.Printf("Starting clica-host on port %d\n", hostPort)
 	}
@@ -255,29 +339,41 @@ func startClineHost(hostPort, corePort int) (*exec.Cmd, error) {
 	binDir := path.Dir(execPath)
 	clineHostPath := path.Join(binDir, "clica-host")
 
-	// Start the clica-host process
-	cmd := exec.Command(clineHostPath,
-		"--verbose",
-		"--port", fmt.Sprintf("%d", hostPort))
-
 	// Create logs directory in ~/.clica/logs
 	logsDir := path.Join(Config.ConfigPath, "logs")
 	if err := os.MkdirAll(logsDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create logs directory: %w", err)
 	}
 
-	// Create timestamped log file
+	// Timestamped log file, written and rotated by clica-host itself. Its stdout and stderr
+	// (panics included) go to a separate file that is never rotated, so a rotation can't
+	// leave them writing to a file that has been pruned.
 	timestamp := time.Now().Format("2006-01-02-15-04-05")
-	logFileName := fmt.Sprintf("clica-host-%s-localhost-%d.log", timestamp, hostPort)
-	logFilePath := path.Join(logsDir, logFileName)
-	logFile, err := os.Create(logFilePath)
+	logFilePath := path.Join(logsDir, fmt.Sprintf("clica-host-%s-localhost-%d.log", timestamp, hostPort))
+	outputFilePath := path.Join(logsDir, fmt.Sprintf("clica-host-%s-localhost-%d-output.log", timestamp, hostPort))
+	outputFile, err := os.OpenFile(outputFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create log file: %w", err)
+		return nil, fmt.Errorf("failed to create output file: %w", err)
 	}
+	defer outputFile.Close()
 
-	// Redirect stdout and stderr to log file
-	cmd.Stdout = logFile
-	cmd.Stderr = logFile
+	logLevel := "info"
+	if Config.Verbose {
+		logLevel = "debug"
+	}
+
+	cmd := exec.Command(clineHostPath,
+		"--port", fmt.Sprintf("%d", hostPort),
+		"--log-file", logFilePath,
+		"--log-level", logLevel)
+
+	cmd.Stdout = outputFile
+	cmd.Stderr = outputFile
+
+	// The host bridge reports its working directory as the workspace
+	if workspace != "" {
+		cmd.Dir = workspace
+	}
 
 	// Put the child process in a new process group so Ctrl+C doesn't kill it
 	cmd.SysProcAttr = &syscall.SysProcAttr{
@@ -285,13 +381,12 @@ func startClineHost(hostPort, corePort int) (*exec.Cmd, error) {
 	}
 
 	if err := cmd.Start(); err != nil {
-		logFile.Close()
 		return nil, fmt.Errorf("failed to start clica-host: %w", err)
 	}
 
 	if Config.Verbose {
 		fmt.Printf("Started clica-host (PID: %d)\n", cmd.Process.Pid)
-		fmt.Printf("Logging clica-host output to: %s\n", logFilePath)
+		fmt.Printf("Logging clica-host to %s, its output to %s\n", logFilePath, outputFilePath)
 	}
 	return cmd, nil
 }
@@ -329,6 +424,10 @@ func KillInstanceByAddress(ctx context.Context, registry *ClientRegistry, addres
 		return fmt.Errorf("failed to kill process %d: %w", pid, err)
 	}
 
+	// Stop supervising it so the daemon doesn't bring it back
+	UnmanageInstance(address)
+	registry.RemoveInstanceMetadata(address)
+
 	// Wait for the instance to remove itself from registry
 	if Config.Verbose {
 		fmt.Printf("Waiting for instance to clean up registry entry...\n")
@@ -346,10 +445,10 @@ func KillInstanceByAddress(ctx context.Context, registry *ClientRegistry, addres
 				// ensureDefaultInstance logic will handle setting a new default
 				defaultInstance := registry.GetDefaultInstance()
 				if defaultInstance == address || defaultInstance == "" {
-					if len(instances) > 0 {
-						if err := registry.SetDefaultInstance(instances[0].Address); err == nil {
+					if replacement := registry.defaultCandidate(instances); replacement != "" {
+						if err := registry.SetDefaultInstance(replacement); err == nil {
 							if Config.Verbose {
-								fmt.Printf("Updated default instance to: %s\n", instances[0].Address)
+								fmt.Printf("Updated default instance to: %s\n", replacement)
 							}
 						}
 					}
@@ -0,0 +1,389 @@
+package global
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/sqlite"
+	"github.com/clica/cli/pkg/common"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Kinds of problems reported by CollectGarbage
+const (
+	GCStaleInstance         = "stale_instance"          // registry row of a core that isn't serving
+	GCOrphanProcess         = "orphan_process"          // clica process left running without a registered core
+	GCStaleSupervisorRecord = "stale_supervisor_record" // supervisor record of an instance whose processes exited
+	GCDanglingDefault       = "dangling_default"        // default-instance file pointing at nothing usable
+	GCStaleLock             = "stale_lock"              // file or folder lock whose holder is gone
+	GCOrphanMetadata        = "orphan_metadata"         // name/labels of an instance that no longer exists
+)
+
+const (
+	gcHealthTimeout  = 10 * time.Second // bounds the health checks of one gc run
+	gcUnknownLockAge = 10 * time.Minute // age after which locks with unrecognised holders are stale
+)
+
+// GCFinding is one inconsistency found by CollectGarbage and what is done about it
+type GCFinding struct {
+	Kind   string `json:"kind"`
+	Target string `json:"target"`
+	Reason string `json:"reason"`
+	Action string `json:"action"`
+	Fixed  bool   `json:"fixed"`
+	Error  string `json:"error,omitempty"`
+
+	fix func() error
+}
+
+// GCReport is the result of CollectGarbage
+type GCReport struct {
+	DryRun            bool        `json:"dry_run"`
+	LiveInstances     []string    `json:"live_instances"`
+	SupervisorRunning bool        `json:"supervisor_running"`
+	Findings          []GCFinding `json:"findings"`
+}
+
+// CollectGarbage reconciles the instance registry with reality: registry rows of dead
+// cores, clica processes left behind on recorded ports, supervisor records, the
+// default-instance file, file locks held by exited processes and orphaned instance
+// metadata. Everything found is reported; unless dryRun is set it is also fixed.
+//
+// Instances that couldn't be health checked in time, or that started recently, are
+// treated as live. While the supervisor daemon runs, the instances it manages are left to it.
+func (r *ClientRegistry) CollectGarbage(ctx context.Context, dryRun bool) (*GCReport, error) {
+	if r.lockManager == nil {
+		return nil, fmt.Errorf("lock manager not available")
+	}
+
+	report := &GCReport{
+		DryRun:            dryRun,
+		LiveInstances:     []string{},
+		SupervisorRunning: GetDaemonPID() != 0,
+		Findings:          []GCFinding{},
+	}
+
+	locks, err := r.lockManager.GetInstanceLocks()
+	if err != nil {
+		return nil, err
+	}
+
+	healthCtx, cancel := context.WithTimeout(ctx, gcHealthTimeout)
+	checked, err := r.lockManager.ListInstancesWithHealthCheck(healthCtx)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]grpc_health_v1.HealthCheckResponse_ServingStatus, len(checked))
+	for _, info := range checked {
+		statuses[info.Address] = info.Status
+	}
+
+	// Instances that must be kept: serving, unchecked, just started, or owned by the daemon
+	kept := make(map[string]bool)
+	handled := make(map[int]bool) // core ports dealt with by the instance pass
+	for _, lock := range locks {
+		status, wasChecked := statuses[lock.HeldBy]
+		if wasChecked && status == grpc_health_v1.HealthCheckResponse_SERVING {
+			kept[lock.HeldBy] = true
+			report.LiveInstances = append(report.LiveInstances, lock.HeldBy)
+			continue
+		}
+
+		managed := GetManagedInstance(lock.HeldBy)
+		if !wasChecked || time.Since(time.UnixMilli(lock.LockedAt)) < startupGracePeriod || (managed != nil && report.SupervisorRunning) {
+			kept[lock.HeldBy] = true
+			continue
+		}
+
+		if _, port, err := common.ParseHostPort(lock.HeldBy); err == nil {
+			handled[port] = true
+		}
+		report.Findings = append(report.Findings, r.staleInstanceFinding(lock, status, managed))
+	}
+
+	if !report.SupervisorRunning {
+		report.Findings = append(report.Findings, supervisorRecordFindings(kept, handled)...)
+	}
+
+	// Stale locks go first: one may be blocking the default-instance file
+	lockFindings, err := r.staleLockFindings(kept)
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = append(report.Findings, lockFindings...)
+	report.Findings = append(report.Findings, r.defaultInstanceFindings(report.LiveInstances, kept)...)
+
+	metadataFindings, err := r.orphanMetadataFindings()
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = append(report.Findings, metadataFindings...)
+
+	if dryRun {
+		return report, nil
+	}
+
+	for i := range report.Findings {
+		finding := &report.Findings[i]
+		if err := finding.fix(); err != nil {
+			finding.Error = err.Error()
+			continue
+		}
+		finding.Fixed = true
+	}
+
+	return report, nil
+}
+
+// staleInstanceFinding describes a registry row whose core isn't serving, along with any
+// clica processes still listening on its ports
+func (r *ClientRegistry) staleInstanceFinding(lock common.LockRow, status grpc_health_v1.HealthCheckResponse_ServingStatus, managed *ManagedInstance) GCFinding {
+	var pids []int
+	var actions []string
+	for _, address := range []string{lock.HeldBy, lock.LockTarget} {
+		if pid, name := clicaProcessOnPort(address); pid != 0 {
+			pids = append(pids, pid)
+			actions = append(actions, fmt.Sprintf("stop %s (PID %d)", name, pid))
+		}
+	}
+	actions = append(actions, "remove registry row and metadata")
+	if managed != nil {
+		actions = append(actions, "forget supervisor record")
+	}
+
+	return GCFinding{
+		Kind:   GCStaleInstance,
+		Target: lock.HeldBy,
+		Reason: fmt.Sprintf("core is not serving (%s)", status),
+		Action: strings.Join(actions, ", "),
+		fix: func() error {
+			for _, pid := range pids {
+				killProcessGroup(pid)
+			}
+			if err := r.lockManager.RemoveInstanceLock(lock.HeldBy); err != nil {
+				return err
+			}
+			if err := r.lockManager.RemoveInstanceMetadata(lock.HeldBy); err != nil {
+				return err
+			}
+			if managed != nil {
+				removeManagedInstance(managed.CorePort)
+			}
+			return nil
+		},
+	}
+}
+
+// supervisorRecordFindings reports supervisor records of instances that have no registry row:
+// their leftover processes are orphans, and records without processes are stale
+func supervisorRecordFindings(kept map[string]bool, handled map[int]bool) []GCFinding {
+	records, err := ListManagedInstances()
+	if err != nil {
+		return nil
+	}
+
+	var findings []GCFinding
+	for _, record := range records {
+		if kept[record.Address] || handled[record.CorePort] || time.Since(record.StartedAt) < startupGracePeriod {
+			continue
+		}
+
+		var pids []int
+		var actions []string
+		for _, pid := range []int{record.CorePID, record.HostPID} {
+			if name := clicaProcessName(pid); name != "" {
+				pids = append(pids, pid)
+				actions = append(actions, fmt.Sprintf("stop %s (PID %d)", name, pid))
+			}
+		}
+		actions = append(actions, "forget supervisor record")
+
+		finding := GCFinding{
+			Kind:   GCStaleSupervisorRecord,
+			Target: record.Address,
+			Reason: "instance is not registered and its processes have exited",
+			Action: strings.Join(actions, ", "),
+			fix: func() error {
+				for _, pid := range pids {
+					killProcessGroup(pid)
+				}
+				removeManagedInstance(record.CorePort)
+				return nil
+			},
+		}
+		if len(pids) > 0 {
+			finding.Kind = GCOrphanProcess
+			finding.Reason = "instance is not registered but its processes are still running"
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// defaultInstanceFindings reports a default-instance file that is unreadable or points at
+// an instance that is going away. The fix picks the first live instance, or removes the file.
+func (r *ClientRegistry) defaultInstanceFindings(live []string, kept map[string]bool) []GCFinding {
+	settingsPath := filepath.Join(r.configPath, common.SETTINGS_SUBFOLDER, "settings", "cli-default-instance.json")
+
+	address, err := sqlite.GetDefaultInstance(r.configPath)
+	reason := ""
+	switch {
+	case err != nil:
+		reason = err.Error()
+	case address != "" && !kept[address]:
+		reason = "default instance is not running"
+	default:
+		return nil
+	}
+
+	target := address
+	if target == "" {
+		target = settingsPath
+	}
+
+	if len(live) == 0 {
+		return []GCFinding{{
+			Kind:   GCDanglingDefault,
+			Target: target,
+			Reason: reason,
+			Action: "remove default-instance file",
+			fix: func() error {
+				if err := os.Remove(settingsPath); err != nil && !os.IsNotExist(err) {
+					return fmt.Errorf("failed to remove default instance file: %w", err)
+				}
+				return nil
+			},
+		}}
+	}
+
+	replacement := live[0]
+	return []GCFinding{{
+		Kind:   GCDanglingDefault,
+		Target: target,
+		Reason: reason,
+		Action: fmt.Sprintf("make %s the default", replacement),
+		fix: func() error {
+			return sqlite.SetDefaultInstance(r.configPath, replacement)
+		},
+	}}
+}
+
+// staleLockFindings reports file and folder locks whose holder is gone: an exited CLI
+// process, an instance that isn't running, or an unrecognised holder of an old lock
+func (r *ClientRegistry) staleLockFindings(kept map[string]bool) ([]GCFinding, error) {
+	locks, err := r.lockManager.GetHeldLocks()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []GCFinding
+	for _, lock := range locks {
+		reason := staleLockReason(lock, kept)
+		if reason == "" {
+			continue
+		}
+
+		id := lock.ID
+		findings = append(findings, GCFinding{
+			Kind:   GCStaleLock,
+			Target: fmt.Sprintf("%s lock on %s", lock.LockType, lock.LockTarget),
+			Reason: reason,
+			Action: "release lock",
+			fix: func() error {
+				return r.lockManager.RemoveLock(id)
+			},
+		})
+	}
+
+	return findings, nil
+}
+
+// staleLockReason explains why a lock is stale, or returns "" if its holder may still need it
+func staleLockReason(lock common.LockRow, kept map[string]bool) string {
+	if lock.LockType == "file" && time.Since(time.UnixMilli(lock.LockedAt)) > sqlite.DefaultFileLockTTL {
+		return fmt.Sprintf("lease of %s expired", lock.HeldBy)
+	}
+
+	if pid, ok := sqlite.LockHolderPID(lock.HeldBy); ok {
+		if !processAlive(pid) {
+			return fmt.Sprintf("held by exited CLI process %d", pid)
+		}
+		return ""
+	}
+
+	if _, _, err := common.ParseHostPort(lock.HeldBy); err == nil {
+		if !kept[lock.HeldBy] {
+			return fmt.Sprintf("held by instance %s, which is not running", lock.HeldBy)
+		}
+		return ""
+	}
+
+	if age := time.Since(time.UnixMilli(lock.LockedAt)); age > gcUnknownLockAge {
+		return fmt.Sprintf("held by %s for %s", lock.HeldBy, age.Round(time.Second))
+	}
+	return ""
+}
+
+// orphanMetadataFindings reports names and labels left behind by instances that are gone
+func (r *ClientRegistry) orphanMetadataFindings() ([]GCFinding, error) {
+	addresses, err := r.lockManager.ListOrphanInstanceMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []GCFinding
+	for _, address := range addresses {
+		findings = append(findings, GCFinding{
+			Kind:   GCOrphanMetadata,
+			Target: address,
+			Reason: "instance is no longer registered",
+			Action: "remove name and labels",
+			fix: func() error {
+				return r.lockManager.RemoveInstanceMetadata(address)
+			},
+		})
+	}
+
+	return findings, nil
+}
+
+// clicaProcessOnPort returns the clica process listening on the port of address, if any
+func clicaProcessOnPort(address string) (int, string) {
+	_, port, err := common.ParseHostPort(address)
+	if err != nil {
+		return 0, ""
+	}
+	pid, err := common.FindListeningPID(port)
+	if err != nil || pid == 0 {
+		return 0, ""
+	}
+	if name := clicaProcessName(pid); name != "" {
+		return pid, name
+	}
+	return 0, ""
+}
+
+// clicaProcessName returns "clica-core" or "clica-host" if pid is one of those processes.
+// Ports and PIDs are reused, so nothing else is ever stopped by gc.
+func clicaProcessName(pid int) string {
+	if !processAlive(pid) {
+		return ""
+	}
+	command, err := common.GetProcessCommand(pid)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case strings.Contains(command, "clica-host"):
+		return "clica-host"
+	case strings.Contains(command, "clica-core"):
+		return "clica-core"
+	}
+	return ""
+}
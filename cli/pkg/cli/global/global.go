@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/clica/cli/pkg/cli/theme"
 	"github.com/clica/cli/pkg/common"
 	"github.com/clica/grpc-go/client"
 	"github.com/muesli/termenv"
@@ -15,10 +16,13 @@ import (
 type Port uint16
 
 type GlobalConfig struct {
-	ConfigPath   string
-	Verbose      bool
-	OutputFormat string
-	CoreAddress  string
+	ConfigPath    string
+	Verbose       bool
+	OutputFormat  string
+	CoreAddress   string
+	Instance      string // instance name or address given with --instance
+	ScreenshotDir string // directory browser screenshots and MCP images are saved to with --save-screenshots
+	Accessible    bool   // screen reader friendly output and prompts, set with --accessible
 }
 
 var (
@@ -49,8 +53,8 @@ func InitializeGlobalConfig(cfg *GlobalConfig) error {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	// Configure lipgloss color profile based on output format
-	if cfg.OutputFormat == "plain" {
+	// Configure lipgloss color profile based on output format and NO_COLOR
+	if cfg.OutputFormat == "plain" || theme.NoColor() {
 		lipgloss.SetColorProfile(termenv.Ascii) // NO COLOR mode
 	}
 	// Otherwise lipgloss auto-detects terminal capabilities (default behavior)
@@ -67,17 +71,77 @@ func InitializeGlobalConfig(cfg *GlobalConfig) error {
 	return nil
 }
 
-// GetDefaultClient returns a client for the default instance or the address override
+// GetDefaultClient returns a client for the selected instance, falling back to the default instance
 func GetDefaultClient(ctx context.Context) (*client.ClicaClient, error) {
-	if Config.CoreAddress != "" && Config.CoreAddress != fmt.Sprintf("localhost:%d", common.DEFAULT_CLICA_CORE_PORT) {
-		// User specified a specific address, use that
-		return Clients.GetRegistry().GetClient(ctx, Config.CoreAddress)
+	address, err := SelectedInstance()
+	if err != nil {
+		return nil, err
+	}
+	if address != "" {
+		return Clients.GetRegistry().GetClient(ctx, address)
 	}
 
 	// Use the default instance from registry
 	return Clients.GetRegistry().GetDefaultClient(ctx)
 }
 
+// SelectedInstance returns the instance to use when a command isn't given an address of its own:
+// the --instance flag, then a non-default --address, then the instance whose workspace contains
+// the current directory. Returns "" when the default instance should be used.
+func SelectedInstance() (string, error) {
+	registry := Clients.GetRegistry()
+
+	if Config.Instance != "" {
+		return registry.ResolveInstance(Config.Instance)
+	}
+
+	if Config.CoreAddress != "" && Config.CoreAddress != fmt.Sprintf("localhost:%d", common.DEFAULT_CLICA_CORE_PORT) {
+		// User specified a specific address (or name), use that
+		return registry.ResolveInstance(Config.CoreAddress)
+	}
+
+	return WorkspaceInstance(), nil
+}
+
+// WorkspaceInstance returns the instance whose workspace contains the current directory, or ""
+func WorkspaceInstance() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return Clients.GetRegistry().GetWorkspaceInstance(cwd)
+}
+
+// ClaimWorkspacePoolInstance claims an idle pool instance for the current directory,
+// or returns nil if its pool has none
+func ClaimWorkspacePoolInstance(ctx context.Context) (*PoolLease, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, nil
+	}
+	workspace, err := PoolWorkspace(cwd)
+	if err != nil {
+		return nil, nil
+	}
+	return Clients.ClaimPoolInstance(ctx, workspace)
+}
+
+// CurrentInstanceAddress returns the address GetDefaultClient connects to, or "" if there is none
+func CurrentInstanceAddress() string {
+	if address, err := SelectedInstance(); err == nil && address != "" {
+		return address
+	}
+	return Clients.GetRegistry().GetDefaultInstance()
+}
+
+// ResolveInstanceAddress resolves an instance name or address given to a command into an address
+func ResolveInstanceAddress(ref string) (string, error) {
+	if Clients == nil {
+		return ref, nil
+	}
+	return Clients.GetRegistry().ResolveInstance(ref)
+}
+
 // GetClientForAddress returns a client for a specific address
 func GetClientForAddress(ctx context.Context, address string) (*client.ClicaClient, error) {
 	return Clients.GetRegistry().GetClient(ctx, address)
@@ -0,0 +1,469 @@
+package global
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"syscall"
+
+	"github.com/clica/cli/pkg/cli/sqlite"
+	"github.com/clica/cli/pkg/common"
+	"github.com/clica/grpc-go/clica"
+)
+
+// PoolLabel marks pool instances in their metadata. Pool instances are only used through a claim,
+// never picked up by workspace affinity or as the default instance.
+const PoolLabel = "clica.pool"
+
+const (
+	defaultPoolMaxUses = 10            // tasks a pool instance runs before it is recycled
+	poolClaimPrefix    = "pool-claim:" // file lock target claiming a pool instance: pool-claim:<address>
+	poolFillPrefix     = "pool-fill:"  // file lock target serializing refills: pool-fill:<workspace>
+)
+
+// Pool member states
+const (
+	PoolIdle     = "idle"     // warm and claimable
+	PoolClaimed  = "claimed"  // running a task
+	PoolStarting = "starting" // started, not serving yet
+	PoolDead     = "dead"     // core not running; the daemon or gc cleans it up
+)
+
+// PoolConfig is the desired number of warm instances per workspace
+type PoolConfig struct {
+	Sizes   map[string]int `json:"sizes"`
+	MaxUses int            `json:"max_uses,omitempty"`
+}
+
+// PoolMember is a pool instance and what it is doing
+type PoolMember struct {
+	*ManagedInstance
+	State     string `json:"state"`
+	ClaimedBy string `json:"claimed_by,omitempty"`
+}
+
+// PoolLease is a pool instance claimed for one task. Return it when the task is done.
+type PoolLease struct {
+	Instance *common.CoreInstanceInfo
+
+	clients  *ClicaClients
+	managed  *ManagedInstance
+	claim    *sqlite.FileLock
+	settings string // instanceSettings when claimed, "" if they couldn't be read
+}
+
+// instanceSettingsFields are the state fields a session can change for the whole instance rather
+// than for its task: provider, model and credentials, mode, and global settings
+var instanceSettingsFields = []string{
+	"apiConfiguration",
+	"autoApprovalSettings",
+	"browserSettings",
+	"mode",
+	"yoloModeToggled",
+	"planActSeparateModelsSetting",
+	"strictPlanModeEnabled",
+	"telemetrySetting",
+	"enableCheckpointsSetting",
+	"preferredLanguage",
+	"customPrompt",
+	"openaiReasoningEffort",
+	"useAutoCondense",
+	"autoCondenseThreshold",
+	"maxConsecutiveMistakes",
+	"shellIntegrationTimeout",
+	"terminalOutputLineLimit",
+	"defaultTerminalProfile",
+}
+
+// poolConfigPath returns the file holding the pool sizes
+func poolConfigPath() string {
+	return filepath.Join(GetSupervisorDir(), "pool.json")
+}
+
+// poolClaimTarget returns the file lock target that claims the pool instance at address
+func poolClaimTarget(address string) string {
+	return poolClaimPrefix + address
+}
+
+// LoadPoolConfig reads the pool sizes; a missing file means no pool
+func LoadPoolConfig() (*PoolConfig, error) {
+	cfg := &PoolConfig{Sizes: map[string]int{}, MaxUses: defaultPoolMaxUses}
+
+	data, err := os.ReadFile(poolConfigPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read pool config: %w", err)
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse pool config: %w", err)
+	}
+	if cfg.Sizes == nil {
+		cfg.Sizes = map[string]int{}
+	}
+	if cfg.MaxUses <= 0 {
+		cfg.MaxUses = defaultPoolMaxUses
+	}
+	return cfg, nil
+}
+
+// SavePoolConfig writes the pool sizes
+func SavePoolConfig(cfg *PoolConfig) error {
+	if err := os.MkdirAll(GetSupervisorDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create supervisor directory: %w", err)
+	}
+
+	// Workspaces without a pool don't need an entry
+	for workspace, size := range cfg.Sizes {
+		if size <= 0 {
+			delete(cfg.Sizes, workspace)
+		}
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal pool config: %w", err)
+	}
+
+	path := poolConfigPath()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write pool config: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// PoolWorkspace returns the workspace a pool for dir is keyed by
+func PoolWorkspace(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve workspace %s: %w", dir, err)
+	}
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	return abs, nil
+}
+
+// ListPoolMembers returns the pool instances of a workspace, or of all workspaces if workspace is empty
+func (c *ClicaClients) ListPoolMembers(ctx context.Context, workspace string) ([]*PoolMember, error) {
+	instances, err := ListManagedInstances()
+	if err != nil {
+		return nil, err
+	}
+
+	var members []*PoolMember
+	for _, instance := range instances {
+		if !instance.Pool || (workspace != "" && instance.Workspace != workspace) {
+			continue
+		}
+
+		member := &PoolMember{ManagedInstance: instance}
+		if lock, err := c.registry.lockManager.GetFileLock(poolClaimTarget(instance.Address)); err == nil && lock != nil {
+			member.ClaimedBy = lock.HeldBy
+		}
+
+		switch {
+		case !instance.coreRunning():
+			member.State = PoolDead
+		case member.ClaimedBy != "":
+			member.State = PoolClaimed
+		case !common.IsInstanceHealthy(ctx, instance.Address):
+			member.State = PoolStarting
+		default:
+			member.State = PoolIdle
+		}
+		members = append(members, member)
+	}
+
+	// Oldest first, so claims keep the longest-warmed instances busy
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].StartedAt.Before(members[j].StartedAt)
+	})
+	return members, nil
+}
+
+// ClaimPoolInstance claims an idle pool instance of workspace for one task.
+// Returns nil without an error if the workspace has no idle pool instance.
+func (c *ClicaClients) ClaimPoolInstance(ctx context.Context, workspace string) (*PoolLease, error) {
+	members, err := c.ListPoolMembers(ctx, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range members {
+		if member.State != PoolIdle {
+			continue
+		}
+
+		// The claim lock is what makes claiming atomic: another CLI may be looking at the same member
+		claim, err := c.registry.lockManager.TryAcquireFileLock(poolClaimTarget(member.Address), sqlite.ProcessLockHolder(), 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to claim pool instance %s: %w", member.Address, err)
+		}
+		if claim == nil {
+			continue
+		}
+
+		// Re-check under the claim: the member may have been recycled since it was listed
+		instance, err := c.registry.GetInstance(member.Address)
+		managed := GetManagedInstance(member.Address)
+		if err != nil || instance == nil || managed == nil || !managed.Pool {
+			claim.Release()
+			continue
+		}
+
+		member.Uses++
+		saveManagedInstance(member.ManagedInstance)
+		TouchInstanceActivity(member.Address)
+
+		settings, _ := instanceSettings(ctx, member.Address)
+		return &PoolLease{Instance: instance, clients: c, managed: member.ManagedInstance, claim: claim, settings: settings}, nil
+	}
+
+	return nil, nil
+}
+
+// Return gives a claimed instance back to the pool after its task. The instance is recycled
+// (stopped, then replaced by a refill) instead if recycle is set, it lost its claim, it reached
+// its use limit, it is unhealthy, the pool has shrunk since it was claimed, or the session
+// changed its model, credentials, mode or settings, which the next claimer must not inherit.
+func (l *PoolLease) Return(ctx context.Context, recycle bool) {
+	defer l.claim.Release()
+
+	cfg, err := LoadPoolConfig()
+	if err != nil {
+		recycle = true
+		cfg = &PoolConfig{MaxUses: defaultPoolMaxUses}
+	}
+
+	switch {
+	case recycle, l.claim.Lost(), l.managed.Uses >= cfg.MaxUses:
+		recycle = true
+	case !common.IsInstanceHealthy(ctx, l.Instance.Address):
+		recycle = true
+	case l.poolSize() > cfg.Sizes[l.managed.Workspace]:
+		recycle = true
+	case l.settingsChanged(ctx):
+		recycle = true
+	}
+
+	// Clear the finished task so the next claimer starts from a clean slate. Failing to is
+	// harmless: creating a task cancels the previous one anyway.
+	if !recycle {
+		if client, err := l.clients.registry.GetClient(ctx, l.Instance.Address); err == nil {
+			client.Task.CancelTask(ctx, &clica.EmptyRequest{})
+		} else {
+			recycle = true
+		}
+	}
+
+	if !recycle {
+		TouchInstanceActivity(l.Instance.Address)
+		return
+	}
+
+	if Config.Verbose {
+		fmt.Printf("Recycling pool instance %s\n", l.Instance.Address)
+	}
+	stopPoolInstance(l.clients.registry, l.managed)
+	if cfg.Sizes[l.managed.Workspace] > 0 {
+		RequestPoolFill(l.managed.Workspace)
+	}
+}
+
+// Detach takes a claimed instance out of the pool, for a task left running after the CLI exits.
+// It becomes a regular instance (stopped by the daemon once idle) and the pool is refilled.
+func (l *PoolLease) Detach() {
+	defer l.claim.Release()
+
+	// Update the record before the claim is released, so nobody can claim it in between
+	l.managed.Pool = false
+	if err := saveManagedInstance(l.managed); err != nil && Config.Verbose {
+		fmt.Printf("Warning: failed to take %s out of the pool: %v\n", l.Instance.Address, err)
+	}
+	if meta := l.clients.registry.GetInstanceMetadata(l.Instance.Address); meta != nil {
+		delete(meta.Labels, PoolLabel)
+		l.clients.registry.SetInstanceMetadata(*meta)
+	}
+
+	if cfg, err := LoadPoolConfig(); err == nil && cfg.Sizes[l.managed.Workspace] > 0 {
+		RequestPoolFill(l.managed.Workspace)
+	}
+}
+
+// settingsChanged reports whether the instance's settings differ from when it was claimed,
+// or can't be compared
+func (l *PoolLease) settingsChanged(ctx context.Context) bool {
+	settings, err := instanceSettings(ctx, l.Instance.Address)
+	return err != nil || l.settings == "" || settings != l.settings
+}
+
+// instanceSettings returns the instanceSettingsFields of an instance's state as JSON.
+// Reading state directly doesn't count as activity.
+func instanceSettings(ctx context.Context, address string) (string, error) {
+	stateJSON, err := FetchInstanceState(ctx, address)
+	if err != nil {
+		return "", err
+	}
+
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(stateJSON), &state); err != nil {
+		return "", fmt.Errorf("failed to parse state: %w", err)
+	}
+
+	// Map keys are marshaled sorted, so equal settings give equal JSON
+	settings := make(map[string]json.RawMessage, len(instanceSettingsFields))
+	for _, field := range instanceSettingsFields {
+		if value, ok := state[field]; ok {
+			settings[field] = value
+		}
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	return string(data), nil
+}
+
+// poolSize counts the pool instances of the lease's workspace that are still running
+func (l *PoolLease) poolSize() int {
+	instances, err := ListManagedInstances()
+	if err != nil {
+		return 0
+	}
+
+	count := 0
+	for _, instance := range instances {
+		if instance.Pool && instance.Workspace == l.managed.Workspace && instance.coreRunning() {
+			count++
+		}
+	}
+	return count
+}
+
+// FillPool starts pool instances until the workspace has as many as configured. Only one
+// fill runs per workspace at a time; if another one is running this returns immediately.
+func (c *ClicaClients) FillPool(ctx context.Context, workspace string) (int, error) {
+	fill, err := c.registry.lockManager.TryAcquireFileLock(poolFillPrefix+workspace, sqlite.ProcessLockHolder(), 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock pool for %s: %w", workspace, err)
+	}
+	if fill == nil {
+		return 0, nil
+	}
+	defer fill.Release()
+
+	cfg, err := LoadPoolConfig()
+	if err != nil {
+		return 0, err
+	}
+
+	members, err := c.ListPoolMembers(ctx, workspace)
+	if err != nil {
+		return 0, err
+	}
+	running := 0
+	for _, member := range members {
+		if member.State != PoolDead {
+			running++
+		}
+	}
+
+	started := 0
+	for running+started < cfg.Sizes[workspace] {
+		if ctx.Err() != nil {
+			return started, ctx.Err()
+		}
+		if _, err := c.StartNewInstanceWithOptions(ctx, InstanceOptions{Workspace: workspace, Pool: true}); err != nil {
+			return started, fmt.Errorf("failed to start pool instance for %s: %w", workspace, err)
+		}
+		started++
+	}
+	return started, nil
+}
+
+// DrainPool stops the idle pool instances of a workspace, or of all workspaces if workspace
+// is empty. Claimed instances finish their task and are recycled when returned.
+func (c *ClicaClients) DrainPool(ctx context.Context, workspace string, keep int) (int, error) {
+	members, err := c.ListPoolMembers(ctx, workspace)
+	if err != nil {
+		return 0, err
+	}
+
+	// Count what stays per workspace, so resizing down stops only the excess
+	running := map[string]int{}
+	for _, member := range members {
+		if member.State != PoolDead {
+			running[member.Workspace]++
+		}
+	}
+
+	stopped := 0
+	// Newest first: the oldest instances are the warmest
+	for i := len(members) - 1; i >= 0; i-- {
+		member := members[i]
+		if running[member.Workspace] <= keep {
+			continue
+		}
+		if member.State == PoolClaimed {
+			continue
+		}
+
+		claim, err := c.registry.lockManager.TryAcquireFileLock(poolClaimTarget(member.Address), sqlite.ProcessLockHolder(), 0)
+		if err != nil {
+			return stopped, fmt.Errorf("failed to claim pool instance %s: %w", member.Address, err)
+		}
+		if claim == nil {
+			continue
+		}
+		stopPoolInstance(c.registry, member.ManagedInstance)
+		claim.Release()
+
+		running[member.Workspace]--
+		stopped++
+	}
+	return stopped, nil
+}
+
+// stopPoolInstance stops a pool instance's processes and forgets it. Dead members' PIDs may
+// belong to other programs by now, so only processes that are still the instance's are stopped.
+func stopPoolInstance(registry *ClientRegistry, instance *ManagedInstance) {
+	removeManagedInstance(instance.CorePort)
+	if instance.coreRunning() {
+		killProcessGroup(instance.CorePID)
+	}
+	if instance.hostRunning() {
+		killProcessGroup(instance.HostPID)
+	}
+	registry.lockManager.RemoveInstanceLock(instance.Address)
+	registry.RemoveInstanceMetadata(instance.Address)
+}
+
+// RequestPoolFill refills a workspace's pool in the background. The daemon refills pools on its
+// own, so this only spawns a detached 'clica pool fill' when no daemon is running.
+func RequestPoolFill(workspace string) {
+	if GetDaemonPID() != 0 {
+		return
+	}
+
+	execPath, err := os.Executable()
+	if err != nil {
+		return
+	}
+
+	cmd := exec.Command(execPath, "pool", "fill", "--workspace", workspace)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if err := cmd.Start(); err != nil {
+		if Config.Verbose {
+			fmt.Printf("Warning: failed to start pool refill: %v\n", err)
+		}
+		return
+	}
+	reapProcess(cmd.Process)
+}
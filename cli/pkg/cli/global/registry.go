@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/clica/cli/pkg/cli/sqlite"
@@ -72,6 +73,112 @@ func (r *ClientRegistry) GetInstance(address string) (*common.CoreInstanceInfo,
 	return r.lockManager.GetInstanceInfo(address)
 }
 
+// SetInstanceMetadata stores the name, workspace and labels of an instance
+func (r *ClientRegistry) SetInstanceMetadata(meta common.InstanceMetadata) error {
+	if r.lockManager == nil {
+		return fmt.Errorf("lock manager not available")
+	}
+
+	return r.lockManager.SetInstanceMetadata(meta)
+}
+
+// GetInstanceMetadata returns the metadata of an instance, or nil if it has none
+func (r *ClientRegistry) GetInstanceMetadata(address string) *common.InstanceMetadata {
+	if r.lockManager == nil {
+		return nil
+	}
+
+	meta, err := r.lockManager.GetInstanceMetadata(address)
+	if err != nil {
+		if Config != nil && Config.Verbose {
+			fmt.Printf("[DEBUG] Failed to read metadata for %s: %v\n", address, err)
+		}
+		return nil
+	}
+	return meta
+}
+
+// ListInstanceMetadata returns the metadata of all running instances keyed by address
+func (r *ClientRegistry) ListInstanceMetadata() map[string]*common.InstanceMetadata {
+	result := make(map[string]*common.InstanceMetadata)
+	if r.lockManager == nil {
+		return result
+	}
+
+	metadata, err := r.lockManager.ListInstanceMetadata()
+	if err != nil {
+		if Config != nil && Config.Verbose {
+			fmt.Printf("[DEBUG] Failed to list instance metadata: %v\n", err)
+		}
+		return result
+	}
+
+	for i := range metadata {
+		result[metadata[i].Address] = &metadata[i]
+	}
+	return result
+}
+
+// RemoveInstanceMetadata forgets the name, workspace and labels of an instance
+func (r *ClientRegistry) RemoveInstanceMetadata(address string) {
+	if r.lockManager == nil {
+		return
+	}
+
+	if err := r.lockManager.RemoveInstanceMetadata(address); err != nil && Config != nil && Config.Verbose {
+		fmt.Printf("[DEBUG] Failed to remove metadata for %s: %v\n", address, err)
+	}
+}
+
+// ResolveInstance turns an instance reference into an address. References containing
+// a colon are addresses and are returned unchanged; anything else is an instance name.
+func (r *ClientRegistry) ResolveInstance(ref string) (string, error) {
+	if ref == "" || strings.Contains(ref, ":") {
+		return ref, nil
+	}
+
+	for address, meta := range r.ListInstanceMetadata() {
+		if meta.Name == ref {
+			return address, nil
+		}
+	}
+
+	return "", fmt.Errorf("no running instance named '%s'. Run 'clica instance list' to see available instances", ref)
+}
+
+// GetWorkspaceInstance returns the instance whose workspace contains dir, preferring
+// the most specific workspace, or "" if no instance has a matching workspace. Pool
+// instances are skipped.
+func (r *ClientRegistry) GetWorkspaceInstance(dir string) string {
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		dir = resolved
+	}
+
+	bestAddress := ""
+	bestLen := -1
+	for address, meta := range r.ListInstanceMetadata() {
+		// Pool instances are only used through a claim
+		if meta.Workspace == "" || meta.Labels[PoolLabel] != "" || !isWithinDir(dir, meta.Workspace) {
+			continue
+		}
+		if len(meta.Workspace) > bestLen {
+			bestAddress = address
+			bestLen = len(meta.Workspace)
+		}
+	}
+
+	return bestAddress
+}
+
+// isWithinDir reports whether path is dir or one of its descendants
+func isWithinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
 // GetClient returns a connected client for the given address (created on-demand)
 func (r *ClientRegistry) GetClient(ctx context.Context, address string) (*client.ClicaClient, error) {
 	// Verify instance exists in SQLite
@@ -281,9 +388,12 @@ func (r *ClientRegistry) EnsureDefaultInstance(instances []*common.CoreInstanceI
 		return nil
 	}
 
-	// If we have instances but no default, pick the first one
+	// If we have instances but no default, pick the first one that may be the default
 	if currentDefault == "" {
-		return sqlite.SetDefaultInstance(r.configPath, instances[0].Address)
+		if candidate := r.defaultCandidate(instances); candidate != "" {
+			return sqlite.SetDefaultInstance(r.configPath, candidate)
+		}
+		return nil
 	}
 
 	// Validate current default still exists in the instances
@@ -297,8 +407,23 @@ func (r *ClientRegistry) EnsureDefaultInstance(instances []*common.CoreInstanceI
 
 	if !defaultExists {
 		// Current default doesn't exist, pick a new one from available instances
-		return sqlite.SetDefaultInstance(r.configPath, instances[0].Address)
+		if candidate := r.defaultCandidate(instances); candidate != "" {
+			return sqlite.SetDefaultInstance(r.configPath, candidate)
+		}
 	}
 
 	return nil
 }
+
+// defaultCandidate returns the first instance that may become the default, or "" if there is
+// none. Pool instances and instances started with NoDefault are skipped.
+func (r *ClientRegistry) defaultCandidate(instances []*common.CoreInstanceInfo) string {
+	metadata := r.ListInstanceMetadata()
+	for _, instance := range instances {
+		if meta := metadata[instance.Address]; meta != nil && (meta.Labels[PoolLabel] != "" || meta.Labels[NoDefaultLabel] != "") {
+			continue
+		}
+		return instance.Address
+	}
+	return ""
+}
@@ -0,0 +1,569 @@
+package global
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/clica/cli/pkg/common"
+	"github.com/clica/grpc-go/clica"
+	"github.com/clica/grpc-go/client"
+)
+
+// ManagedInstance is an instance started by this CLI, tracked so the supervisor
+// can restart its core and clean up its host bridge
+type ManagedInstance struct {
+	Address   string    `json:"address"`
+	CorePort  int       `json:"core_port"`
+	HostPort  int       `json:"host_port"`
+	CorePID   int       `json:"core_pid"`
+	HostPID   int       `json:"host_pid"`
+	Workspace string    `json:"workspace,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	Restarts  int       `json:"restarts"`
+	Pool      bool      `json:"pool,omitempty"` // warm pool member, only used through a claim
+	Uses      int       `json:"uses,omitempty"` // tasks run by a pool member
+}
+
+// SupervisorOptions configures RunSupervisor
+type SupervisorOptions struct {
+	PollInterval     time.Duration // how often instances are checked
+	IdleTTL          time.Duration // shut down instances idle longer than this (0 disables)
+	Restart          bool          // restart crashed cores
+	MaxRestarts      int           // consecutive restarts before giving up on an instance
+	MaxBackoff       time.Duration // upper bound for the delay between restarts
+	UnhealthyChecks  int           // failed health checks before a running core is considered hung
+	HealthyResetTime time.Duration // healthy time after which the restart count is reset
+}
+
+// DefaultSupervisorOptions returns the options used by 'clica daemon'
+func DefaultSupervisorOptions() SupervisorOptions {
+	return SupervisorOptions{
+		PollInterval:     5 * time.Second,
+		IdleTTL:          0,
+		Restart:          true,
+		MaxRestarts:      5,
+		MaxBackoff:       time.Minute,
+		UnhealthyChecks:  3,
+		HealthyResetTime: 5 * time.Minute,
+	}
+}
+
+const (
+	activityTouchInterval = 30 * time.Second // limits how often client activity is written to disk
+	startupGracePeriod    = 60 * time.Second // time a (re)started core gets to become healthy
+)
+
+// GetSupervisorDir returns the directory holding managed instance records
+func GetSupervisorDir() string {
+	return filepath.Join(Config.ConfigPath, common.SETTINGS_SUBFOLDER, "supervisor")
+}
+
+// managedInstancePath returns the record path for an instance
+func managedInstancePath(corePort int) string {
+	return filepath.Join(GetSupervisorDir(), fmt.Sprintf("%d.json", corePort))
+}
+
+// activityPath returns the file whose modification time marks an instance's last use
+func activityPath(corePort int) string {
+	return filepath.Join(GetSupervisorDir(), fmt.Sprintf("%d.activity", corePort))
+}
+
+// daemonPIDPath returns the PID file of the running supervisor daemon
+func daemonPIDPath() string {
+	return filepath.Join(GetSupervisorDir(), "daemon.pid")
+}
+
+// recordManagedInstance saves the PIDs of a newly started instance. Best effort:
+// failing to record only means the instance won't be supervised.
+func recordManagedInstance(instance *ManagedInstance) {
+	if err := saveManagedInstance(instance); err != nil {
+		if Config.Verbose {
+			fmt.Printf("Warning: failed to record instance for supervision: %v\n", err)
+		}
+		return
+	}
+	TouchInstanceActivity(instance.Address)
+}
+
+// saveManagedInstance writes an instance record
+func saveManagedInstance(instance *ManagedInstance) error {
+	if err := os.MkdirAll(GetSupervisorDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create supervisor directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(instance, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal instance record: %w", err)
+	}
+
+	// Write atomically so the daemon never reads a partial record
+	path := managedInstancePath(instance.CorePort)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write instance record: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// removeManagedInstance deletes an instance's record and activity marker
+func removeManagedInstance(corePort int) {
+	os.Remove(managedInstancePath(corePort))
+	os.Remove(activityPath(corePort))
+}
+
+// UnmanageInstance stops supervising the instance at address, e.g. before it is killed on purpose
+func UnmanageInstance(address string) {
+	if _, port, err := common.ParseHostPort(address); err == nil {
+		removeManagedInstance(port)
+	}
+}
+
+// GetManagedInstance returns the record of a tracked instance, or nil if it isn't tracked
+func GetManagedInstance(address string) *ManagedInstance {
+	_, port, err := common.ParseHostPort(address)
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(managedInstancePath(port))
+	if err != nil {
+		return nil
+	}
+	var instance ManagedInstance
+	if err := json.Unmarshal(data, &instance); err != nil {
+		return nil
+	}
+	return &instance
+}
+
+// ListManagedInstances returns all tracked instances sorted by port
+func ListManagedInstances() ([]*ManagedInstance, error) {
+	entries, err := os.ReadDir(GetSupervisorDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*ManagedInstance{}, nil
+		}
+		return nil, fmt.Errorf("failed to read supervisor directory: %w", err)
+	}
+
+	var instances []*ManagedInstance
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(GetSupervisorDir(), entry.Name()))
+		if err != nil {
+			continue
+		}
+		var instance ManagedInstance
+		if err := json.Unmarshal(data, &instance); err != nil {
+			continue
+		}
+		instances = append(instances, &instance)
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return instances[i].CorePort < instances[j].CorePort
+	})
+	return instances, nil
+}
+
+// TouchInstanceActivity marks an instance as used now, if it is supervised. Only task traffic
+// counts: listing or inspecting instances must not keep idle ones from being shut down.
+func TouchInstanceActivity(address string) {
+	_, port, err := common.ParseHostPort(address)
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(managedInstancePath(port)); err != nil {
+		return
+	}
+
+	path := activityPath(port)
+	if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < activityTouchInterval {
+		return
+	}
+
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		if f, err := os.Create(path); err == nil {
+			f.Close()
+		}
+	}
+}
+
+// InstanceLastActivity returns when an instance was last used by a client
+func InstanceLastActivity(instance *ManagedInstance) time.Time {
+	if info, err := os.Stat(activityPath(instance.CorePort)); err == nil {
+		return info.ModTime()
+	}
+	return instance.StartedAt
+}
+
+// GetDaemonPID returns the PID of the running supervisor daemon, or 0 if none is running
+func GetDaemonPID() int {
+	data, err := os.ReadFile(daemonPIDPath())
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || !processAlive(pid) {
+		return 0
+	}
+	return pid
+}
+
+// processAlive reports whether a process with the given PID exists
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// ownsInstanceProcess reports whether pid is still the named clica process ("clica-core" or
+// "clica-host") of the instance serving port. PIDs are reused, and signalling a recorded PID
+// that now belongs to another program would take that program's whole process group down.
+func ownsInstanceProcess(pid int, name string, port int) bool {
+	if clicaProcessName(pid) != name {
+		return false
+	}
+	// Whatever listens on the port must be the process or one of its children
+	listener, err := common.FindListeningPID(port)
+	if err != nil || listener == 0 || listener == pid {
+		return true
+	}
+	pgid, err := syscall.Getpgid(listener)
+	return err == nil && pgid == pid
+}
+
+// coreRunning reports whether the instance's recorded core PID is still its core
+func (i *ManagedInstance) coreRunning() bool {
+	return ownsInstanceProcess(i.CorePID, "clica-core", i.CorePort)
+}
+
+// hostRunning reports whether the instance's recorded host bridge PID is still its host bridge
+func (i *ManagedInstance) hostRunning() bool {
+	return ownsInstanceProcess(i.HostPID, "clica-host", i.HostPort)
+}
+
+// killProcessGroup sends SIGTERM to a process group, then SIGKILL if it is still alive after a grace period
+func killProcessGroup(pid int) {
+	if pid <= 0 {
+		return
+	}
+	// Children are started with Setpgid, so the PID is also the process group ID
+	if err := syscall.Kill(-pid, syscall.SIGTERM); err != nil {
+		syscall.Kill(pid, syscall.SIGTERM)
+	}
+
+	for i := 0; i < 10; i++ {
+		if !processAlive(pid) {
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+
+	if err := syscall.Kill(-pid, syscall.SIGKILL); err != nil {
+		syscall.Kill(pid, syscall.SIGKILL)
+	}
+}
+
+// supervisedState is the supervisor's in-memory bookkeeping for one instance
+type supervisedState struct {
+	failedChecks int
+	nextRestart  time.Time
+	healthySince time.Time
+}
+
+// Supervisor watches managed instances
+type Supervisor struct {
+	opts     SupervisorOptions
+	registry *ClientRegistry
+	states   map[int]*supervisedState
+}
+
+// RunSupervisor watches managed instances until ctx is cancelled: crashed or hung cores are
+// restarted with exponential backoff, the paired host is killed when a core can't be brought
+// back, and instances idle longer than IdleTTL are shut down. Only one daemon runs at a time.
+func RunSupervisor(ctx context.Context, opts SupervisorOptions) error {
+	if pid := GetDaemonPID(); pid != 0 && pid != os.Getpid() {
+		return fmt.Errorf("supervisor daemon already running (PID %d)", pid)
+	}
+
+	if err := os.MkdirAll(GetSupervisorDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create supervisor directory: %w", err)
+	}
+	if err := os.WriteFile(daemonPIDPath(), []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		return fmt.Errorf("failed to write daemon PID file: %w", err)
+	}
+	defer os.Remove(daemonPIDPath())
+
+	s := &Supervisor{
+		opts:     opts,
+		registry: Clients.GetRegistry(),
+		states:   make(map[int]*supervisedState),
+	}
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.checkAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			// Restarted cores are our children; they keep running after we exit
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkAll runs one supervision pass over all managed instances
+func (s *Supervisor) checkAll(ctx context.Context) {
+	instances, err := ListManagedInstances()
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return
+	}
+
+	seen := make(map[int]bool, len(instances))
+	for _, instance := range instances {
+		seen[instance.CorePort] = true
+		s.check(ctx, instance)
+	}
+
+	s.fillPools(ctx)
+
+	// Forget state for instances that are no longer tracked
+	for port := range s.states {
+		if !seen[port] {
+			delete(s.states, port)
+		}
+	}
+}
+
+// check supervises a single instance
+func (s *Supervisor) check(ctx context.Context, instance *ManagedInstance) {
+	state := s.states[instance.CorePort]
+	if state == nil {
+		state = &supervisedState{}
+		s.states[instance.CorePort] = state
+	}
+
+	// A recycled PID counts as a crashed core: its lock is dropped and nothing is signalled
+	coreAlive := instance.coreRunning()
+	healthy := false
+	if coreAlive {
+		probeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+		healthy = common.IsInstanceHealthy(probeCtx, instance.Address)
+		cancel()
+	}
+
+	if healthy {
+		state.failedChecks = 0
+		if state.healthySince.IsZero() {
+			state.healthySince = time.Now()
+		}
+		// A core that has stayed up for a while earns back its restart budget
+		if instance.Restarts > 0 && time.Since(state.healthySince) > s.opts.HealthyResetTime {
+			instance.Restarts = 0
+			saveManagedInstance(instance)
+		}
+		s.checkIdle(ctx, instance)
+		return
+	}
+
+	state.healthySince = time.Time{}
+	if coreAlive && time.Since(instance.StartedAt) < startupGracePeriod {
+		// Still starting up
+		return
+	}
+	if coreAlive {
+		// Give a running core a few chances before treating it as hung
+		state.failedChecks++
+		if state.failedChecks < s.opts.UnhealthyChecks {
+			return
+		}
+		fmt.Printf("Core %s (PID %d) failed %d health checks, stopping it\n", instance.Address, instance.CorePID, state.failedChecks)
+		killProcessGroup(instance.CorePID)
+	} else {
+		// Instances stopped on purpose ('clica instance kill') are no longer tracked,
+		// so a tracked core that isn't running has crashed
+		fmt.Printf("Core %s (PID %d) is not running\n", instance.Address, instance.CorePID)
+	}
+
+	// The core is gone; its lock is stale either way
+	s.registry.lockManager.RemoveInstanceLock(instance.Address)
+
+	if !s.opts.Restart || instance.Restarts >= s.opts.MaxRestarts {
+		if s.opts.Restart {
+			fmt.Printf("Giving up on %s after %d restarts\n", instance.Address, instance.Restarts)
+		}
+		s.retire(instance)
+		return
+	}
+
+	if time.Now().Before(state.nextRestart) {
+		return
+	}
+
+	if err := s.restartCore(instance); err != nil {
+		fmt.Printf("Warning: failed to restart core %s: %v\n", instance.Address, err)
+	}
+	state.failedChecks = 0
+	state.nextRestart = time.Now().Add(s.backoff(instance.Restarts))
+}
+
+// checkIdle shuts down an instance that has been idle longer than IdleTTL
+func (s *Supervisor) checkIdle(ctx context.Context, instance *ManagedInstance) {
+	// Pool instances are meant to sit idle
+	if s.opts.IdleTTL <= 0 || instance.Pool {
+		return
+	}
+
+	idleFor := time.Since(InstanceLastActivity(instance))
+	if idleFor < s.opts.IdleTTL {
+		return
+	}
+
+	// Never stop an instance in the middle of a task
+	if instanceHasActiveTask(ctx, instance.Address) {
+		return
+	}
+
+	fmt.Printf("Instance %s idle for %s, shutting down\n", instance.Address, idleFor.Round(time.Second))
+	if instance.coreRunning() {
+		killProcessGroup(instance.CorePID)
+	}
+	s.registry.lockManager.RemoveInstanceLock(instance.Address)
+	s.retire(instance)
+}
+
+// fillPools tops up the warm pool of every workspace that has one
+func (s *Supervisor) fillPools(ctx context.Context) {
+	cfg, err := LoadPoolConfig()
+	if err != nil {
+		fmt.Printf("Warning: %v\n", err)
+		return
+	}
+
+	for workspace := range cfg.Sizes {
+		started, err := Clients.FillPool(ctx, workspace)
+		if started > 0 {
+			fmt.Printf("Started %d pool instances for %s\n", started, workspace)
+		}
+		if err != nil {
+			fmt.Printf("Warning: %v\n", err)
+		}
+	}
+}
+
+// retire kills an instance's host bridge and stops tracking it
+func (s *Supervisor) retire(instance *ManagedInstance) {
+	if instance.hostRunning() {
+		fmt.Printf("Stopping host bridge for %s (PID %d)\n", instance.Address, instance.HostPID)
+		killProcessGroup(instance.HostPID)
+	}
+	removeManagedInstance(instance.CorePort)
+	delete(s.states, instance.CorePort)
+}
+
+// restartCore starts a new core on the same ports, restarting the host bridge too if it died
+func (s *Supervisor) restartCore(instance *ManagedInstance) error {
+	instance.Restarts++
+	fmt.Printf("Restarting core %s (attempt %d/%d)\n", instance.Address, instance.Restarts, s.opts.MaxRestarts)
+
+	if !instance.hostRunning() {
+		hostCmd, err := startClineHost(instance.HostPort, instance.CorePort, instance.Workspace)
+		if err != nil {
+			saveManagedInstance(instance)
+			return fmt.Errorf("failed to start clica-host: %w", err)
+		}
+		instance.HostPID = hostCmd.Process.Pid
+		reapProcess(hostCmd.Process)
+	}
+
+	coreCmd, err := startClineCore(instance.CorePort, instance.HostPort)
+	if err != nil {
+		saveManagedInstance(instance)
+		return err
+	}
+	instance.CorePID = coreCmd.Process.Pid
+	instance.StartedAt = time.Now()
+	reapProcess(coreCmd.Process)
+
+	return saveManagedInstance(instance)
+}
+
+// reapProcess waits on a child process in the background so it doesn't linger as a zombie (which would look alive)
+func reapProcess(process *os.Process) {
+	go process.Wait()
+}
+
+// backoff returns the delay before the next restart: 1s, 2s, 4s, ... up to MaxBackoff
+func (s *Supervisor) backoff(restarts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < restarts && delay < s.opts.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.opts.MaxBackoff)
+}
+
+// instanceHasActiveTask reports whether the instance is running a task
+func instanceHasActiveTask(ctx context.Context, address string) bool {
+	stateJSON, err := FetchInstanceState(ctx, address)
+	if err != nil {
+		// If we can't tell, assume it's busy rather than killing work in progress
+		return !errors.Is(err, errInstanceUnreachable)
+	}
+
+	var stateData map[string]interface{}
+	if err := json.Unmarshal([]byte(stateJSON), &stateData); err != nil {
+		return true
+	}
+	return stateData["currentTaskItem"] != nil
+}
+
+// errInstanceUnreachable means FetchInstanceState couldn't connect to the instance at all
+var errInstanceUnreachable = errors.New("instance unreachable")
+
+// FetchInstanceState returns the state JSON of an instance. Connects directly rather than
+// through the registry so the call doesn't count as activity.
+func FetchInstanceState(ctx context.Context, address string) (string, error) {
+	target, err := common.NormalizeAddressForGRPC(address)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errInstanceUnreachable, err)
+	}
+
+	c, err := client.NewClicaClient(target)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errInstanceUnreachable, err)
+	}
+	defer c.Disconnect()
+
+	rpcCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if err := c.Connect(rpcCtx); err != nil {
+		return "", fmt.Errorf("%w: %v", errInstanceUnreachable, err)
+	}
+
+	state, err := c.State.GetLatestState(rpcCtx, &clica.EmptyRequest{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get state of %s: %w", address, err)
+	}
+	return state.StateJson, nil
+}
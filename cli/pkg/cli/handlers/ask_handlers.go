@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/clica/cli/pkg/cli/clerror"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/output"
 	"github.com/clica/cli/pkg/cli/types"
 )
@@ -158,7 +159,7 @@ func (h *AskHandler) handleCommandOutput(msg *types.ClicaMessage, dc *DisplayCon
 	markdown := fmt.Sprintf("```\n%s\n```", commandOutput)
 	rendered := dc.Renderer.RenderMarkdown(markdown)
 
-	fmt.Printf("%s", rendered)
+	output.Printf("%s", rendered)
 
 	return nil
 }
@@ -241,7 +242,7 @@ func (h *AskHandler) handleMistakeLimitReached(msg *types.ClicaMessage, dc *Disp
 			"Clica has made too many consecutive mistakes and needs your guidance to proceed.",
 			details,
 		)
-		fmt.Printf("\n**Approval required to continue.**\n")
+		output.Print(display.ScreenReaderMarkdown("\n**Approval required to continue.**\n"))
 		return nil
 	}
 	return dc.Renderer.RenderMessage("ERROR", fmt.Sprintf("Mistake Limit Reached: %s. Approval required.", msg.Text), true)
@@ -260,7 +261,7 @@ func (h *AskHandler) handleAutoApprovalMaxReached(msg *types.ClicaMessage, dc *D
 			"The maximum number of auto-approved requests has been reached. Manual approval is now required.",
 			details,
 		)
-		fmt.Printf("\n**Approval required to continue.**\n")
+		output.Print(display.ScreenReaderMarkdown("\n**Approval required to continue.**\n"))
 		return nil
 	}
 	return dc.Renderer.RenderMessage("WARNING", fmt.Sprintf("Auto-approval limit reached: %s. Approval required.", msg.Text), true)
@@ -274,35 +275,9 @@ func (h *AskHandler) handleBrowserActionLaunch(msg *types.ClicaMessage, dc *Disp
 	return err
 }
 
-// handleUseMcpServer handles MCP server usage requests
+// handleUseMcpServer handles MCP tool call and resource read requests
 func (h *AskHandler) handleUseMcpServer(msg *types.ClicaMessage, dc *DisplayContext) error {
-	// Parse MCP server usage request
-	type McpServerRequest struct {
-		ServerName string `json:"serverName"`
-		Type       string `json:"type"`
-		ToolName   string `json:"toolName,omitempty"`
-		Arguments  string `json:"arguments,omitempty"`
-		URI        string `json:"uri,omitempty"`
-	}
-
-	var mcpReq McpServerRequest
-	if err := json.Unmarshal([]byte(msg.Text), &mcpReq); err != nil {
-		return dc.Renderer.RenderMessage("MCP", msg.Text, true)
-	}
-
-	var operation string
-	if mcpReq.Type == "access_mcp_resource" {
-		operation = "access a resource"
-	} else {
-		operation = fmt.Sprintf("use a tool (%s)", mcpReq.ToolName)
-		if mcpReq.Arguments != "" {
-			operation = fmt.Sprintf("%s with args (%s)", operation, mcpReq.Arguments)
-		}
-	}
-
-	err := dc.Renderer.RenderMessage("MCP",
-		fmt.Sprintf("Clica wants to %s on the %s MCP server", operation, mcpReq.ServerName), true)
-
+	err := renderMcpRequest(msg, dc, "wants to")
 	h.showApprovalHint(dc)
 	return err
 }
@@ -336,12 +311,12 @@ func (h *AskHandler) handleReportBug(msg *types.ClicaMessage, dc *DisplayContext
 		return fmt.Errorf("failed to render handleReportBug: %w", err)
 	}
 
-	fmt.Printf("\n**Title**: %s\n", bugData.Title)
-	fmt.Printf("**What Happened**: %s\n", bugData.WhatHappened)
-	fmt.Printf("**Steps to Reproduce**: %s\n", bugData.StepsToReproduce)
-	fmt.Printf("**API Request Output**: %s\n", bugData.APIRequestOutput)
-	fmt.Printf("**Additional Context**: %s\n", bugData.AdditionalContext)
-	fmt.Printf("\nApprove to create a GitHub issue.\n")
+	output.Print(display.ScreenReaderMarkdown(fmt.Sprintf("\n**Title**: %s\n", bugData.Title)))
+	output.Print(display.ScreenReaderMarkdown(fmt.Sprintf("**What Happened**: %s\n", bugData.WhatHappened)))
+	output.Print(display.ScreenReaderMarkdown(fmt.Sprintf("**Steps to Reproduce**: %s\n", bugData.StepsToReproduce)))
+	output.Print(display.ScreenReaderMarkdown(fmt.Sprintf("**API Request Output**: %s\n", bugData.APIRequestOutput)))
+	output.Print(display.ScreenReaderMarkdown(fmt.Sprintf("**Additional Context**: %s\n", bugData.AdditionalContext)))
+	output.Printf("\nApprove to create a GitHub issue.\n")
 
 	return nil
 }
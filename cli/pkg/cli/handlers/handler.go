@@ -1,10 +1,17 @@
 package handlers
 
 import (
+	"errors"
+	"sync"
+
 	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/types"
 )
 
+// ErrPassThrough is returned by a handler's Handle to let lower-priority handlers process
+// the message as well, e.g. for handlers that forward messages without rendering them
+var ErrPassThrough = errors.New("pass message to the next handler")
+
 // MessageHandler defines the interface for handling different message types
 type MessageHandler interface {
 	// CanHandle returns true if this handler can process the given message
@@ -60,6 +67,7 @@ func (h *BaseHandler) GetPriority() int {
 
 // HandlerRegistry manages a collection of message handlers
 type HandlerRegistry struct {
+	mu       sync.RWMutex
 	handlers []MessageHandler
 }
 
@@ -70,8 +78,40 @@ func NewHandlerRegistry() *HandlerRegistry {
 	}
 }
 
-// Register adds a handler to the registry
+var (
+	globalHandlersMu sync.Mutex
+	globalHandlers   []MessageHandler
+)
+
+// RegisterHandler adds a handler to every registry created by NewDefaultRegistry afterwards.
+// Programs embedding the CLI packages call it at startup to render or forward messages their own way;
+// handlers with PriorityOverride take precedence over the built-in ones.
+func RegisterHandler(handler MessageHandler) {
+	globalHandlersMu.Lock()
+	defer globalHandlersMu.Unlock()
+	globalHandlers = append(globalHandlers, handler)
+}
+
+// NewDefaultRegistry creates a registry with the built-in ask and say handlers
+// and the handlers added with RegisterHandler
+func NewDefaultRegistry() *HandlerRegistry {
+	registry := NewHandlerRegistry()
+	registry.Register(NewAskHandler())
+	registry.Register(NewSayHandler())
+
+	globalHandlersMu.Lock()
+	defer globalHandlersMu.Unlock()
+	for _, handler := range globalHandlers {
+		registry.Register(handler)
+	}
+	return registry
+}
+
+// Register adds a handler to the registry. Handlers with equal priority keep registration order.
 func (r *HandlerRegistry) Register(handler MessageHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.handlers = append(r.handlers, handler)
 
 	// Sort handlers by priority (highest first)
@@ -84,11 +124,29 @@ func (r *HandlerRegistry) Register(handler MessageHandler) {
 	}
 }
 
-// Handle finds the appropriate handler and processes the message
+// Unregister removes the handler with the given name, and reports whether there was one
+func (r *HandlerRegistry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, handler := range r.handlers {
+		if handler.GetName() == name {
+			r.handlers = append(r.handlers[:i], r.handlers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Handle finds the appropriate handler and processes the message.
+// Handlers returning ErrPassThrough hand the message on to the next matching handler.
 func (r *HandlerRegistry) Handle(msg *types.ClicaMessage, dc *DisplayContext) error {
-	for _, handler := range r.handlers {
-		if handler.CanHandle(msg) {
-			return handler.Handle(msg, dc)
+	for _, handler := range r.GetHandlers() {
+		if !handler.CanHandle(msg) {
+			continue
+		}
+		if err := handler.Handle(msg, dc); !errors.Is(err, ErrPassThrough) {
+			return err
 		}
 	}
 
@@ -107,13 +165,18 @@ func (r *HandlerRegistry) handleDefault(msg *types.ClicaMessage, dc *DisplayCont
 	return dc.Renderer.RenderMessage(prefix, msg.Text, true)
 }
 
-// GetHandlers returns all registered handlers
+// GetHandlers returns all registered handlers, highest priority first
 func (r *HandlerRegistry) GetHandlers() []MessageHandler {
-	return r.handlers
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]MessageHandler(nil), r.handlers...)
 }
 
 // GetHandlerByName finds a handler by name
 func (r *HandlerRegistry) GetHandlerByName(name string) MessageHandler {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	for _, handler := range r.handlers {
 		if handler.GetName() == name {
 			return handler
@@ -124,7 +187,8 @@ func (r *HandlerRegistry) GetHandlerByName(name string) MessageHandler {
 
 // HandlerPriorities defines standard priority levels for handlers
 const (
-	PriorityHigh   = 100
-	PriorityNormal = 50
-	PriorityLow    = 10
+	PriorityOverride = 200 // above the built-in ask and say handlers
+	PriorityHigh     = 100
+	PriorityNormal   = 50
+	PriorityLow      = 10
 )
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/terminal"
+	"golang.org/x/term"
+)
+
+// maxImageColumns caps how wide inline images are drawn
+const maxImageColumns = 80
+
+// imageKind describes a kind of image shown in the conversation
+type imageKind struct {
+	label      string // message prefix when the image can't be shown inline
+	caption    string
+	filePrefix string // saved files are <filePrefix>-001.png, <filePrefix>-002.png, ...
+}
+
+var (
+	screenshotImage = imageKind{label: "SCREENSHOT", caption: "Screenshot", filePrefix: "screenshot"}
+	mcpImage        = imageKind{label: "IMAGE", caption: "Image", filePrefix: "mcp-image"}
+)
+
+// imageSaver writes images to the --save-screenshots directory with sequence numbers per kind
+type imageSaver struct {
+	mu    sync.Mutex
+	dir   string
+	next  map[string]int    // file prefix -> next sequence number
+	saved map[string]string // file prefix and message key -> path, so redisplayed history isn't saved twice
+}
+
+var savedImages = &imageSaver{next: make(map[string]int), saved: make(map[string]string)}
+
+// save writes an image as the next numbered PNG file of its kind and returns its path
+func (s *imageSaver) save(dir string, kind imageKind, key string, img image.Image) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	savedKey := kind.filePrefix + ":" + key
+	if path, ok := s.saved[savedKey]; ok {
+		return path, nil
+	}
+
+	if s.dir != dir {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return "", fmt.Errorf("failed to create screenshot directory: %w", err)
+		}
+		s.dir = dir
+		s.next = make(map[string]int)
+	}
+	if _, ok := s.next[kind.filePrefix]; !ok {
+		// Continue after images of earlier sessions instead of overwriting them
+		s.next[kind.filePrefix] = lastImageNumber(dir, kind.filePrefix) + 1
+	}
+
+	data, err := display.EncodePNG(img)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fmt.Sprintf("%s-%03d.png", kind.filePrefix, s.next[kind.filePrefix]))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write image: %w", err)
+	}
+	s.next[kind.filePrefix]++
+	s.saved[savedKey] = path
+	return path, nil
+}
+
+// lastImageNumber returns the highest sequence number of the images with a file prefix in dir, or 0
+func lastImageNumber(dir, filePrefix string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0
+	}
+
+	pattern := regexp.MustCompile(`^` + regexp.QuoteMeta(filePrefix) + `-(\d+)\.png$`)
+	last := 0
+	for _, entry := range entries {
+		match := pattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		if n, err := strconv.Atoi(match[1]); err == nil && n > last {
+			last = n
+		}
+	}
+	return last
+}
+
+// saveImage decodes an image data URL and saves it if --save-screenshots is set.
+// Returns the image and the saved path, or "" if it wasn't saved.
+func saveImage(kind imageKind, dataURL, key string) (image.Image, string, error) {
+	img, err := display.DecodeDataURL(dataURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	dir := global.Config.ScreenshotDir
+	if dir == "" {
+		return img, "", nil
+	}
+	path, err := savedImages.save(dir, kind, key, img)
+	return img, path, err
+}
+
+// renderScreenshot shows a browser screenshot inline if the terminal supports images,
+// and otherwise describes it with its size and saved path
+func renderScreenshot(dataURL string, ts int64, dc *DisplayContext) error {
+	return renderImage(screenshotImage, dataURL, strconv.FormatInt(ts, 10), dc)
+}
+
+// renderImage shows an image inline if the terminal supports images, and otherwise
+// describes it with its size and saved path. key identifies the image across redisplays.
+func renderImage(kind imageKind, dataURL, key string, dc *DisplayContext) error {
+	img, path, err := saveImage(kind, dataURL, key)
+	if img == nil {
+		return dc.Renderer.RenderMessage(kind.label, fmt.Sprintf("Could not read %s: %v", strings.ToLower(kind.caption), err), true)
+	}
+	if err != nil {
+		dc.Renderer.RenderMessage("WARNING", err.Error(), true)
+	}
+
+	bounds := img.Bounds()
+	description := fmt.Sprintf("%dx%d", bounds.Dx(), bounds.Dy())
+	if path != "" {
+		description += ", saved to " + path
+	}
+
+	protocol := terminal.DetectImageProtocol()
+	if global.Config.OutputFormat != "rich" || dc.IsPartial || output.IsCapturing() || protocol == display.ImageProtocolNone {
+		if path == "" {
+			description += " (keep images with --save-screenshots <dir>)"
+		}
+		if hint := terminal.ImageHint(); hint != "" && protocol == display.ImageProtocolNone && global.Config.OutputFormat == "rich" {
+			description += " (" + hint + ")"
+		}
+		return dc.Renderer.RenderMessage(kind.label, description, true)
+	}
+
+	inline, err := display.InlineImage(protocol, img, imageColumns())
+	if err != nil {
+		return dc.Renderer.RenderMessage(kind.label, description, true)
+	}
+	output.Print(inline + "\n")
+	output.Println(dc.Renderer.Dim(kind.caption + " " + description))
+	return nil
+}
+
+// imageColumns is the width inline images are drawn at
+func imageColumns() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width <= 0 {
+		return maxImageColumns
+	}
+	return min(width, maxImageColumns)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/types"
+)
+
+// renderMcpRequest shows an MCP tool call or resource read with its arguments,
+// and remembers it so the response can be labeled with the tool or resource
+func renderMcpRequest(msg *types.ClicaMessage, dc *DisplayContext, verbTense string) error {
+	req, err := types.ParseMcpRequest(msg.Text)
+	if err != nil {
+		if msg.Partial {
+			// Incomplete JSON while the request streams in
+			return nil
+		}
+		return dc.Renderer.RenderMessage("MCP", msg.Text, true)
+	}
+
+	if !msg.Partial {
+		dc.State.SetLastMcpRequest(req)
+	}
+	output.Print(dc.ToolRenderer.RenderMcpRequest(req, verbTense))
+	return nil
+}
+
+// renderMcpResponse shows an MCP response: JSON pretty-printed, long text truncated unless
+// running with --verbose, and images inline or saved. In the TUI the full response is kept,
+// since it collapses tool output itself.
+func renderMcpResponse(msg *types.ClicaMessage, dc *DisplayContext) error {
+	resp := types.ParseMcpResponse(msg.Text)
+	full := global.Config.Verbose || output.IsCapturing()
+	output.Print(dc.ToolRenderer.RenderMcpResponse(resp, dc.State.GetLastMcpRequest(), full))
+
+	for i, part := range resp.Content {
+		if part.Type != "image" {
+			continue
+		}
+		if err := renderImage(mcpImage, part.DataURL, fmt.Sprintf("%d-%d", msg.Timestamp, i), dc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderMcpNotification shows a notification an MCP server sent during a tool call
+func renderMcpNotification(msg *types.ClicaMessage, dc *DisplayContext) error {
+	server, message := types.ParseMcpNotification(msg.Text)
+	if server == "" {
+		return dc.Renderer.RenderMessage("MCP", message, true)
+	}
+	return dc.Renderer.RenderMessage("MCP", fmt.Sprintf("%s: %s", server, message), true)
+}
+
+// DescribeMcpMessage returns the details of an MCP message for JSON output, or nil for other messages.
+// Responses are matched with the last request recorded in state; images are saved with --save-screenshots.
+func DescribeMcpMessage(msg *types.ClicaMessage, state *types.ConversationState) *types.McpDetails {
+	if msg.Partial {
+		return nil
+	}
+
+	kind := msg.Say
+	if msg.IsAsk() {
+		kind = msg.Ask
+	}
+
+	switch kind {
+	case string(types.SayTypeUseMcpServer): // the ask has the same name
+		req, err := types.ParseMcpRequest(msg.Text)
+		if err != nil {
+			return nil
+		}
+		state.SetLastMcpRequest(req)
+
+		if req.IsResourceRead() {
+			return &types.McpDetails{Kind: "resource_read", Server: req.ServerName, URI: req.URI, MimeType: req.MimeType()}
+		}
+		return &types.McpDetails{
+			Kind:      "tool_call",
+			Server:    req.ServerName,
+			Tool:      req.ToolName,
+			Arguments: types.McpArgumentsJSON(req.Arguments),
+		}
+
+	case string(types.SayTypeMcpServerRequestStarted):
+		details := &types.McpDetails{Kind: "request_started"}
+		if req := state.GetLastMcpRequest(); req != nil {
+			details.Server = req.ServerName
+		}
+		return details
+
+	case string(types.SayTypeMcpServerResponse):
+		resp := types.ParseMcpResponse(msg.Text)
+		details := &types.McpDetails{Kind: "response", IsError: resp.IsError, Content: resp.Content}
+		if req := state.GetLastMcpRequest(); req != nil {
+			details.Server, details.Tool, details.URI = req.ServerName, req.ToolName, req.URI
+			details.MimeType = req.MimeType()
+		}
+		for i := range details.Content {
+			if details.Content[i].Type != "image" {
+				continue
+			}
+			if _, path, err := saveImage(mcpImage, details.Content[i].DataURL, fmt.Sprintf("%d-%d", msg.Timestamp, i)); err == nil {
+				details.Content[i].SavedTo = path
+			}
+		}
+		return details
+
+	case string(types.SayTypeMcpNotification):
+		server, message := types.ParseMcpNotification(msg.Text)
+		return &types.McpDetails{Kind: "notification", Server: server, Message: message}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/clica/cli/pkg/cli/clerror"
+	"github.com/clica/cli/pkg/cli/display"
 	"github.com/clica/cli/pkg/cli/types"
 	"github.com/clica/cli/pkg/cli/output"
 )
@@ -193,7 +194,7 @@ func (h *SayHandler) handleText(msg *types.ClicaMessage, dc *DisplayContext) err
 		return nil
 	} else {
 		// In non-streaming mode, render header + body together
-		markdown := fmt.Sprintf("### Clica responds\n\n%s", msg.Text)
+		markdown := fmt.Sprintf("%s\n\n%s", segmentHeader("### Clica responds", msg), msg.Text)
 		rendered = dc.Renderer.RenderMarkdown(markdown)
 		output.Printf("\n%s\n", rendered)
 	}
@@ -213,7 +214,7 @@ func (h *SayHandler) handleReasoning(msg *types.ClicaMessage, dc *DisplayContext
 		return nil
 	} else {
 		// In non-streaming mode, render header + body together
-		markdown := fmt.Sprintf("### Clica is thinking\n\n%s", msg.Text)
+		markdown := fmt.Sprintf("%s\n\n%s", segmentHeader("### Clica is thinking", msg), msg.Text)
 		rendered = dc.Renderer.RenderMarkdown(markdown)
 		output.Printf("\n%s\n", rendered)
 	}
@@ -234,14 +235,26 @@ func (h *SayHandler) handleCompletionResult(msg *types.ClicaMessage, dc *Display
 		return nil
 	} else {
 		// In non-streaming mode, render header + body together
-		markdown := fmt.Sprintf("### Task completed\n\n%s", text)
+		markdown := fmt.Sprintf("%s\n\n%s", segmentHeader("### Task completed", msg), text)
 		rendered = dc.Renderer.RenderMarkdown(markdown)
 		output.Printf("\n%s\n", rendered)
 	}
 	return nil
 }
 
+// segmentHeader returns the markdown header of a message, or its role label for screen readers
+func segmentHeader(header string, msg *types.ClicaMessage) string {
+	if display.Accessible() {
+		return display.SegmentLabel(msg.Say, msg)
+	}
+	return header
+}
+
 func formatUserMessage(text string) string {
+    if display.Accessible() {
+        return "You said:\n" + text + "\n"
+    }
+
     lines := strings.Split(text, "\n")
     
     // Wrap each line in backticks
@@ -424,31 +437,40 @@ func (h *SayHandler) handleBrowserActionResult(msg *types.ClicaMessage, dc *Disp
 
 	// If we have logs, include them in the message
 	if result.Logs != "" {
-		return dc.Renderer.RenderMessage("BROWSER", fmt.Sprintf("Action completed with logs: '%s'", result.Logs), true)
+		if err := dc.Renderer.RenderMessage("BROWSER", fmt.Sprintf("Action completed with logs: '%s'", result.Logs), true); err != nil {
+			return err
+		}
+	} else if err := dc.Renderer.RenderMessage("BROWSER", "Action completed", true); err != nil {
+		return err
 	}
 
-	// Default case
-	return dc.Renderer.RenderMessage("BROWSER", "Action completed", true)
+	if result.Screenshot == "" {
+		return nil
+	}
+	return renderScreenshot(result.Screenshot, msg.Timestamp, dc)
 }
 
 // handleMcpServerRequestStarted handles MCP server request started messages
 func (h *SayHandler) handleMcpServerRequestStarted(msg *types.ClicaMessage, dc *DisplayContext) error {
+	if req := dc.State.GetLastMcpRequest(); req != nil {
+		return dc.Renderer.RenderMessage("MCP", fmt.Sprintf("Sending request to %s", req.ServerName), true)
+	}
 	return dc.Renderer.RenderMessage("MCP", "Sending request to server", true)
 }
 
 // handleMcpServerResponse handles MCP server response messages
 func (h *SayHandler) handleMcpServerResponse(msg *types.ClicaMessage, dc *DisplayContext) error {
-	return dc.Renderer.RenderMessage("MCP", fmt.Sprintf("Server response: %s", msg.Text), true)
+	return renderMcpResponse(msg, dc)
 }
 
 // handleMcpNotification handles MCP notification messages
 func (h *SayHandler) handleMcpNotification(msg *types.ClicaMessage, dc *DisplayContext) error {
-	return dc.Renderer.RenderMessage("MCP", fmt.Sprintf("Server notification: %s", msg.Text), true)
+	return renderMcpNotification(msg, dc)
 }
 
-// handleUseMcpServer handles MCP server usage messages
+// handleUseMcpServer handles auto-approved MCP tool calls and resource reads
 func (h *SayHandler) handleUseMcpServer(msg *types.ClicaMessage, dc *DisplayContext) error {
-	return dc.Renderer.RenderMessage("MCP", "Server operation approved", true)
+	return renderMcpRequest(msg, dc, "is")
 }
 
 // handleDiffError handles diff error messages
@@ -0,0 +1,327 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/types"
+)
+
+const (
+	// defaultSubprocessTimeout bounds how long a passthrough handler's program may run per
+	// message. It runs in the background, while the message is rendered as usual.
+	defaultSubprocessTimeout = 10 * time.Second
+	// defaultReplaceTimeout bounds how long a handler whose output replaces the rendering may
+	// run. Rendering waits for it, so it's kept short.
+	defaultReplaceTimeout = 2 * time.Second
+)
+
+// background tracks the programs of passthrough handlers that are still running
+var background sync.WaitGroup
+
+// WaitForBackground waits for the programs passthrough handlers run in the background, so the
+// CLI exiting doesn't cut them off. Each is bounded by its handler's timeout.
+func WaitForBackground() {
+	background.Wait()
+}
+
+// SubprocessConfig configures a handler that pipes messages as JSON to an external program.
+// In settings each handler is a group of message-handlers.<name>.<field> keys, which only the
+// user config (~/.clica/config.yaml) may set, e.g.:
+//
+//	message-handlers:
+//	  chat:
+//	    command: ~/bin/post-to-chat.sh
+//	    match: [say:completion_result]
+type SubprocessConfig struct {
+	Name    string
+	Command string // run with sh -c, or cmd /C on Windows
+	// Match selects messages: "ask", "say", "ask:<type>", "say:<type>" or "*" (the default)
+	Match []string
+	// Passthrough also renders matched messages as usual (the default), running the program in
+	// the background. Otherwise rendering waits for the program and shows its output instead.
+	Passthrough bool
+	Priority    int
+	Timeout     time.Duration // 0 for defaultSubprocessTimeout, or defaultReplaceTimeout without passthrough
+}
+
+// ParseSubprocessConfigs builds handler configs from message-handlers settings, keyed by "<name>.<field>"
+func ParseSubprocessConfigs(settings map[string]string) ([]SubprocessConfig, error) {
+	configs := make(map[string]*SubprocessConfig)
+	var errs []error
+
+	for key, value := range settings {
+		name, field, ok := strings.Cut(key, ".")
+		if !ok || name == "" {
+			errs = append(errs, fmt.Errorf("invalid message handler setting '%s': expected <name>.<field>", key))
+			continue
+		}
+
+		config, ok := configs[name]
+		if !ok {
+			config = &SubprocessConfig{
+				Name:        name,
+				Match:       []string{"*"},
+				Passthrough: true,
+				Priority:    PriorityOverride,
+			}
+			configs[name] = config
+		}
+
+		if err := config.set(field, value); err != nil {
+			errs = append(errs, fmt.Errorf("message handler '%s': %w", name, err))
+		}
+	}
+
+	result := make([]SubprocessConfig, 0, len(configs))
+	for _, config := range configs {
+		if config.Command == "" {
+			errs = append(errs, fmt.Errorf("message handler '%s': command is required", config.Name))
+			continue
+		}
+		result = append(result, *config)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// set applies one message-handlers.<name>.<field> setting
+func (c *SubprocessConfig) set(field, value string) error {
+	switch field {
+	case "command":
+		c.Command = value
+	case "match":
+		c.Match = nil
+		for _, selector := range strings.Split(value, ",") {
+			selector = strings.TrimSpace(selector)
+			if selector == "" {
+				continue
+			}
+			if err := validateSelector(selector); err != nil {
+				return err
+			}
+			c.Match = append(c.Match, selector)
+		}
+		if len(c.Match) == 0 {
+			return fmt.Errorf("match must select at least one message type")
+		}
+	case "passthrough":
+		passthrough, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid passthrough '%s': expected true or false", value)
+		}
+		c.Passthrough = passthrough
+	case "priority":
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid priority '%s': expected a number", value)
+		}
+		c.Priority = priority
+	case "timeout":
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid timeout '%s': expected a duration such as 5s", value)
+		}
+		c.Timeout = timeout
+	default:
+		return fmt.Errorf("unknown field '%s' (expected command, match, passthrough, priority or timeout)", field)
+	}
+	return nil
+}
+
+// validateSelector checks a match selector such as "say:completion_result"
+func validateSelector(selector string) error {
+	kind, name, hasName := strings.Cut(selector, ":")
+	switch {
+	case selector == "*":
+		return nil
+	case kind != "ask" && kind != "say":
+		return fmt.Errorf("invalid match '%s': expected ask, say, ask:<type>, say:<type> or *", selector)
+	case hasName && name == "":
+		return fmt.Errorf("invalid match '%s': missing message type after ':'", selector)
+	}
+	return nil
+}
+
+// SubprocessHandler pipes matching messages as JSON to an external program. Only messages that
+// arrive while it's registered are sent: history replayed by e.g. clica attach was sent by the
+// session it arrived in.
+type SubprocessHandler struct {
+	*BaseHandler
+	config SubprocessConfig
+	since  int64 // messages from before this time (in ms, like message timestamps) are history
+
+	mu      sync.Mutex
+	outputs map[int64]string // program output by message timestamp, so redisplayed messages aren't sent twice
+}
+
+// subprocessPayload is the JSON written to the program's stdin
+type subprocessPayload struct {
+	Handler     string              `json:"handler"`
+	Message     *types.ClicaMessage `json:"message"`
+	IsLast      bool                `json:"is_last"`
+	Interactive bool                `json:"interactive"`
+}
+
+// NewSubprocessHandler creates a handler that runs the configured program for matching messages
+func NewSubprocessHandler(config SubprocessConfig) *SubprocessHandler {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultSubprocessTimeout
+		if !config.Passthrough {
+			config.Timeout = defaultReplaceTimeout
+		}
+	}
+	return &SubprocessHandler{
+		BaseHandler: NewBaseHandler("subprocess:"+config.Name, config.Priority),
+		config:      config,
+		since:       time.Now().UnixMilli(),
+		outputs:     make(map[int64]string),
+	}
+}
+
+// CanHandle returns true for complete messages the handler's selectors match
+func (h *SubprocessHandler) CanHandle(msg *types.ClicaMessage) bool {
+	// Streaming updates would start the program once per chunk
+	if msg.Partial || msg.Timestamp < h.since {
+		return false
+	}
+
+	for _, selector := range h.config.Match {
+		if matchesSelector(selector, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesSelector reports whether a message matches a selector such as "say:completion_result"
+func matchesSelector(selector string, msg *types.ClicaMessage) bool {
+	if selector == "*" {
+		return true
+	}
+
+	kind, name, _ := strings.Cut(selector, ":")
+	switch kind {
+	case "ask":
+		return msg.IsAsk() && (name == "" || name == "*" || name == msg.Ask)
+	case "say":
+		return msg.IsSay() && (name == "" || name == "*" || name == msg.Say)
+	}
+	return false
+}
+
+func (h *SubprocessHandler) Handle(msg *types.ClicaMessage, dc *DisplayContext) error {
+	h.mu.Lock()
+	out, seen := h.outputs[msg.Timestamp]
+	if !seen && h.config.Passthrough {
+		h.outputs[msg.Timestamp] = ""
+	}
+	h.mu.Unlock()
+
+	if !seen {
+		payload, err := h.payload(msg, dc)
+		if err == nil && h.config.Passthrough {
+			background.Add(1)
+			go h.runInBackground(payload, dc)
+			return ErrPassThrough
+		}
+		if err == nil {
+			out, err = h.run(payload)
+		}
+		if err != nil {
+			h.warn(dc, err)
+			// Fall back to the built-in rendering
+			return ErrPassThrough
+		}
+
+		h.mu.Lock()
+		h.outputs[msg.Timestamp] = out
+		h.mu.Unlock()
+	}
+
+	if h.config.Passthrough {
+		return ErrPassThrough
+	}
+
+	if out != "" {
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		output.Print(out)
+	}
+	return nil
+}
+
+// runInBackground runs the program for a passthrough handler, which doesn't show its output
+func (h *SubprocessHandler) runInBackground(payload []byte, dc *DisplayContext) {
+	defer background.Done()
+	if _, err := h.run(payload); err != nil {
+		h.warn(dc, err)
+	}
+}
+
+func (h *SubprocessHandler) warn(dc *DisplayContext, err error) {
+	dc.Renderer.RenderMessage("WARNING", fmt.Sprintf("Message handler %s failed: %v", h.config.Name, err), true)
+}
+
+// payload returns the JSON sent to the program for msg
+func (h *SubprocessHandler) payload(msg *types.ClicaMessage, dc *DisplayContext) ([]byte, error) {
+	payload, err := json.Marshal(subprocessPayload{
+		Handler:     h.config.Name,
+		Message:     msg,
+		IsLast:      dc.IsLast,
+		Interactive: dc.IsInteractive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+	return payload, nil
+}
+
+// run starts the program with payload on stdin and returns its stdout
+func (h *SubprocessHandler) run(payload []byte) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.config.Timeout)
+	defer cancel()
+
+	cmd := shellCommand(ctx, h.config.Command)
+	cmd.Stdin = bytes.NewReader(append(payload, '\n'))
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("timed out after %s", h.config.Timeout)
+		}
+		if detail := strings.TrimSpace(stderr.String()); detail != "" {
+			return "", fmt.Errorf("%w: %s", err, detail)
+		}
+		return "", err
+	}
+	return stdout.String(), nil
+}
+
+// shellCommand runs a command line through the platform shell
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/spf13/cobra"
+)
+
+func newInstanceGCCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Find and repair stale instance state",
+		Long: `Reconcile the instance registry with what is actually running, and repair it.
+
+Checks, in one pass:
+  - registry rows of cores that are not serving, and clica processes left on their ports
+  - supervisor records of instances that are gone, and their leftover processes
+  - file and folder locks held by processes or instances that have exited
+  - a default instance that is unreadable or not running
+  - names and labels of instances that no longer exist
+
+Only clica-core and clica-host processes are ever stopped. Instances that started
+recently, or that are managed by a running 'clica daemon', are left alone.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			report, err := global.Clients.GetRegistry().CollectGarbage(cmd.Context(), dryRun)
+			if err != nil {
+				return fmt.Errorf("failed to collect garbage: %w", err)
+			}
+
+			failed := 0
+			for _, finding := range report.Findings {
+				if finding.Error != "" {
+					failed++
+				}
+			}
+
+			if global.Config.OutputFormat == "json" {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal report: %w", err)
+				}
+				fmt.Println(string(data))
+			} else {
+				renderGCReport(report)
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("failed to fix %d of %d problems", failed, len(report.Findings))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report problems without fixing them")
+
+	return cmd
+}
+
+// renderGCReport prints the findings of a gc run as a table
+func renderGCReport(report *global.GCReport) {
+	renderer := display.NewRenderer(global.Config.OutputFormat)
+
+	if len(report.Findings) == 0 {
+		fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Nothing to clean up (%d live instances)", len(report.LiveInstances))))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if report.DryRun {
+		fmt.Fprintln(w, "KIND\tTARGET\tREASON\tACTION")
+	} else {
+		fmt.Fprintln(w, "KIND\tTARGET\tREASON\tACTION\tRESULT")
+	}
+	for _, finding := range report.Findings {
+		if report.DryRun {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", finding.Kind, finding.Target, finding.Reason, finding.Action)
+			continue
+		}
+		// Plain result words keep tabwriter columns aligned
+		result := "fixed"
+		if finding.Error != "" {
+			result = "failed: " + finding.Error
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", finding.Kind, finding.Target, finding.Reason, finding.Action, result)
+	}
+	w.Flush()
+
+	fmt.Println()
+	if report.SupervisorRunning {
+		fmt.Println(renderer.Dim("The clica daemon is running; instances it manages were left to it."))
+	}
+	if report.DryRun {
+		fmt.Printf("%d problems found. Run without --dry-run to fix them.\n", len(report.Findings))
+		return
+	}
+	fmt.Printf("%d live instances remain.\n", len(report.LiveInstances))
+}
@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/types"
+	"github.com/clica/cli/pkg/common"
+	"github.com/clica/grpc-go/clica"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// instanceInspectTimeout bounds all RPCs made by 'instance inspect'
+const instanceInspectTimeout = 5 * time.Second
+
+// instanceInspection is everything 'instance inspect' reports about an instance
+type instanceInspection struct {
+	Address       string               `json:"address"`
+	Name          string               `json:"name,omitempty"`
+	Workspace     string               `json:"workspace,omitempty"`
+	Labels        map[string]string    `json:"labels,omitempty"`
+	Status        string               `json:"status"`
+	Version       string               `json:"version,omitempty"`
+	UptimeSeconds int64                `json:"uptime_seconds,omitempty"`
+	Default       bool                 `json:"default"`
+	Supervised    bool                 `json:"supervised"`
+	Restarts      int                  `json:"restarts,omitempty"`
+	CurrentTaskID string               `json:"current_task_id,omitempty"`
+	Core          inspectedProcess     `json:"core"`
+	Host          inspectedProcess     `json:"host"`
+	McpServers    []inspectedMcpServer `json:"mcp_servers"`
+}
+
+// inspectedProcess describes the core or host process of an instance
+type inspectedProcess struct {
+	Port        int     `json:"port"`
+	PID         int     `json:"pid,omitempty"`
+	MemoryBytes int64   `json:"memory_bytes,omitempty"`
+	CPUPercent  float64 `json:"cpu_percent"`
+	LogFile     string  `json:"log_file,omitempty"`
+}
+
+// inspectedMcpServer describes an MCP server configured in an instance
+type inspectedMcpServer struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Disabled bool   `json:"disabled,omitempty"`
+	Tools    int    `json:"tools"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newInstanceInspectCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect <address|name>",
+		Short: "Show detailed information about a Clica instance",
+		Long: `Show the processes, ports, uptime, version, workspace, current task, resource usage
+and MCP servers of a Clica instance.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			registry := global.Clients.GetRegistry()
+			address, err := registry.ResolveInstance(args[0])
+			if err != nil {
+				return err
+			}
+
+			inspection, err := inspectInstance(cmd.Context(), registry, address)
+			if err != nil {
+				return err
+			}
+
+			if global.Config.OutputFormat == "json" {
+				data, err := json.MarshalIndent(inspection, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal inspection: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			renderInstanceInspection(inspection)
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// inspectInstance gathers information about an instance from the registry, its RPCs and the OS.
+// Missing pieces (e.g. an unhealthy core) are left empty rather than failing the inspection.
+func inspectInstance(ctx context.Context, registry *global.ClientRegistry, address string) (*instanceInspection, error) {
+	info, err := registry.GetInstance(address)
+	if err != nil {
+		return nil, fmt.Errorf("instance %s not found. Run 'clica instance list' to see available instances", address)
+	}
+
+	inspection := &instanceInspection{
+		Address:    info.Address,
+		Status:     grpc_health_v1.HealthCheckResponse_UNKNOWN.String(),
+		Default:    registry.GetDefaultInstance() == info.Address,
+		Core:       inspectedProcess{Port: info.CorePort()},
+		Host:       inspectedProcess{Port: info.HostPort()},
+		McpServers: []inspectedMcpServer{},
+	}
+
+	if meta := registry.GetInstanceMetadata(info.Address); meta != nil {
+		inspection.Name = meta.Name
+		inspection.Workspace = meta.Workspace
+		inspection.Labels = meta.Labels
+	}
+
+	managed := global.GetManagedInstance(info.Address)
+	if managed != nil {
+		inspection.Supervised = true
+		inspection.Restarts = managed.Restarts
+	}
+
+	rpcCtx, cancel := context.WithTimeout(ctx, instanceInspectTimeout)
+	defer cancel()
+
+	if status, err := common.PerformHealthCheck(rpcCtx, info.Address); err == nil {
+		inspection.Status = status.String()
+	}
+
+	if inspection.Status == grpc_health_v1.HealthCheckResponse_SERVING.String() {
+		inspectCoreRPCs(rpcCtx, registry, inspection)
+	}
+
+	// The core reports its own PID; the host PID comes from the supervisor record or its port
+	if managed != nil && inspection.Core.PID == 0 {
+		inspection.Core.PID = managed.CorePID
+	}
+	if managed != nil {
+		inspection.Host.PID = managed.HostPID
+	}
+	if inspection.Host.PID == 0 && inspection.Host.Port != 0 {
+		if pid, err := common.FindListeningPID(inspection.Host.Port); err == nil {
+			inspection.Host.PID = pid
+		}
+	}
+
+	for _, process := range []*inspectedProcess{&inspection.Core, &inspection.Host} {
+		if process.PID == 0 {
+			continue
+		}
+		if stats, err := common.GetProcessStats(process.PID); err == nil {
+			process.MemoryBytes = stats.RSSBytes
+			process.CPUPercent = stats.CPUPercent
+		} else {
+			// A recorded PID that no longer exists is stale
+			process.PID = 0
+		}
+	}
+
+	logsDir := filepath.Join(global.Config.ConfigPath, "logs")
+	inspection.Core.LogFile = newestInstanceLog(logsDir, "core", inspection.Core.Port)
+	inspection.Host.LogFile = newestInstanceLog(logsDir, "host", inspection.Host.Port)
+
+	return inspection, nil
+}
+
+// inspectCoreRPCs fills in what the core reports about itself: process info, current task and MCP servers
+func inspectCoreRPCs(ctx context.Context, registry *global.ClientRegistry, inspection *instanceInspection) {
+	client, err := registry.GetClient(ctx, inspection.Address)
+	if err != nil {
+		return
+	}
+	defer client.Disconnect()
+
+	if processInfo, err := client.State.GetProcessInfo(ctx, &clica.EmptyRequest{}); err == nil {
+		inspection.Core.PID = int(processInfo.ProcessId)
+		if processInfo.Version != nil && *processInfo.Version != "unknown" {
+			inspection.Version = *processInfo.Version
+		}
+		if processInfo.UptimeMs != nil {
+			inspection.UptimeSeconds = *processInfo.UptimeMs / 1000
+		}
+	}
+
+	if state, err := client.State.GetLatestState(ctx, &clica.EmptyRequest{}); err == nil {
+		var extensionState types.ExtensionState
+		if err := json.Unmarshal([]byte(state.StateJson), &extensionState); err == nil && extensionState.CurrentTaskItem != nil {
+			inspection.CurrentTaskID = extensionState.CurrentTaskItem.Id
+		}
+	}
+
+	if servers, err := client.Mcp.GetLatestMcpServers(ctx, &clica.Empty{}); err == nil {
+		for _, server := range servers.McpServers {
+			inspection.McpServers = append(inspection.McpServers, inspectedMcpServer{
+				Name:     server.Name,
+				Status:   strings.ToLower(strings.TrimPrefix(server.Status.String(), "MCP_SERVER_STATUS_")),
+				Disabled: server.GetDisabled(),
+				Tools:    len(server.Tools),
+				Error:    server.GetError(),
+			})
+		}
+	}
+}
+
+// renderInstanceInspection prints an inspection as labelled sections
+func renderInstanceInspection(inspection *instanceInspection) {
+	renderer := display.NewRenderer(global.Config.OutputFormat)
+	notAvailable := "-" // plain, escape codes would break tabwriter alignment
+
+	orNA := func(value string) string {
+		if value == "" {
+			return notAvailable
+		}
+		return value
+	}
+
+	status := inspection.Status
+	switch status {
+	case grpc_health_v1.HealthCheckResponse_SERVING.String():
+		status = renderer.Green(status)
+	case grpc_health_v1.HealthCheckResponse_NOT_SERVING.String():
+		status = renderer.Red(status)
+	default:
+		status = renderer.Yellow(status)
+	}
+
+	uptime := ""
+	if inspection.UptimeSeconds > 0 {
+		uptime = (time.Duration(inspection.UptimeSeconds) * time.Second).String()
+	}
+
+	fmt.Printf("\n%s\n\n", renderer.Bold(fmt.Sprintf("Instance %s", inspection.Address)))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s\n", orNA(inspection.Name))
+	fmt.Fprintf(w, "Status:\t%s\n", status)
+	fmt.Fprintf(w, "Version:\t%s\n", orNA(inspection.Version))
+	fmt.Fprintf(w, "Uptime:\t%s\n", orNA(uptime))
+	fmt.Fprintf(w, "Workspace:\t%s\n", orNA(inspection.Workspace))
+	fmt.Fprintf(w, "Current task:\t%s\n", orNA(inspection.CurrentTaskID))
+	fmt.Fprintf(w, "Default:\t%t\n", inspection.Default)
+	if inspection.Supervised {
+		fmt.Fprintf(w, "Supervised:\tyes (%d restarts)\n", inspection.Restarts)
+	} else {
+		fmt.Fprintf(w, "Supervised:\tno\n")
+	}
+	if len(inspection.Labels) > 0 {
+		fmt.Fprintf(w, "Labels:\t%s\n", formatInstanceLabels(inspection.Labels))
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s\n\n", renderer.Dim("━━━ Processes ━━━"))
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROCESS\tPORT\tPID\tMEMORY\tCPU\tLOG")
+	for _, process := range []struct {
+		name string
+		inspectedProcess
+	}{{"clica-core", inspection.Core}, {"clica-host", inspection.Host}} {
+		pid, memory, cpu := notAvailable, notAvailable, notAvailable
+		if process.PID != 0 {
+			pid = fmt.Sprintf("%d", process.PID)
+			memory = formatFileSize(process.MemoryBytes)
+			cpu = fmt.Sprintf("%.1f%%", process.CPUPercent)
+		}
+		logFile := notAvailable
+		if process.LogFile != "" {
+			logFile = filepath.Base(process.LogFile)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n", process.name, process.Port, pid, memory, cpu, logFile)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%s\n\n", renderer.Dim("━━━ MCP Servers ━━━"))
+	if len(inspection.McpServers) == 0 {
+		fmt.Println("No MCP servers configured.")
+		fmt.Println()
+		return
+	}
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATUS\tTOOLS\tERROR")
+	for _, server := range inspection.McpServers {
+		serverStatus := server.Status
+		if server.Disabled {
+			serverStatus = "disabled"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", server.Name, serverStatus, server.Tools, server.Error)
+	}
+	w.Flush()
+	fmt.Println()
+}
@@ -0,0 +1,388 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/common"
+	"github.com/spf13/cobra"
+)
+
+const (
+	logFollowInterval = 500 * time.Millisecond // how often followed log files are polled
+	logFollowLines    = 10                     // lines shown before following when --lines isn't set
+	logFollowMaxRead  = 16 * 1024 * 1024       // most bytes read from a followed file per poll
+)
+
+// logLineTimeLayouts are the timestamp formats recognised at the start of log lines
+var logLineTimeLayouts = []string{
+	"2006/01/02 15:04:05", // Go log package (clica-host)
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// instanceLogSource is one log file of an instance
+type instanceLogSource struct {
+	service string // "core", "host", or "out" for the stdout and stderr of clica-host
+	port    int
+	path    string
+}
+
+// logLineFilter decides which log lines are shown
+type logLineFilter struct {
+	since   time.Time
+	pattern *regexp.Regexp
+}
+
+func newInstanceLogsCommand() *cobra.Command {
+	var (
+		follow   bool
+		coreOnly bool
+		hostOnly bool
+		since    string
+		grep     string
+		lines    int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs <address|name>",
+		Short: "Show the logs of a Clica instance",
+		Long: `Show the clica-core and clica-host logs of an instance, optionally following them.
+
+The most recent log file of each process is used, found by the instance's ports.
+When both logs are shown, lines are prefixed with the process they come from.
+
+Examples:
+  clica instance logs localhost:50052 -f
+  clica instance logs api-refactor --core --since 10m
+  clica instance logs api-refactor --grep "error|panic"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			if coreOnly && hostOnly {
+				return fmt.Errorf("cannot use both --core and --host")
+			}
+
+			var filter logLineFilter
+			if since != "" {
+				sinceTime, err := parseSince(since)
+				if err != nil {
+					return err
+				}
+				filter.since = sinceTime
+			}
+			if grep != "" {
+				pattern, err := regexp.Compile(grep)
+				if err != nil {
+					return fmt.Errorf("invalid --grep pattern: %w", err)
+				}
+				filter.pattern = pattern
+			}
+
+			registry := global.Clients.GetRegistry()
+			address, err := registry.ResolveInstance(args[0])
+			if err != nil {
+				return err
+			}
+
+			sources, err := resolveInstanceLogSources(registry, address, !hostOnly, !coreOnly)
+			if err != nil {
+				return err
+			}
+
+			if follow && !cmd.Flags().Changed("lines") {
+				lines = logFollowLines
+			}
+
+			prefixed := len(sources) > 1
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+
+			offsets := make([]int64, len(sources))
+			for i, source := range sources {
+				offset, err := printLogFile(source, filter, lines, !follow, prefixed, renderer)
+				if err != nil {
+					return err
+				}
+				offsets[i] = offset
+			}
+
+			if !follow {
+				return nil
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+
+			return followLogFiles(ctx, sources, offsets, filter, prefixed, renderer)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "keep printing new log lines as they are written")
+	cmd.Flags().BoolVar(&coreOnly, "core", false, "only show the clica-core log")
+	cmd.Flags().BoolVar(&hostOnly, "host", false, "only show the clica-host log")
+	cmd.Flags().StringVar(&since, "since", "", "only show lines newer than a duration (e.g. 10m) or time (RFC 3339)")
+	cmd.Flags().StringVar(&grep, "grep", "", "only show lines matching a regular expression")
+	cmd.Flags().IntVarP(&lines, "lines", "n", 0, "only show the last N matching lines (0 for all)")
+
+	return cmd
+}
+
+// resolveInstanceLogSources finds the newest core and host log files of an instance by its ports.
+// The host port comes from the instance's lock row, or its supervisor record if it is gone.
+func resolveInstanceLogSources(registry *global.ClientRegistry, address string, core, host bool) ([]instanceLogSource, error) {
+	_, corePort, err := common.ParseHostPort(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	hostPort := 0
+	if info, err := registry.GetInstance(address); err == nil {
+		hostPort = info.HostPort()
+	} else if managed := global.GetManagedInstance(address); managed != nil {
+		hostPort = managed.HostPort
+	}
+
+	logsDir := filepath.Join(global.Config.ConfigPath, "logs")
+
+	var sources []instanceLogSource
+	if core {
+		if path := newestInstanceLog(logsDir, "core", corePort); path != "" {
+			sources = append(sources, instanceLogSource{service: "core", port: corePort, path: path})
+		}
+	}
+	if host && hostPort != 0 {
+		if path := newestInstanceLog(logsDir, "host", hostPort); path != "" {
+			sources = append(sources, instanceLogSource{service: "host", port: hostPort, path: path})
+		}
+		// Panics and anything else clica-host prints outside its structured log
+		if path := newestInstanceLog(logsDir, "out", hostPort); path != "" {
+			sources = append(sources, instanceLogSource{service: "out", port: hostPort, path: path})
+		}
+	}
+
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no log files found for instance %s in %s", address, logsDir)
+	}
+	return sources, nil
+}
+
+// newestInstanceLog returns the most recent log file of a service on a port, or ""
+func newestInstanceLog(logsDir, service string, port int) string {
+	logs, err := listLogFiles(logsDir)
+	if err != nil {
+		return ""
+	}
+
+	// listLogFiles sorts newest first
+	prefix := fmt.Sprintf("clica-%s-", service)
+	suffix := fmt.Sprintf("-localhost-%d.log", port)
+	if service == "out" {
+		// clica-host-<timestamp>-localhost-<port>-output.log, next to the host log
+		prefix, suffix = "clica-host-", fmt.Sprintf("-localhost-%d-output.log", port)
+	}
+	for _, log := range logs {
+		if strings.HasPrefix(log.name, prefix) && strings.HasSuffix(log.name, suffix) {
+			return log.path
+		}
+	}
+	return ""
+}
+
+// printLogFile prints the matching lines of a log file (the last limit lines if limit > 0)
+// and returns the offset reading stopped at, for following. A partially written last line
+// is only included when the file won't be followed.
+func printLogFile(source instanceLogSource, filter logLineFilter, limit int, includePartial bool, prefixed bool, renderer *display.Renderer) (int64, error) {
+	file, err := os.Open(source.path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	// Lines without a timestamp belong to the last timestamped line before them
+	lineTime := time.Time{}
+	if created, err := parseTimestampFromFilename(filepath.Base(source.path)); err == nil {
+		lineTime = created
+	}
+
+	var matched []string
+	var offset int64
+	reader := bufio.NewReaderSize(file, 64*1024)
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF && (line == "" || !includePartial) {
+			break
+		}
+		offset += int64(len(line))
+		line = strings.TrimRight(line, "\r\n")
+
+		if t, ok := parseLogLineTime(line); ok {
+			lineTime = t
+		}
+		if filter.matches(line, lineTime) {
+			matched = append(matched, line)
+			if limit > 0 && len(matched) > limit {
+				matched = matched[1:]
+			}
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	for _, line := range matched {
+		printLogLine(source, line, prefixed, renderer)
+	}
+
+	return offset, nil
+}
+
+// followLogFiles prints lines appended to the log files until ctx is done. If an instance
+// restarts and a newer log file appears for the same port, following switches to it.
+func followLogFiles(ctx context.Context, sources []instanceLogSource, offsets []int64, filter logLineFilter, prefixed bool, renderer *display.Renderer) error {
+	logsDir := filepath.Join(global.Config.ConfigPath, "logs")
+	partial := make([]string, len(sources))
+
+	ticker := time.NewTicker(logFollowInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		for i := range sources {
+			if newest := newestInstanceLog(logsDir, sources[i].service, sources[i].port); newest != "" && newest != sources[i].path {
+				sources[i].path = newest
+				offsets[i] = 0
+				partial[i] = ""
+			}
+
+			data, offset, err := readLogFrom(sources[i].path, offsets[i])
+			if err != nil {
+				continue
+			}
+			offsets[i] = offset
+
+			chunk := partial[i] + data
+			lines := strings.Split(chunk, "\n")
+			partial[i] = lines[len(lines)-1]
+			for _, line := range lines[:len(lines)-1] {
+				line = strings.TrimRight(line, "\r")
+				if filter.pattern == nil || filter.pattern.MatchString(line) {
+					printLogLine(sources[i], line, prefixed, renderer)
+				}
+			}
+		}
+	}
+}
+
+// readLogFrom reads a log file from offset to its end. A file that shrank was
+// truncated or rotated, so it is read again from the start.
+func readLogFrom(path string, offset int64) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", offset, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", offset, err
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if info.Size() == offset {
+		return "", offset, nil
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return "", offset, err
+	}
+	data, err := io.ReadAll(io.LimitReader(file, logFollowMaxRead))
+	if err != nil {
+		return "", offset, err
+	}
+	return string(data), offset + int64(len(data)), nil
+}
+
+// printLogLine prints a log line, prefixed with its process when several logs are shown
+func printLogLine(source instanceLogSource, line string, prefixed bool, renderer *display.Renderer) {
+	if prefixed {
+		fmt.Printf("%s %s\n", renderer.Dim(fmt.Sprintf("%-4s |", source.service)), line)
+		return
+	}
+	fmt.Println(line)
+}
+
+// matches reports whether a line with the given timestamp passes the filter
+func (f logLineFilter) matches(line string, lineTime time.Time) bool {
+	if !f.since.IsZero() && lineTime.Before(f.since) {
+		return false
+	}
+	if f.pattern != nil && !f.pattern.MatchString(line) {
+		return false
+	}
+	return true
+}
+
+// parseLogLineTime extracts the timestamp of a log line, if there is one: the time field of a
+// JSON line, or the timestamp at the start of a plain one. ISO timestamps ending in Z are UTC;
+// everything else is local time.
+func parseLogLineTime(line string) (time.Time, bool) {
+	if entry, ok := parseStructuredLogLine(line); ok {
+		return entry.Time, true
+	}
+
+	line = strings.TrimLeft(line, "[")
+	for _, layout := range logLineTimeLayouts {
+		if len(line) < len(layout) {
+			continue
+		}
+
+		loc := time.Local
+		if strings.HasPrefix(strings.TrimLeft(line[len(layout):], ".0123456789"), "Z") {
+			loc = time.UTC
+		}
+		if t, err := time.ParseInLocation(layout, line[:len(layout)], loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseSince parses a --since value: a duration ago (e.g. 10m, 2h) or an RFC 3339 time
+func parseSince(value string) (time.Time, error) {
+	return parseTimeFlag("since", value)
+}
+
+// parseTimeFlag parses a time flag given as a duration ago or an absolute time
+func parseTimeFlag(flag, value string) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --%s value '%s': use a duration like 10m or a time like 2025-01-02T15:04:05Z", flag, value)
+}
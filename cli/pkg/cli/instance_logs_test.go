@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLogLineTime(t *testing.T) {
+	for name, tc := range map[string]struct {
+		line string
+		want time.Time
+		ok   bool
+	}{
+		"host JSON": {
+			`{"time":"2026-10-19T09:15:02.123456789+02:00","level":"INFO","msg":"served request","service":"host","method":"/host.WindowService/ShowMessage"}`,
+			time.Date(2026, 10, 19, 7, 15, 2, 123456789, time.UTC), true,
+		},
+		"Go log":         {"2026/10/19 09:15:02 listening on 127.0.0.1:50052", time.Date(2026, 10, 19, 9, 15, 2, 0, time.Local), true},
+		"ISO UTC":        {"[2026-10-19T09:15:02.500Z] INFO starting", time.Date(2026, 10, 19, 9, 15, 2, 0, time.UTC), true},
+		"continuation":   {"    at Object.<anonymous> (index.js:10:5)", time.Time{}, false},
+		"JSON sans time": {`{"level":"INFO","msg":"no time"}`, time.Time{}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, ok := parseLogLineTime(tc.line)
+			if ok != tc.ok || !got.Equal(tc.want) {
+				t.Errorf("got %v, %v, want %v, %v", got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
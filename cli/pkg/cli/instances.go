@@ -2,9 +2,14 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"text/tabwriter"
 	"time"
@@ -23,8 +28,14 @@ const (
 	platformJetBrains = "JetBrains"
 	platformNA        = "N/A"
 	hostPlatformCLI   = "Clica CLI" // Value returned by host bridge for CLI instances
+
+	instanceListConcurrency = 8               // instances queried at once by 'instance list'
+	instanceInfoTimeout     = 3 * time.Second // deadline for PID and platform lookups per instance
 )
 
+// instanceNamePattern restricts instance names so they can never be mistaken for an address
+var instanceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,62}$`)
+
 // detectInstancePlatform connects to an instance's host bridge and determines its platform
 func detectInstancePlatform(ctx context.Context, instance *common.CoreInstanceInfo) (string, error) {
 	hostTarget, err := common.NormalizeAddressForGRPC(instance.HostServiceAddress)
@@ -70,6 +81,10 @@ func NewInstanceCommand() *cobra.Command {
 	cmd.AddCommand(newInstanceDefaultCommand())
 	cmd.AddCommand(newInstanceNewCommand())
 	cmd.AddCommand(newInstanceKillCommand())
+	cmd.AddCommand(newInstanceLabelCommand())
+	cmd.AddCommand(newInstanceLogsCommand())
+	cmd.AddCommand(newInstanceInspectCommand())
+	cmd.AddCommand(newInstanceGCCommand())
 
 	return cmd
 }
@@ -78,9 +93,9 @@ func newInstanceKillCommand() *cobra.Command {
 	var killAllCLI bool
 
 	cmd := &cobra.Command{
-		Use:     "kill <address>",
+		Use:     "kill <address|name>",
 		Aliases: []string{"k"},
-		Short:   "Kill a Clica instance by address",
+		Short:   "Kill a Clica instance by address or name",
 		Long:    `Kill a running Clica instance and clean up its registry entry.`,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if killAllCLI && len(args) > 0 {
@@ -102,7 +117,11 @@ func newInstanceKillCommand() *cobra.Command {
 			if killAllCLI {
 				return killAllCLIInstances(ctx, registry)
 			} else {
-				return global.KillInstanceByAddress(ctx, registry, args[0])
+				address, err := registry.ResolveInstance(args[0])
+				if err != nil {
+					return err
+				}
+				return global.KillInstanceByAddress(ctx, registry, address)
 			}
 		},
 	}
@@ -266,6 +285,10 @@ func killInstanceProcess(ctx context.Context, registry *global.ClientRegistry, a
 		return killResult{address: address, pid: pid, err: err}
 	}
 
+	// Stop supervising it so the daemon doesn't bring it back
+	global.UnmanageInstance(address)
+	registry.RemoveInstanceMetadata(address)
+
 	return killResult{address: address, pid: pid, err: nil}
 }
 
@@ -289,6 +312,7 @@ func newInstanceListCommand() *cobra.Command {
 				return fmt.Errorf("failed to list instances: %w", err)
 			}
 			defaultInstance := registry.GetDefaultInstance()
+			metadata := registry.ListInstanceMetadata()
 
 			if len(instances) == 0 {
 				fmt.Println("No Clica instances found.")
@@ -299,6 +323,8 @@ func newInstanceListCommand() *cobra.Command {
 			// Build instance data
 			type instanceRow struct {
 				address   string
+				name      string
+				workspace string
 				status    string
 				version   string
 				lastSeen  string
@@ -307,59 +333,85 @@ func newInstanceListCommand() *cobra.Command {
 				isDefault string
 			}
 
-			var rows []instanceRow
-			for _, instance := range instances {
-				isDefault := ""
-				if instance.Address == defaultInstance {
-					isDefault = "✓"
-				}
+			// Fetch PID and platform for all instances in parallel
+			rows := make([]instanceRow, len(instances))
+			sem := make(chan struct{}, instanceListConcurrency)
+			var wg sync.WaitGroup
+			for i, instance := range instances {
+				wg.Add(1)
+				go func(i int, instance *common.CoreInstanceInfo) {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+
+					isDefault := ""
+					if instance.Address == defaultInstance {
+						isDefault = "✓"
+					}
 
-				lastSeen := instance.LastSeen.Format("15:04:05")
-				if time.Since(instance.LastSeen) > 24*time.Hour {
-					lastSeen = instance.LastSeen.Format("2006-01-02")
-				}
+					lastSeen := instance.LastSeen.Format("15:04:05")
+					if time.Since(instance.LastSeen) > 24*time.Hour {
+						lastSeen = instance.LastSeen.Format("2006-01-02")
+					}
 
-				// Get PID and platform via RPC if instance is healthy
-				pid := platformNA
-				platform := platformNA
-				if instance.Status == grpc_health_v1.HealthCheckResponse_SERVING {
-					// Get PID from core
-					if client, err := registry.GetClient(ctx, instance.Address); err == nil {
-						if processInfo, err := client.State.GetProcessInfo(ctx, &clica.EmptyRequest{}); err == nil {
-							pid = fmt.Sprintf("%d", processInfo.ProcessId)
-							// Update version from RPC if available
-							if processInfo.Version != nil && *processInfo.Version != "" && *processInfo.Version != "unknown" {
-								instance.Version = *processInfo.Version
+					// Get PID and platform via RPC if instance is healthy
+					pid := platformNA
+					platform := platformNA
+					if instance.Status == grpc_health_v1.HealthCheckResponse_SERVING {
+						rpcCtx, cancel := context.WithTimeout(ctx, instanceInfoTimeout)
+						defer cancel()
+
+						// Get PID from core
+						if client, err := registry.GetClient(rpcCtx, instance.Address); err == nil {
+							if processInfo, err := client.State.GetProcessInfo(rpcCtx, &clica.EmptyRequest{}); err == nil {
+								pid = fmt.Sprintf("%d", processInfo.ProcessId)
+								// Update version from RPC if available
+								if processInfo.Version != nil && *processInfo.Version != "" && *processInfo.Version != "unknown" {
+									instance.Version = *processInfo.Version
+								}
 							}
+							client.Disconnect()
+						}
+
+						// Get platform from host bridge
+						if detectedPlatform, err := detectInstancePlatform(rpcCtx, instance); err == nil {
+							platform = detectedPlatform
 						}
 					}
 
-					// Get platform from host bridge
-					if detectedPlatform, err := detectInstancePlatform(ctx, instance); err == nil {
-						platform = detectedPlatform
+					name := ""
+					workspace := ""
+					if meta, ok := metadata[instance.Address]; ok {
+						name = meta.Name
+						workspace = shortenHomePath(meta.Workspace)
 					}
-				}
 
-				rows = append(rows, instanceRow{
-					address:   instance.Address,
-					status:    instance.Status.String(),
-					version:   instance.Version,
-					lastSeen:  lastSeen,
-					pid:       pid,
-					platform:  platform,
-					isDefault: isDefault,
-				})
+					rows[i] = instanceRow{
+						address:   instance.Address,
+						name:      name,
+						workspace: workspace,
+						status:    instance.Status.String(),
+						version:   instance.Version,
+						lastSeen:  lastSeen,
+						pid:       pid,
+						platform:  platform,
+						isDefault: isDefault,
+					}
+				}(i, instance)
 			}
+			wg.Wait()
 
 			// Check output format
 			if global.Config.OutputFormat == "plain" {
 				// Use tabwriter for plain output
 				w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-				fmt.Fprintln(w, "ADDRESS\tSTATUS\tVERSION\tLAST SEEN\tPID\tPLATFORM\tDEFAULT")
+				fmt.Fprintln(w, "ADDRESS\tNAME\tWORKSPACE\tSTATUS\tVERSION\tLAST SEEN\tPID\tPLATFORM\tDEFAULT")
 
 				for _, row := range rows {
-					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 						row.address,
+						row.name,
+						row.workspace,
 						row.status,
 						row.version,
 						row.lastSeen,
@@ -373,12 +425,14 @@ func newInstanceListCommand() *cobra.Command {
 			} else {
 				// Use markdown table for rich output
 				var markdown strings.Builder
-				markdown.WriteString("| **ADDRESS (ID)** | **STATUS** | **VERSION** | **LAST SEEN** | **PID** | **PLATFORM** | **DEFAULT** |\n")
-				markdown.WriteString("|---------|--------|---------|-----------|-----|----------|---------|")
+				markdown.WriteString("| **ADDRESS (ID)** | **NAME** | **WORKSPACE** | **STATUS** | **VERSION** | **LAST SEEN** | **PID** | **PLATFORM** | **DEFAULT** |\n")
+				markdown.WriteString("|---------|------|-----------|--------|---------|-----------|-----|----------|---------|")
 
 				for _, row := range rows {
-					markdown.WriteString(fmt.Sprintf("\n| %s | %s | %s | %s | %s | %s | %s |",
+					markdown.WriteString(fmt.Sprintf("\n| %s | %s | %s | %s | %s | %s | %s | %s | %s |",
 						row.address,
+						row.name,
+						row.workspace,
 						row.status,
 						row.version,
 						row.lastSeen,
@@ -420,22 +474,25 @@ func newInstanceListCommand() *cobra.Command {
 
 func newInstanceDefaultCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "default <address>",
+		Use:     "default <address|name>",
 		Aliases: []string{"d"},
 		Short:   "Set the default Clica instance",
 		Long:    `Set the default Clica instance to use for subsequent commands.`,
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			address := args[0]
-
 			if global.Clients == nil {
 				return fmt.Errorf("clients not initialized")
 			}
 
 			registry := global.Clients.GetRegistry()
 
+			address, err := registry.ResolveInstance(args[0])
+			if err != nil {
+				return err
+			}
+
 			// Verify the instance exists
-			_, err := registry.GetInstance(address)
+			_, err = registry.GetInstance(address)
 			if err != nil {
 				return fmt.Errorf("instance %s not found. Run 'clica instance list' to see available instances", address)
 			}
@@ -454,13 +511,27 @@ func newInstanceDefaultCommand() *cobra.Command {
 }
 
 func newInstanceNewCommand() *cobra.Command {
-	var setDefault bool
+	var (
+		setDefault bool
+		name       string
+		workspace  string
+		labels     []string
+	)
 
 	cmd := &cobra.Command{
 		Use:     "new",
 		Aliases: []string{"n"},
 		Short:   "Create a new Clica instance",
-		Long:    `Create a new Clica instance with automatically assigned ports.`,
+		Long: `Create a new Clica instance with automatically assigned ports.
+
+Give the instance a --name to refer to it with --instance <name> (or in place of its
+address) in other commands. With --workspace the instance works in that directory, and
+commands run from inside it use this instance instead of the default one.
+
+Examples:
+  clica instance new --name api-refactor --workspace ./services/api
+  clica instance new --name web --label team=frontend`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
@@ -468,15 +539,44 @@ func newInstanceNewCommand() *cobra.Command {
 				return fmt.Errorf("clients not initialized")
 			}
 
+			if name != "" {
+				if err := validateInstanceName(name); err != nil {
+					return err
+				}
+			}
+
+			if workspace != "" {
+				resolved, err := resolveWorkspaceDir(workspace)
+				if err != nil {
+					return err
+				}
+				workspace = resolved
+			}
+
+			parsedLabels, err := parseInstanceLabels(labels)
+			if err != nil {
+				return err
+			}
+
 			fmt.Println("Starting new Clica instance...")
 
-			instance, err := global.Clients.StartNewInstance(ctx)
+			instance, err := global.Clients.StartNewInstanceWithOptions(ctx, global.InstanceOptions{
+				Name:      name,
+				Workspace: workspace,
+				Labels:    parsedLabels,
+			})
 			if err != nil {
 				return fmt.Errorf("failed to start instance: %w", err)
 			}
 
 			fmt.Printf("Successfully started new instance:\n")
 			fmt.Printf("  Address: %s\n", instance.Address)
+			if name != "" {
+				fmt.Printf("  Name: %s\n", name)
+			}
+			if workspace != "" {
+				fmt.Printf("  Workspace: %s\n", workspace)
+			}
 			fmt.Printf("  Core Port: %d\n", instance.CorePort())
 			fmt.Printf("  Host Bridge Port: %d\n", instance.HostPort())
 
@@ -501,6 +601,178 @@ func newInstanceNewCommand() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&setDefault, "default", "d", false, "set as default instance")
+	cmd.Flags().StringVar(&name, "name", "", "unique name to refer to the instance by")
+	cmd.Flags().StringVar(&workspace, "workspace", "", "workspace directory for the instance (defaults to the current directory)")
+	cmd.Flags().StringSliceVarP(&labels, "label", "l", nil, "instance labels (key=value format)")
 
 	return cmd
-}
\ No newline at end of file
+}
+
+func newInstanceLabelCommand() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "label <address|name> [key=value | key-]...",
+		Short: "Set the name and labels of a Clica instance",
+		Long: `Set or remove labels of a running Clica instance, or rename it with --name.
+
+Labels are given as key=value; a trailing dash (key-) removes a label. Without any
+labels or --name, the current name, workspace and labels are shown.
+
+Examples:
+  clica instance label localhost:50052 --name api-refactor
+  clica instance label api-refactor team=backend ticket-`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			registry := global.Clients.GetRegistry()
+
+			address, err := registry.ResolveInstance(args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := registry.GetInstance(address); err != nil {
+				return fmt.Errorf("instance %s not found. Run 'clica instance list' to see available instances", address)
+			}
+
+			meta := registry.GetInstanceMetadata(address)
+			if meta == nil {
+				meta = &common.InstanceMetadata{Address: address}
+			}
+			if meta.Labels == nil {
+				meta.Labels = map[string]string{}
+			}
+
+			if len(args) == 1 && !cmd.Flags().Changed("name") {
+				return printInstanceMetadata(meta)
+			}
+
+			if cmd.Flags().Changed("name") {
+				if name != "" {
+					if err := validateInstanceName(name); err != nil {
+						return err
+					}
+				}
+				meta.Name = name
+			}
+
+			for _, arg := range args[1:] {
+				if key, ok := strings.CutSuffix(arg, "-"); ok && !strings.Contains(arg, "=") {
+					delete(meta.Labels, key)
+					continue
+				}
+				parsed, err := parseInstanceLabels([]string{arg})
+				if err != nil {
+					return err
+				}
+				for key, value := range parsed {
+					meta.Labels[key] = value
+				}
+			}
+
+			if err := registry.SetInstanceMetadata(*meta); err != nil {
+				return fmt.Errorf("failed to update instance %s: %w", address, err)
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Updated instance %s", address)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "new name for the instance (empty to remove it)")
+
+	return cmd
+}
+
+// printInstanceMetadata shows the name, workspace and labels of an instance
+func printInstanceMetadata(meta *common.InstanceMetadata) error {
+	if global.Config.OutputFormat == "json" {
+		data, err := json.MarshalIndent(meta, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal instance metadata: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Address:\t%s\n", meta.Address)
+	fmt.Fprintf(w, "Name:\t%s\n", meta.Name)
+	fmt.Fprintf(w, "Workspace:\t%s\n", meta.Workspace)
+
+	fmt.Fprintf(w, "Labels:\t%s\n", formatInstanceLabels(meta.Labels))
+
+	return w.Flush()
+}
+
+// formatInstanceLabels formats labels as sorted key=value pairs
+func formatInstanceLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// validateInstanceName checks that a name can be used with --instance
+func validateInstanceName(name string) error {
+	if !instanceNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid instance name '%s': use up to 63 letters, digits, '.', '_' or '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// resolveWorkspaceDir turns a --workspace value into an absolute directory with symlinks resolved
+func resolveWorkspaceDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve workspace %s: %w", dir, err)
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("workspace %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("workspace %s is not a directory", dir)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(abs); err == nil {
+		abs = resolved
+	}
+	return abs, nil
+}
+
+// parseInstanceLabels parses key=value label flags
+func parseInstanceLabels(labels []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(labels))
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label '%s': expected key=value", label)
+		}
+		parsed[key] = strings.TrimSpace(value)
+	}
+	return parsed, nil
+}
+
+// shortenHomePath replaces the home directory prefix of a path with ~
+func shortenHomePath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return filepath.Join("~", rest)
+	}
+	return path
+}
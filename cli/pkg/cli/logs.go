@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -32,6 +33,7 @@ func NewLogsCommand() *cobra.Command {
 	cmd.AddCommand(newLogsListCommand())
 	cmd.AddCommand(newLogsCleanCommand())
 	cmd.AddCommand(newLogsPathCommand())
+	cmd.AddCommand(newLogsQueryCommand())
 
 	return cmd
 }
@@ -68,6 +70,7 @@ func newLogsListCommand() *cobra.Command {
 
 func newLogsCleanCommand() *cobra.Command {
 	var olderThan int
+	var maxSize string
 	var all bool
 	var dryRun bool
 
@@ -75,7 +78,15 @@ func newLogsCleanCommand() *cobra.Command {
 		Use:     "clean",
 		Aliases: []string{"c"},
 		Short:   "Delete old log files",
-		Long:    `Delete log files older than a specified number of days.`,
+		Long: `Delete log files older than a specified number of days, or the oldest log files
+until the logs directory fits in a size cap.
+
+With --max-size, the log files currently written by running instances are kept.
+
+Examples:
+  clica logs clean --older-than 3
+  clica logs clean --max-size 500MB
+  clica logs clean --max-size 1G --older-than 30`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if global.Config == nil {
 				return fmt.Errorf("config not initialized")
@@ -87,17 +98,35 @@ func newLogsCleanCommand() *cobra.Command {
 				return fmt.Errorf("failed to list log files: %w", err)
 			}
 
+			var sizeCap int64
+			if maxSize != "" {
+				sizeCap, err = parseByteSize(maxSize)
+				if err != nil {
+					return err
+				}
+			}
+
 			var toDelete []logFileInfo
-			if all {
+			switch {
+			case all:
 				toDelete = logs
-			} else {
+			case maxSize != "":
+				// The size cap replaces the age filter unless --older-than is given too
+				if cmd.Flags().Changed("older-than") {
+					toDelete = filterOldLogs(logs, olderThan)
+				}
+				toDelete = append(toDelete, filterLogsOverSize(logs, toDelete, sizeCap, activeLogFiles(logsDir))...)
+			default:
 				toDelete = filterOldLogs(logs, olderThan)
 			}
 
 			if len(toDelete) == 0 {
-				if all {
+				switch {
+				case all:
 					fmt.Println("No log files to delete.")
-				} else {
+				case maxSize != "":
+					fmt.Printf("Log files already fit in %s.\n", formatFileSize(sizeCap))
+				default:
 					fmt.Printf("No log files older than %d days found.\n", olderThan)
 				}
 				return nil
@@ -139,6 +168,7 @@ func newLogsCleanCommand() *cobra.Command {
 	}
 
 	cmd.Flags().IntVar(&olderThan, "older-than", 7, "delete logs older than N days")
+	cmd.Flags().StringVar(&maxSize, "max-size", "", "delete the oldest logs until all logs fit in this size (e.g. 500MB, 1G)")
 	cmd.Flags().BoolVar(&all, "all", false, "delete all log files")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be deleted without deleting")
 
@@ -251,6 +281,80 @@ func filterOldLogs(logs []logFileInfo, olderThanDays int) []logFileInfo {
 	return filtered
 }
 
+// filterLogsOverSize picks the oldest logs to delete, besides those already selected,
+// until the remaining logs fit in maxBytes. Files in keep are never picked.
+func filterLogsOverSize(logs []logFileInfo, selected []logFileInfo, maxBytes int64, keep map[string]bool) []logFileInfo {
+	deleting := make(map[string]bool)
+	for _, log := range selected {
+		deleting[log.path] = true
+	}
+
+	var total int64
+	for _, log := range logs {
+		if !deleting[log.path] {
+			total += log.size
+		}
+	}
+
+	// logs are sorted newest first, so walk them backwards
+	var filtered []logFileInfo
+	for i := len(logs) - 1; i >= 0 && total > maxBytes; i-- {
+		log := logs[i]
+		if deleting[log.path] || keep[log.path] {
+			continue
+		}
+		filtered = append(filtered, log)
+		total -= log.size
+	}
+
+	return filtered
+}
+
+// activeLogFiles returns the log files running instances are writing to: the newest
+// core log, host log and host output file for each of their ports. Deleting these
+// would free no space while the processes hold them open.
+func activeLogFiles(logsDir string) map[string]bool {
+	active := make(map[string]bool)
+	if global.Clients == nil {
+		return active
+	}
+
+	for _, instance := range global.Clients.GetRegistry().ListInstances() {
+		if path := newestInstanceLog(logsDir, "core", instance.CorePort()); path != "" {
+			active[path] = true
+		}
+		if path := newestInstanceLog(logsDir, "host", instance.HostPort()); path != "" {
+			active[path] = true
+		}
+		if path := newestInstanceLog(logsDir, "out", instance.HostPort()); path != "" {
+			active[path] = true
+		}
+	}
+
+	return active
+}
+
+// parseByteSize parses sizes like 500MB, 1.5G or 2048 (bytes), using 1024-based units
+func parseByteSize(value string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "IB"), "B")
+
+	multiplier := int64(1)
+	if s != "" {
+		if exp := strings.IndexByte("KMGT", s[len(s)-1]); exp >= 0 {
+			multiplier = int64(1) << (10 * (exp + 1))
+			s = s[:len(s)-1]
+		}
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size '%s': use a value like 500MB or 1G", value)
+	}
+
+	return int64(n * float64(multiplier)), nil
+}
+
 func deleteLogFiles(files []logFileInfo) (int, int64, error) {
 	var count int
 	var bytesFreed int64
@@ -0,0 +1,343 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/spf13/cobra"
+)
+
+// logLevels orders the levels 'logs query' filters on
+var logLevels = map[string]int{"debug": 0, "info": 1, "warn": 2, "error": 3}
+
+// plainLogLevelPattern finds a level in log lines that aren't structured
+var plainLogLevelPattern = regexp.MustCompile(`\b(DEBUG|INFO|WARN|WARNING|ERROR|FATAL)\b`)
+
+// logFileNamePattern extracts the service and port from a log file name
+var logFileNamePattern = regexp.MustCompile(`^clica-(\w+)-\d{4}-\d{2}-\d{2}-\d{2}-\d{2}-\d{2}-localhost-(\d+)\.log$`)
+
+// logEntry is one log record found by 'logs query'
+type logEntry struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Service string         `json:"service"`
+	Port    int            `json:"port"`
+	File    string         `json:"file"`
+	Message string         `json:"message"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// logQuery selects log entries
+type logQuery struct {
+	level   int
+	service string
+	since   time.Time
+	until   time.Time
+	pattern *regexp.Regexp
+}
+
+func newLogsQueryCommand() *cobra.Command {
+	var (
+		level   string
+		service string
+		since   string
+		until   string
+		grep    string
+		limit   int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "query",
+		Aliases: []string{"q"},
+		Short:   "Search log entries across all log files",
+		Long: `Search the log files of all instances, merging their entries in time order.
+
+Structured (JSON) logs written by clica-host are filtered by their level; for plain
+log lines the level is guessed from words like ERROR or WARN, defaulting to info.
+
+Examples:
+  clica logs query --level warn --since 1h
+  clica logs query --service host --grep "diff_id"
+  clica logs query --since 2025-01-02T15:00:00Z --until 2025-01-02T16:00:00Z -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Config == nil {
+				return fmt.Errorf("config not initialized")
+			}
+
+			query := logQuery{service: service}
+
+			minLevel, ok := logLevels[strings.ToLower(level)]
+			if !ok {
+				return fmt.Errorf("invalid --level '%s': must be one of debug, info, warn, error", level)
+			}
+			query.level = minLevel
+
+			if service != "" && service != "core" && service != "host" {
+				return fmt.Errorf("invalid --service '%s': must be core or host", service)
+			}
+
+			var err error
+			if since != "" {
+				if query.since, err = parseTimeFlag("since", since); err != nil {
+					return err
+				}
+			}
+			if until != "" {
+				if query.until, err = parseTimeFlag("until", until); err != nil {
+					return err
+				}
+			}
+			if grep != "" {
+				if query.pattern, err = regexp.Compile(grep); err != nil {
+					return fmt.Errorf("invalid --grep pattern: %w", err)
+				}
+			}
+
+			logsDir := filepath.Join(global.Config.ConfigPath, "logs")
+			entries, err := queryLogs(logsDir, query)
+			if err != nil {
+				return err
+			}
+
+			if limit > 0 && len(entries) > limit {
+				entries = entries[len(entries)-limit:]
+			}
+
+			if global.Config.OutputFormat == "json" {
+				data, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal log entries: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(entries) == 0 {
+				fmt.Println("No matching log entries found.")
+				return nil
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			for _, entry := range entries {
+				printLogEntry(entry, renderer)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&level, "level", "debug", "minimum level to show (debug, info, warn, error)")
+	cmd.Flags().StringVar(&service, "service", "", "only show logs of one service (core or host)")
+	cmd.Flags().StringVar(&since, "since", "", "only show entries newer than a duration (e.g. 1h) or time (RFC 3339)")
+	cmd.Flags().StringVar(&until, "until", "", "only show entries older than a duration (e.g. 10m) or time (RFC 3339)")
+	cmd.Flags().StringVar(&grep, "grep", "", "only show entries whose message matches a regular expression")
+	cmd.Flags().IntVarP(&limit, "limit", "n", 0, "only show the last N matching entries (0 for all)")
+
+	return cmd
+}
+
+// queryLogs reads all log files and returns the matching entries, oldest first
+func queryLogs(logsDir string, query logQuery) ([]logEntry, error) {
+	logs, err := listLogFiles(logsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list log files: %w", err)
+	}
+
+	entries := []logEntry{}
+	for _, log := range logs {
+		match := logFileNamePattern.FindStringSubmatch(log.name)
+		if match == nil {
+			continue
+		}
+		if query.service != "" && match[1] != query.service {
+			continue
+		}
+		// A file started after the end of the window can't contain matching entries
+		if !query.until.IsZero() && log.created.After(query.until) {
+			continue
+		}
+
+		port, _ := strconv.Atoi(match[2])
+		fileEntries, err := readLogEntries(log, match[1], port)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range fileEntries {
+			if query.matches(entry) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+
+	return entries, nil
+}
+
+// readLogEntries parses a log file into entries. Plain lines without a timestamp
+// (e.g. stack traces) are appended to the entry before them.
+func readLogEntries(log logFileInfo, service string, port int) ([]logEntry, error) {
+	file, err := os.Open(log.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	defer file.Close()
+
+	var entries []logEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		entry, ok := parseStructuredLogLine(line)
+		if !ok {
+			lineTime, hasTime := parseLogLineTime(line)
+			if !hasTime && len(entries) > 0 {
+				last := &entries[len(entries)-1]
+				last.Message += "\n" + line
+				continue
+			}
+			if !hasTime {
+				lineTime = log.created
+			}
+			entry = logEntry{Time: lineTime, Level: guessLogLevel(line), Message: line}
+		}
+
+		entry.Service = service
+		entry.Port = port
+		entry.File = log.name
+		entries = append(entries, entry)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", log.name, err)
+	}
+	return entries, nil
+}
+
+// parseStructuredLogLine parses a JSON log line as written by slog
+func parseStructuredLogLine(line string) (logEntry, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return logEntry{}, false
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		return logEntry{}, false
+	}
+
+	timeValue, _ := record["time"].(string)
+	t, err := time.Parse(time.RFC3339Nano, timeValue)
+	if err != nil {
+		return logEntry{}, false
+	}
+
+	entry := logEntry{Time: t, Level: "info"}
+	if level, ok := record["level"].(string); ok {
+		// slog writes levels between the named ones as e.g. INFO+2
+		entry.Level = strings.ToLower(strings.SplitN(strings.SplitN(level, "+", 2)[0], "-", 2)[0])
+	}
+	entry.Message, _ = record["msg"].(string)
+
+	for _, key := range []string{"time", "level", "msg", "service"} {
+		delete(record, key)
+	}
+	if len(record) > 0 {
+		entry.Attrs = record
+	}
+
+	return entry, true
+}
+
+// guessLogLevel picks the level of a plain log line from the first level word in it
+func guessLogLevel(line string) string {
+	if strings.HasPrefix(line, "panic:") {
+		return "error"
+	}
+	switch plainLogLevelPattern.FindString(line) {
+	case "DEBUG":
+		return "debug"
+	case "WARN", "WARNING":
+		return "warn"
+	case "ERROR", "FATAL":
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// matches reports whether an entry passes the query's filters
+func (q logQuery) matches(entry logEntry) bool {
+	if level, ok := logLevels[entry.Level]; ok && level < q.level {
+		return false
+	}
+	if !q.since.IsZero() && entry.Time.Before(q.since) {
+		return false
+	}
+	if !q.until.IsZero() && entry.Time.After(q.until) {
+		return false
+	}
+	if q.pattern != nil && !q.pattern.MatchString(entry.Message) && !q.pattern.MatchString(formatLogAttrs(entry.Attrs)) {
+		return false
+	}
+	return true
+}
+
+// printLogEntry prints an entry as one line: time, level, service, message and attributes
+func printLogEntry(entry logEntry, renderer *display.Renderer) {
+	level := fmt.Sprintf("%-5s", strings.ToUpper(entry.Level))
+	switch entry.Level {
+	case "error":
+		level = renderer.Red(level)
+	case "warn":
+		level = renderer.Yellow(level)
+	case "debug":
+		level = renderer.Dim(level)
+	}
+
+	line := fmt.Sprintf("%s %s %s %s",
+		renderer.Dim(entry.Time.Local().Format("2006-01-02 15:04:05.000")),
+		level,
+		renderer.Dim(fmt.Sprintf("%s:%d", entry.Service, entry.Port)),
+		entry.Message)
+	if attrs := formatLogAttrs(entry.Attrs); attrs != "" {
+		line += " " + renderer.Dim(attrs)
+	}
+	fmt.Println(line)
+}
+
+// formatLogAttrs formats structured attributes as sorted key=value pairs
+func formatLogAttrs(attrs map[string]any) string {
+	keys := make([]string, 0, len(attrs))
+	for key := range attrs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := attrs[key]
+		if s, ok := value.(string); ok {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", key, s))
+			continue
+		}
+		data, _ := json.Marshal(value)
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, data))
+	}
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,275 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/clica/cli/pkg/cli/auth"
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/grpc-go/clica"
+	"github.com/spf13/cobra"
+)
+
+func NewModelCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "model",
+		Aliases: []string{"models"},
+		Short:   "List and switch models",
+		Long: `List the models available for a provider and switch models without going through the auth wizard.
+
+Examples:
+  clica model list
+  clica model list --provider anthropic
+  clica model use claude-sonnet-4-5-20250929
+  clica model use gpt-5 --act --provider openai-native
+  clica model use anthropic/claude-opus-4.1 --plan --task-only`,
+	}
+
+	cmd.AddCommand(newModelListCommand())
+	cmd.AddCommand(newModelUseCommand())
+
+	return cmd
+}
+
+func newModelListCommand() *cobra.Command {
+	var (
+		address  string
+		provider string
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"l", "ls"},
+		Short:   "List available models",
+		Long:    `List models for a provider, including context window, pricing and image support. Defaults to the provider active in plan mode.`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if err := ensureTaskManager(ctx, address); err != nil {
+				return err
+			}
+
+			// Resolve provider, defaulting to the one active in plan mode
+			var providerEnum clica.ApiProvider
+			currentModel := ""
+			active, activeErr := auth.GetActiveProviderForMode(ctx, taskManager, "plan")
+			if provider != "" {
+				var err error
+				providerEnum, err = auth.ParseProviderID(provider)
+				if err != nil {
+					return err
+				}
+				if activeErr == nil && active.Provider == providerEnum {
+					currentModel = active.ModelID
+				}
+			} else {
+				if activeErr != nil {
+					return activeErr
+				}
+				providerEnum = active.Provider
+				currentModel = active.ModelID
+			}
+
+			models, err := auth.ListProviderModels(ctx, taskManager, providerEnum)
+			if err != nil {
+				return err
+			}
+
+			if global.Config.OutputFormat == "json" {
+				data, err := json.MarshalIndent(models, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal models: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(models) == 0 {
+				fmt.Printf("No models found for %s\n", auth.GetProviderDisplayName(providerEnum))
+				return nil
+			}
+
+			fmt.Printf("Models for %s (%d)\n\n", auth.GetProviderDisplayName(providerEnum), len(models))
+			renderModelsTable(models, currentModel)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to use")
+	cmd.Flags().StringVarP(&provider, "provider", "p", "", "provider ID to list models for (e.g., anthropic, openrouter)")
+
+	return cmd
+}
+
+func newModelUseCommand() *cobra.Command {
+	var (
+		address  string
+		provider string
+		planOnly bool
+		actOnly  bool
+		taskOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "use <model-id>",
+		Short: "Switch the model used for plan and/or act mode",
+		Long: `Switch the model used for plan and/or act mode.
+
+By default the model is saved to the global API configuration for both modes.
+Use --plan or --act to change a single mode, and --task-only to apply the change
+to the current task without touching the global configuration.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			modelID := strings.TrimSpace(args[0])
+
+			if planOnly && actOnly {
+				return fmt.Errorf("--plan and --act are mutually exclusive (omit both to update both modes)")
+			}
+
+			modes := []string{"plan", "act"}
+			if planOnly {
+				modes = []string{"plan"}
+			} else if actOnly {
+				modes = []string{"act"}
+			}
+
+			if err := ensureTaskManager(ctx, address); err != nil {
+				return err
+			}
+
+			// Resolve provider, defaulting to the one active in the first selected mode
+			var providerEnum clica.ApiProvider
+			setProvider := false
+			if provider != "" {
+				var err error
+				providerEnum, err = auth.ParseProviderID(provider)
+				if err != nil {
+					return err
+				}
+				setProvider = true
+			} else {
+				active, err := auth.GetActiveProviderForMode(ctx, taskManager, modes[0])
+				if err != nil {
+					return err
+				}
+				providerEnum = active.Provider
+			}
+
+			if taskOnly {
+				if err := auth.UseModelForCurrentTask(ctx, taskManager, providerEnum, modelID, modes, setProvider); err != nil {
+					return err
+				}
+			} else {
+				if err := auth.UseModel(ctx, taskManager, providerEnum, modelID, modes, setProvider); err != nil {
+					return err
+				}
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			scope := "globally"
+			if taskOnly {
+				scope = "for the current task"
+			}
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Using %s (%s) for %s mode %s",
+				modelID, auth.GetProviderDisplayName(providerEnum), strings.Join(modes, " and "), scope)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to use")
+	cmd.Flags().StringVarP(&provider, "provider", "p", "", "provider ID for the model (defaults to the active provider)")
+	cmd.Flags().BoolVar(&planOnly, "plan", false, "only change the plan mode model")
+	cmd.Flags().BoolVar(&actOnly, "act", false, "only change the act mode model")
+	cmd.Flags().BoolVar(&taskOnly, "task-only", false, "apply to the current task only instead of the global configuration")
+
+	return cmd
+}
+
+// renderModelsTable prints models as a tabwriter table (plain) or markdown table (rich)
+func renderModelsTable(models []auth.ModelSummary, currentModel string) {
+	type modelRow struct {
+		id, context, input, output, images, current string
+	}
+
+	rows := make([]modelRow, 0, len(models))
+	for _, model := range models {
+		row := modelRow{id: model.ID, context: "-", input: "-", output: "-", images: "-"}
+		if model.HasInfo {
+			if model.ContextWindow > 0 {
+				row.context = formatContextWindow(model.ContextWindow)
+			}
+			row.input = formatModelPrice(model.InputPrice)
+			row.output = formatModelPrice(model.OutputPrice)
+			row.images = "no"
+			if model.SupportsImages {
+				row.images = "yes"
+			}
+		}
+		if model.ID == currentModel {
+			row.current = "✓"
+		}
+		rows = append(rows, row)
+	}
+
+	if global.Config.OutputFormat == "plain" {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "MODEL\tCONTEXT\tINPUT $/M\tOUTPUT $/M\tIMAGES\tCURRENT")
+		for _, row := range rows {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", row.id, row.context, row.input, row.output, row.images, row.current)
+		}
+		w.Flush()
+		return
+	}
+
+	var markdown strings.Builder
+	markdown.WriteString("| **MODEL** | **CONTEXT** | **INPUT $/M** | **OUTPUT $/M** | **IMAGES** | **CURRENT** |\n")
+	markdown.WriteString("|-------|---------|-----------|------------|--------|---------|")
+	for _, row := range rows {
+		markdown.WriteString(fmt.Sprintf("\n| %s | %s | %s | %s | %s | %s |",
+			row.id, row.context, row.input, row.output, row.images, row.current))
+	}
+
+	mdRenderer, err := display.NewMarkdownRendererForTerminal()
+	if err != nil {
+		fmt.Println(markdown.String())
+		return
+	}
+	rendered, err := mdRenderer.Render(markdown.String())
+	if err != nil {
+		fmt.Println(markdown.String())
+		return
+	}
+
+	colorRenderer := display.NewRenderer(global.Config.OutputFormat)
+	rendered = strings.ReplaceAll(rendered, "✓", colorRenderer.Green("✓"))
+	fmt.Print(strings.TrimLeft(rendered, "\n"))
+	fmt.Println()
+}
+
+// formatContextWindow formats a token count as e.g. "200k" or "1M"
+func formatContextWindow(tokens int64) string {
+	if tokens >= 1000000 && tokens%1000000 == 0 {
+		return fmt.Sprintf("%dM", tokens/1000000)
+	}
+	if tokens >= 1000 {
+		return fmt.Sprintf("%dk", tokens/1000)
+	}
+	return fmt.Sprintf("%d", tokens)
+}
+
+// formatModelPrice formats a per-million-token price, "free" when it's zero and "-" when unknown
+func formatModelPrice(price *float64) string {
+	if price == nil {
+		return "-"
+	}
+	if *price == 0 {
+		return "free"
+	}
+	return fmt.Sprintf("$%.2f", *price)
+}
@@ -1,7 +1,9 @@
 package output
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,6 +24,8 @@ type OutputCoordinator struct {
 	inputVisible    atomic.Bool
 	inputModel      *InputModel      // Reference to current input model for state restoration
 	restartCallback func(*InputModel) // Callback to restart the program with preserved state
+	capture         io.Writer         // Receives all output instead of stdout while set, see Capture
+	captureMu       sync.Mutex        // Serializes Capture calls
 }
 
 var (
@@ -74,6 +78,11 @@ func (oc *OutputCoordinator) IsInputVisible() bool {
 // Printf prints formatted output, suspending input if necessary
 func (oc *OutputCoordinator) Printf(format string, args ...interface{}) {
 	oc.mu.Lock()
+	if oc.capture != nil {
+		fmt.Fprintf(oc.capture, format, args...)
+		oc.mu.Unlock()
+		return
+	}
 	prog := oc.program
 	model := oc.inputModel
 	restart := oc.restartCallback
@@ -119,6 +128,34 @@ func (oc *OutputCoordinator) Print(args ...interface{}) {
 	oc.Printf("%s", fmt.Sprint(args...))
 }
 
+// Capture runs fn and returns everything it printed through the coordinator instead of
+// writing it to stdout. The full-screen TUI uses it to render messages with the handlers.
+func (oc *OutputCoordinator) Capture(fn func()) string {
+	oc.captureMu.Lock()
+	defer oc.captureMu.Unlock()
+
+	var buf bytes.Buffer
+	oc.mu.Lock()
+	oc.capture = &buf
+	oc.mu.Unlock()
+
+	defer func() {
+		oc.mu.Lock()
+		oc.capture = nil
+		oc.mu.Unlock()
+	}()
+
+	fn()
+	return buf.String()
+}
+
+// IsCapturing reports whether output is currently being captured
+func (oc *OutputCoordinator) IsCapturing() bool {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	return oc.capture != nil
+}
+
 // Package-level convenience functions
 
 // Printf prints formatted output via the global coordinator
@@ -136,6 +173,16 @@ func Print(args ...interface{}) {
 	GetCoordinator().Print(args...)
 }
 
+// Capture captures the output of fn via the global coordinator
+func Capture(fn func()) string {
+	return GetCoordinator().Capture(fn)
+}
+
+// IsCapturing checks whether the global coordinator is capturing output
+func IsCapturing() bool {
+	return GetCoordinator().IsCapturing()
+}
+
 // SetProgram sets the bubbletea program on the global coordinator
 func SetProgram(program *tea.Program) {
 	GetCoordinator().SetProgram(program)
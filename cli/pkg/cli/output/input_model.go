@@ -9,6 +9,7 @@ import (
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/clica/cli/pkg/cli/theme"
 )
 
 // InputType represents the type of input being collected
@@ -80,36 +81,25 @@ type fieldStyles struct {
 	option         lipgloss.Style
 }
 
-// newFieldStyles creates huh-inspired styles (Charm theme)
+// newFieldStyles creates huh-inspired styles in the colors of the current theme
 func newFieldStyles() fieldStyles {
-	// Charm theme colors
-	indigo := lipgloss.AdaptiveColor{Light: "#5A56E0", Dark: "#7571F9"}
-	fuchsia := lipgloss.Color("#F780E2")
-	normalFg := lipgloss.AdaptiveColor{Light: "235", Dark: "252"}
-	green := lipgloss.AdaptiveColor{Light: "#02BA84", Dark: "#02BF87"}
+	t := theme.Current()
 
 	return fieldStyles{
 		base: lipgloss.NewStyle().
 			PaddingLeft(1).
 			BorderStyle(lipgloss.ThickBorder()).
 			BorderLeft(true).
-			BorderForeground(lipgloss.Color("238")),
-		title: lipgloss.NewStyle().
-			Foreground(indigo).
+			BorderForeground(t.Border.Lipgloss()),
+		title: t.Style(t.Accent).
 			Bold(true),
-		textArea: lipgloss.NewStyle().
-			Foreground(normalFg),
-		cursor: lipgloss.NewStyle().
-			Foreground(green),
-		placeholder: lipgloss.NewStyle().
-			Foreground(lipgloss.AdaptiveColor{Light: "248", Dark: "238"}),
-		selector: lipgloss.NewStyle().
-			Foreground(fuchsia).
+		textArea:    t.Style(t.Text),
+		cursor:      t.Style(t.Accent),
+		placeholder: t.Style(t.Subtle),
+		selector: t.Style(t.Accent).
 			SetString("> "),
-		selectedOption: lipgloss.NewStyle().
-			Foreground(normalFg),
-		option: lipgloss.NewStyle().
-			Foreground(normalFg),
+		selectedOption: t.Style(t.Text),
+		option:         t.Style(t.Text),
 	}
 }
 
@@ -133,10 +123,7 @@ func NewInputModel(inputType InputType, title, placeholder, currentMode string)
 	styles := newFieldStyles()
 
 	// Set cursor color based on mode
-	cursorColor := lipgloss.Color("3") // Yellow for plan
-	if currentMode == "act" {
-		cursorColor = lipgloss.Color("39") // Blue for act
-	}
+	cursorColor := theme.Current().ModeColor(currentMode).Lipgloss()
 
 	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()   // No cursor line highlighting
 	ta.FocusedStyle.EndOfBuffer = lipgloss.NewStyle()  // No end-of-buffer styling
@@ -345,15 +332,7 @@ func (m *InputModel) View() string {
 	var parts []string
 
 	// Render title with mode indicator
-	yellow := lipgloss.Color("3")
-	blue := lipgloss.Color("39")
-
-	modeStyle := lipgloss.NewStyle().Bold(true)
-	if m.currentMode == "plan" {
-		modeStyle = modeStyle.Foreground(yellow)
-	} else {
-		modeStyle = modeStyle.Foreground(blue)
-	}
+	modeStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Current().ModeColor(m.currentMode).Lipgloss())
 
 	modeIndicator := modeStyle.Render(fmt.Sprintf("[%s mode]", m.currentMode))
 	titleText := m.styles.title.Render(m.title)
@@ -418,10 +397,7 @@ func (m *InputModel) Clone() *InputModel {
 	ta.KeyMap.InsertNewline.SetKeys("alt+enter", "ctrl+j")
 
 	// Apply styles (including mode-based cursor color)
-	cursorColor := lipgloss.Color("3") // Yellow for plan
-	if m.currentMode == "act" {
-		cursorColor = lipgloss.Color("39") // Blue for act
-	}
+	cursorColor := theme.Current().ModeColor(m.currentMode).Lipgloss()
 
 	ta.FocusedStyle.CursorLine = lipgloss.NewStyle()
 	ta.FocusedStyle.EndOfBuffer = lipgloss.NewStyle()
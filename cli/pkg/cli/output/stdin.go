@@ -0,0 +1,95 @@
+package output
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"sync"
+)
+
+// stdinLines is the only reader of stdin for line-based prompts. Accessible prompts and
+// accessible forms both take their lines from it, so they never race each other for input.
+// A line is only read when one is asked for, leaving the terminal free in between for
+// password prompts, which read it directly.
+var stdinLines struct {
+	once     sync.Once
+	mu       sync.Mutex
+	pending  bool // a line was asked for and not yet taken, e.g. by a prompt whose context ended
+	eof      bool
+	requests chan struct{}
+	lines    chan string
+}
+
+// ReadStdinLine returns the next line typed on stdin, or io.EOF when stdin is closed. A line
+// asked for by a call whose context ended is returned by the next call instead of being lost.
+func ReadStdinLine(ctx context.Context) (string, error) {
+	s := &stdinLines
+	s.once.Do(func() {
+		s.requests = make(chan struct{}, 1)
+		s.lines = make(chan string, 1)
+		go func() {
+			scanner := bufio.NewScanner(os.Stdin)
+			for range s.requests {
+				if !scanner.Scan() {
+					close(s.lines)
+					return
+				}
+				s.lines <- scanner.Text()
+			}
+		}()
+	})
+
+	s.mu.Lock()
+	if s.eof {
+		s.mu.Unlock()
+		return "", io.EOF
+	}
+	if !s.pending {
+		s.pending = true
+		s.requests <- struct{}{}
+	}
+	s.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case line, ok := <-s.lines:
+		s.mu.Lock()
+		s.pending = false
+		s.eof = !ok
+		s.mu.Unlock()
+		if !ok {
+			return "", io.EOF
+		}
+		return line, nil
+	}
+}
+
+// StdinReader returns a reader of stdin for huh's accessible forms that shares the lines of
+// ReadStdinLine. It hands out the stdin file descriptor, which password fields read directly.
+func StdinReader() io.Reader {
+	return &stdinReader{}
+}
+
+type stdinReader struct {
+	buf []byte
+}
+
+func (r *stdinReader) Read(p []byte) (int, error) {
+	if len(r.buf) == 0 {
+		line, err := ReadStdinLine(context.Background())
+		if err != nil {
+			return 0, err
+		}
+		r.buf = []byte(line + "\n")
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Fd returns the stdin file descriptor, for reading passwords without echo
+func (r *stdinReader) Fd() uintptr {
+	return os.Stdin.Fd()
+}
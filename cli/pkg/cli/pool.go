@@ -0,0 +1,329 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/spf13/cobra"
+)
+
+func NewPoolCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pool",
+		Short: "Manage warm instance pools for fast task startup",
+		Long: `Keep pre-started, idle Clica instances ready for a workspace.
+
+'clica "prompt"' run in a workspace with a pool claims an idle pool instance instead of
+starting a new one, and returns it to the pool when the task is done. Instances are
+recycled after a failed task, after a profile was applied to them, or after running
+--max-uses tasks.
+
+Pools are kept at their size by 'clica daemon' if it is running; otherwise a
+recycled instance is replaced in the background.`,
+	}
+
+	cmd.AddCommand(newPoolStatusCommand())
+	cmd.AddCommand(newPoolResizeCommand())
+	cmd.AddCommand(newPoolDrainCommand())
+	cmd.AddCommand(newPoolFillCommand())
+
+	return cmd
+}
+
+// poolStatus is the JSON form of one workspace's pool
+type poolStatus struct {
+	Workspace string               `json:"workspace"`
+	Size      int                  `json:"size"`
+	Members   []*global.PoolMember `json:"members"`
+}
+
+func newPoolStatusCommand() *cobra.Command {
+	var workspace string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show pool sizes and pool instances",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			filter := ""
+			if cmd.Flags().Changed("workspace") {
+				resolved, err := global.PoolWorkspace(workspace)
+				if err != nil {
+					return err
+				}
+				filter = resolved
+			}
+
+			cfg, err := global.LoadPoolConfig()
+			if err != nil {
+				return err
+			}
+			members, err := global.Clients.ListPoolMembers(cmd.Context(), filter)
+			if err != nil {
+				return err
+			}
+
+			pools := groupPoolMembers(cfg, members, filter)
+
+			if global.Config.OutputFormat == "json" {
+				status := struct {
+					DaemonRunning bool          `json:"daemon_running"`
+					MaxUses       int           `json:"max_uses"`
+					Pools         []*poolStatus `json:"pools"`
+				}{global.GetDaemonPID() != 0, cfg.MaxUses, pools}
+
+				data, err := json.MarshalIndent(status, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal pool status: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			if len(pools) == 0 {
+				fmt.Println("No pools. Create one with 'clica pool resize <size>'.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "WORKSPACE\tSIZE\tADDRESS\tSTATE\tUSES\tAGE\tCLAIMED BY")
+			for _, pool := range pools {
+				size := fmt.Sprintf("%d/%d", countLivePoolMembers(pool.Members), pool.Size)
+				if len(pool.Members) == 0 {
+					fmt.Fprintf(w, "%s\t%s\t-\t\t\t\t\n", pool.Workspace, size)
+					continue
+				}
+				for _, member := range pool.Members {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%d\t%s\t%s\n",
+						pool.Workspace,
+						size,
+						member.Address,
+						member.State,
+						member.Uses,
+						cfg.MaxUses,
+						time.Since(member.StartedAt).Round(time.Second),
+						member.ClaimedBy,
+					)
+				}
+			}
+			w.Flush()
+
+			if global.GetDaemonPID() == 0 {
+				fmt.Println()
+				fmt.Println(renderer.Dim("Daemon not running: pools are only refilled when an instance is recycled."))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&workspace, "workspace", "", "only show the pool of this workspace")
+
+	return cmd
+}
+
+func newPoolResizeCommand() *cobra.Command {
+	var workspace string
+	var maxUses int
+
+	cmd := &cobra.Command{
+		Use:   "resize <size>",
+		Short: "Set the number of warm instances for a workspace",
+		Long: `Set how many idle instances are kept ready for a workspace (the current directory
+unless --workspace is given), and start or stop instances to match.
+
+Shrinking stops idle instances only; claimed instances finish their task and are
+recycled when they are returned. A size of 0 removes the pool.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			size, err := strconv.Atoi(args[0])
+			if err != nil || size < 0 {
+				return fmt.Errorf("invalid pool size '%s': must be a non-negative number", args[0])
+			}
+
+			resolved, err := global.PoolWorkspace(workspace)
+			if err != nil {
+				return err
+			}
+
+			cfg, err := global.LoadPoolConfig()
+			if err != nil {
+				return err
+			}
+			cfg.Sizes[resolved] = size
+			if cmd.Flags().Changed("max-uses") {
+				if maxUses <= 0 {
+					return fmt.Errorf("--max-uses must be positive")
+				}
+				cfg.MaxUses = maxUses
+			}
+			if err := global.SavePoolConfig(cfg); err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			stopped, err := global.Clients.DrainPool(ctx, resolved, size)
+			if err != nil {
+				return err
+			}
+			if stopped > 0 {
+				fmt.Printf("Stopped %d idle pool instances\n", stopped)
+			}
+
+			if size > 0 {
+				fmt.Printf("Warming pool for %s...\n", resolved)
+			}
+			started, err := global.Clients.FillPool(ctx, resolved)
+			if err != nil {
+				return err
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Pool for %s resized to %d (%d started)", resolved, size, started)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&workspace, "workspace", ".", "workspace the pool serves")
+	cmd.Flags().IntVar(&maxUses, "max-uses", 0, "tasks a pool instance runs before it is recycled (applies to all pools)")
+
+	return cmd
+}
+
+func newPoolDrainCommand() *cobra.Command {
+	var workspace string
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "drain",
+		Short: "Remove a workspace's pool and stop its idle instances",
+		Long: `Remove the pool of a workspace (the current directory unless --workspace is given),
+or of every workspace with --all, and stop its idle instances. Claimed instances finish
+their task and are stopped when they are returned.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			cfg, err := global.LoadPoolConfig()
+			if err != nil {
+				return err
+			}
+
+			target := ""
+			if all {
+				cfg.Sizes = map[string]int{}
+			} else {
+				resolved, err := global.PoolWorkspace(workspace)
+				if err != nil {
+					return err
+				}
+				target = resolved
+				delete(cfg.Sizes, target)
+			}
+
+			// Save first, so the daemon doesn't refill what we are about to stop
+			if err := global.SavePoolConfig(cfg); err != nil {
+				return err
+			}
+
+			stopped, err := global.Clients.DrainPool(cmd.Context(), target, 0)
+			if err != nil {
+				return err
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Drained pool, stopped %d idle instances", stopped)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&workspace, "workspace", ".", "workspace whose pool to drain")
+	cmd.Flags().BoolVar(&all, "all", false, "drain the pools of all workspaces")
+
+	return cmd
+}
+
+// newPoolFillCommand refills a pool in the background after an instance is recycled
+func newPoolFillCommand() *cobra.Command {
+	var workspace string
+
+	cmd := &cobra.Command{
+		Use:    "fill",
+		Short:  "Start pool instances until the pool is full",
+		Hidden: true,
+		Args:   cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			resolved, err := global.PoolWorkspace(workspace)
+			if err != nil {
+				return err
+			}
+
+			_, err = global.Clients.FillPool(cmd.Context(), resolved)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&workspace, "workspace", ".", "workspace whose pool to fill")
+
+	return cmd
+}
+
+// groupPoolMembers arranges pool members by workspace, including configured pools without members
+func groupPoolMembers(cfg *global.PoolConfig, members []*global.PoolMember, filter string) []*poolStatus {
+	byWorkspace := map[string]*poolStatus{}
+	for workspace, size := range cfg.Sizes {
+		if filter == "" || workspace == filter {
+			byWorkspace[workspace] = &poolStatus{Workspace: workspace, Size: size, Members: []*global.PoolMember{}}
+		}
+	}
+	for _, member := range members {
+		pool := byWorkspace[member.Workspace]
+		if pool == nil {
+			// Left over from a drained pool, still finishing a task
+			pool = &poolStatus{Workspace: member.Workspace, Members: []*global.PoolMember{}}
+			byWorkspace[member.Workspace] = pool
+		}
+		pool.Members = append(pool.Members, member)
+	}
+
+	pools := make([]*poolStatus, 0, len(byWorkspace))
+	for _, pool := range byWorkspace {
+		pools = append(pools, pool)
+	}
+	sort.Slice(pools, func(i, j int) bool {
+		return pools[i].Workspace < pools[j].Workspace
+	})
+	return pools
+}
+
+// countLivePoolMembers counts the pool members whose core is running
+func countLivePoolMembers(members []*global.PoolMember) int {
+	count := 0
+	for _, member := range members {
+		if member.State != global.PoolDead {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/config"
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/spf13/cobra"
+)
+
+func NewProfileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "profile",
+		Aliases: []string{"p"},
+		Short:   "Manage named configuration profiles",
+		Long: `Save and switch between named sets of provider, model, auto-approval and browser settings.
+
+Profiles are stored in ~/.clica/data/profiles and may contain API keys.
+
+Examples:
+  clica profile create bedrock-prod
+  clica profile create local -s plan-mode-api-provider=ollama -s act-mode-api-provider=ollama
+  clica profile use bedrock-prod
+  clica --profile bedrock-prod "Fix the failing tests"`,
+	}
+
+	cmd.AddCommand(newProfileCreateCommand())
+	cmd.AddCommand(newProfileUseCommand())
+	cmd.AddCommand(newProfileListCommand())
+	cmd.AddCommand(newProfileDeleteCommand())
+	cmd.AddCommand(newProfileExportCommand())
+	cmd.AddCommand(newProfileImportCommand())
+
+	return cmd
+}
+
+func newProfileCreateCommand() *cobra.Command {
+	var (
+		address  string
+		settings []string
+		empty    bool
+		force    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "create <name>",
+		Aliases: []string{"c", "save"},
+		Short:   "Create a profile from the current configuration",
+		Long:    `Snapshot the current provider, model, auto-approval and browser settings into a named profile. Settings passed with -s override the snapshot.`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			name := args[0]
+
+			if err := config.ValidateProfileName(name); err != nil {
+				return err
+			}
+			if config.ProfileExists(name) && !force {
+				return fmt.Errorf("profile '%s' already exists (use --force to overwrite)", name)
+			}
+
+			profile := &config.Profile{Name: name, CreatedAt: time.Now()}
+
+			if !empty {
+				if err := ensureConfigManager(ctx, address); err != nil {
+					return err
+				}
+
+				snapshot, err := configManager.SnapshotProfile(ctx, name)
+				if err != nil {
+					return fmt.Errorf("failed to snapshot configuration: %w", err)
+				}
+				profile = snapshot
+			}
+
+			profile.Settings = mergeProfileSettings(profile.Settings, settings)
+
+			if err := config.SaveProfile(profile); err != nil {
+				return err
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Profile '%s' saved (%d settings)", name, len(profile.Settings))))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to snapshot")
+	cmd.Flags().StringSliceVarP(&settings, "setting", "s", nil, "additional settings (key=value format)")
+	cmd.Flags().BoolVar(&empty, "empty", false, "don't snapshot the current configuration, only use -s settings")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing profile")
+
+	return cmd
+}
+
+func newProfileUseCommand() *cobra.Command {
+	var address string
+
+	cmd := &cobra.Command{
+		Use:     "use <name>",
+		Aliases: []string{"u"},
+		Short:   "Apply a profile to an instance",
+		Long:    `Apply a profile's settings to the default instance (or the one given with --address).`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			profile, err := config.LoadProfile(args[0])
+			if err != nil {
+				return err
+			}
+
+			if err := ensureConfigManager(ctx, address); err != nil {
+				return err
+			}
+
+			if err := configManager.ApplyProfile(ctx, profile); err != nil {
+				return err
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Profile '%s' applied to %s", profile.Name, configManager.GetCurrentInstance())))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to use")
+
+	return cmd
+}
+
+func newProfileListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"l", "ls"},
+		Short:   "List saved profiles",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profiles, err := config.ListProfiles()
+			if err != nil {
+				return err
+			}
+
+			if global.Config.OutputFormat == "json" {
+				// Never print secrets in listings
+				stripped := make([]*config.Profile, len(profiles))
+				for i, profile := range profiles {
+					stripped[i] = profile.WithoutSecrets()
+				}
+				data, err := json.MarshalIndent(stripped, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal profiles: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(profiles) == 0 {
+				fmt.Println("No profiles found. Create one with 'clica profile create <name>'.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "NAME\tPLAN PROVIDER\tACT PROVIDER\tSETTINGS\tCREATED")
+			for _, profile := range profiles {
+				planProvider, _ := profile.GetSetting("plan-mode-api-provider")
+				actProvider, _ := profile.GetSetting("act-mode-api-provider")
+				fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+					profile.Name,
+					valueOrDash(planProvider),
+					valueOrDash(actProvider),
+					len(profile.Settings),
+					profile.CreatedAt.Format("2006-01-02 15:04"),
+				)
+			}
+			w.Flush()
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newProfileDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete <name>",
+		Aliases: []string{"d", "rm"},
+		Short:   "Delete a saved profile",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.DeleteProfile(args[0]); err != nil {
+				return err
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Profile '%s' deleted", args[0])))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newProfileExportCommand() *cobra.Command {
+	var (
+		outputPath string
+		noSecrets  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "export <name>",
+		Aliases: []string{"e"},
+		Short:   "Export a profile as JSON",
+		Long:    `Export a profile as JSON to stdout or a file. Use --no-secrets to strip API keys before sharing.`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := config.LoadProfile(args[0])
+			if err != nil {
+				return err
+			}
+
+			if noSecrets {
+				profile = profile.WithoutSecrets()
+			}
+
+			data, err := json.MarshalIndent(profile, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal profile: %w", err)
+			}
+
+			if outputPath == "" {
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if err := os.WriteFile(outputPath, data, 0600); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outputPath, err)
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Profile '%s' exported to %s", profile.Name, outputPath)))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "file to write the profile to (defaults to stdout)")
+	cmd.Flags().BoolVar(&noSecrets, "no-secrets", false, "strip API keys and other secrets")
+
+	return cmd
+}
+
+func newProfileImportCommand() *cobra.Command {
+	var (
+		name  string
+		force bool
+	)
+
+	cmd := &cobra.Command{
+		Use:     "import <file>",
+		Aliases: []string{"i"},
+		Short:   "Import a profile from a JSON file",
+		Long:    `Import a profile previously written by 'clica profile export'. Use - to read from stdin.`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", args[0], err)
+			}
+
+			profile, err := config.ParseProfile(data)
+			if err != nil {
+				return err
+			}
+
+			if name != "" {
+				profile.Name = name
+			}
+			if profile.Name == "" {
+				return fmt.Errorf("profile has no name, use --name to set one")
+			}
+			if profile.CreatedAt.IsZero() {
+				profile.CreatedAt = time.Now()
+			}
+
+			if config.ProfileExists(profile.Name) && !force {
+				return fmt.Errorf("profile '%s' already exists (use --force to overwrite)", profile.Name)
+			}
+
+			if err := config.SaveProfile(profile); err != nil {
+				return err
+			}
+
+			renderer := display.NewRenderer(global.Config.OutputFormat)
+			fmt.Println(renderer.SuccessWithCheckmark(fmt.Sprintf("Profile '%s' imported (%d settings)", profile.Name, len(profile.Settings))))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", "", "name to save the profile as (defaults to the name in the file)")
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing profile")
+
+	return cmd
+}
+
+// ApplyProfileToInstance loads a named profile and applies it to the instance at address.
+// Used by the root --profile flag after the instance has been started.
+func ApplyProfileToInstance(ctx context.Context, name, address string) error {
+	profile, err := config.LoadProfile(name)
+	if err != nil {
+		return err
+	}
+
+	manager, err := config.NewManager(ctx, address)
+	if err != nil {
+		return fmt.Errorf("failed to create config manager: %w", err)
+	}
+
+	return manager.ApplyProfile(ctx, profile)
+}
+
+// mergeProfileSettings overlays key=value overrides onto base settings, replacing matching keys
+func mergeProfileSettings(base, overrides []string) []string {
+	normalize := func(setting string) string {
+		key, _, _ := strings.Cut(setting, "=")
+		return strings.ReplaceAll(strings.TrimSpace(key), "_", "-")
+	}
+
+	overridden := make(map[string]bool, len(overrides))
+	for _, setting := range overrides {
+		overridden[normalize(setting)] = true
+	}
+
+	merged := make([]string, 0, len(base)+len(overrides))
+	for _, setting := range base {
+		if !overridden[normalize(setting)] {
+			merged = append(merged, setting)
+		}
+	}
+	return append(merged, overrides...)
+}
+
+// valueOrDash returns "-" for empty strings in table output
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
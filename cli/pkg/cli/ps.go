@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/clica/cli/pkg/common"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// taskPromptWidth limits the prompt column of 'clica ps'
+const taskPromptWidth = 50
+
+// runningTask is a task found on an instance by 'clica ps'
+type runningTask struct {
+	Address string `json:"address"`
+	Name    string `json:"name,omitempty"`
+	*task.TaskStatus
+}
+
+func NewPsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ps",
+		Short: "List tasks running on Clica instances",
+		Long: `List the current task of every running instance and what it is doing:
+streaming, waiting_approval, waiting_input, failed or completed.
+
+Reattach to one with 'clica attach <task-id|instance>'.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if global.Clients == nil {
+				return fmt.Errorf("clients not initialized")
+			}
+
+			tasks, err := listRunningTasks(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if global.Config.OutputFormat == "json" {
+				if tasks == nil {
+					tasks = []*runningTask{}
+				}
+				data, err := json.MarshalIndent(tasks, "", "  ")
+				if err != nil {
+					return fmt.Errorf("failed to marshal tasks: %w", err)
+				}
+				fmt.Println(string(data))
+				return nil
+			}
+
+			if len(tasks) == 0 {
+				fmt.Println("No tasks running.")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "TASK ID\tINSTANCE\tSTATUS\tLAST ACTIVITY\tTASK")
+			for _, t := range tasks {
+				instance := t.Address
+				if t.Name != "" {
+					instance = t.Name
+				}
+				lastActivity := "-"
+				if !t.LastActivity.IsZero() {
+					lastActivity = time.Since(t.LastActivity).Round(time.Second).String() + " ago"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+					t.TaskID,
+					instance,
+					t.Status,
+					lastActivity,
+					truncatePrompt(t.Task, taskPromptWidth),
+				)
+			}
+			w.Flush()
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// listRunningTasks returns the current task of every serving instance, most recently active first
+func listRunningTasks(ctx context.Context) ([]*runningTask, error) {
+	registry := global.Clients.GetRegistry()
+
+	instances, err := registry.ListInstancesCleaned(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list instances: %w", err)
+	}
+	metadata := registry.ListInstanceMetadata()
+
+	var (
+		mu    sync.Mutex
+		tasks []*runningTask
+		wg    sync.WaitGroup
+	)
+	sem := make(chan struct{}, instanceListConcurrency)
+	for _, instance := range instances {
+		if instance.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+			continue
+		}
+
+		wg.Add(1)
+		go func(instance *common.CoreInstanceInfo) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			stateJSON, err := global.FetchInstanceState(ctx, instance.Address)
+			if err != nil {
+				return
+			}
+			status, err := task.TaskStatusFromState(stateJSON)
+			if err != nil || status == nil {
+				return
+			}
+
+			t := &runningTask{Address: instance.Address, TaskStatus: status}
+			if meta, ok := metadata[instance.Address]; ok {
+				t.Name = meta.Name
+			}
+
+			mu.Lock()
+			tasks = append(tasks, t)
+			mu.Unlock()
+		}(instance)
+	}
+	wg.Wait()
+
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].LastActivity.After(tasks[j].LastActivity)
+	})
+	return tasks, nil
+}
+
+// truncatePrompt shortens a task prompt to one line of at most width characters
+func truncatePrompt(prompt string, width int) string {
+	prompt = strings.Join(strings.Fields(prompt), " ")
+	runes := []rune(prompt)
+	if len(runes) <= width {
+		return prompt
+	}
+	return string(runes[:width-3]) + "..."
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/task"
+	"github.com/spf13/cobra"
+)
+
+// RecordFlagUsage describes --record, which the commands that stream a task take
+const RecordFlagUsage = "record the session's stream updates to a file for 'clica replay'"
+
+// replayMaxDelay caps the pause between two replayed frames, such as a long wait on the model
+const replayMaxDelay = 5 * time.Second
+
+func NewReplayCommand() *cobra.Command {
+	var speed string
+
+	cmd := &cobra.Command{
+		Use:   "replay <recording>",
+		Short: "Replay a session recorded with --record",
+		Long: `Replay a session recording (.clrec) written with 'clica --record <file>'.
+
+The recorded state and message updates are rendered exactly as they were in
+the live session, without a Clica instance or a model. Use it to reproduce
+rendering bugs, demo a session, or as fixtures for display tests.
+
+--speed scales the recorded timing: 2x is twice as fast, 0.5x half as fast,
+and "max" replays without delays. Pauses longer than 5s are shortened to 5s.`,
+		Example: `  clica --record session.clrec "Add pagination to the API"
+  clica replay session.clrec
+  clica replay session.clrec --speed 4x
+  clica replay session.clrec --speed max -F plain > session.txt`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			factor, err := parseReplaySpeed(speed)
+			if err != nil {
+				return err
+			}
+
+			header, frames, err := task.ReadRecording(args[0])
+			if err != nil {
+				return err
+			}
+
+			if global.Config.OutputFormat != "json" {
+				fmt.Printf("Replaying session recorded %s", header.StartedAt.Local().Format("2006-01-02 15:04"))
+				if header.Instance != "" {
+					fmt.Printf(" on %s", header.Instance)
+				}
+				fmt.Printf(" (%d frames)\n", len(frames))
+			}
+
+			// Stop cleanly on Ctrl+C so a streaming message is finished rather than cut off
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			manager := task.NewManager(nil)
+			return manager.Replay(ctx, frames, task.ReplayOptions{Speed: factor, MaxDelay: replayMaxDelay})
+		},
+	}
+
+	cmd.Flags().StringVar(&speed, "speed", "1x", "playback speed, e.g. 2x, 0.5x or max")
+
+	return cmd
+}
+
+// parseReplaySpeed parses a speed such as "4x", "0.5" or "max". Returns 0 for no delays.
+func parseReplaySpeed(speed string) (float64, error) {
+	speed = strings.ToLower(strings.TrimSpace(speed))
+	if speed == "max" {
+		return 0, nil
+	}
+
+	// max is the only way to replay without delays: 0x, negative factors, NaN and Inf are errors
+	factor, err := strconv.ParseFloat(strings.TrimSuffix(speed, "x"), 64)
+	if err != nil || !(factor > 0) || math.IsInf(factor, 0) {
+		return 0, fmt.Errorf("invalid speed '%s': expected a factor such as 2x or 0.5x, or max", speed)
+	}
+	return factor, nil
+}
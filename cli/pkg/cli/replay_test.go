@@ -0,0 +1,17 @@
+package cli
+
+import "testing"
+
+func TestParseReplaySpeed(t *testing.T) {
+	for speed, want := range map[string]float64{"max": 0, "MAX": 0, "2x": 2, "0.5x": 0.5, "3": 3} {
+		if got, err := parseReplaySpeed(speed); err != nil || got != want {
+			t.Errorf("got %v, %v for %q, want %v", got, err, speed, want)
+		}
+	}
+
+	for _, speed := range []string{"0x", "0", "-2x", "NaN", "nanx", "inf", "fast"} {
+		if got, err := parseReplaySpeed(speed); err == nil {
+			t.Errorf("got %v for %q, want an error", got, speed)
+		}
+	}
+}
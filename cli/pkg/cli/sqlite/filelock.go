@@ -0,0 +1,306 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/clica/cli/pkg/common"
+)
+
+// File lock tuning
+const (
+	DefaultFileLockTTL  = 30 * time.Second       // lease of a file lock; holders renew it every third of this
+	defaultFileLockWait = 10 * time.Second       // how long WithFileLock waits for a busy lock
+	lockRetryMin        = 25 * time.Millisecond  // first delay between acquire attempts
+	lockRetryMax        = 500 * time.Millisecond // upper bound for the delay between acquire attempts
+	lockWaiterTTL       = 5 * time.Second        // queued waiters that stop heartbeating for this long are dropped
+)
+
+// processLockHolderPrefix identifies file locks held by CLI processes: cli-process-<pid>
+const processLockHolderPrefix = "cli-process-"
+
+// FileLock is a held file lock. Its lease is renewed in the background until Release is called.
+type FileLock struct {
+	lm     *LockManager
+	target string
+	heldBy string
+
+	stop        chan struct{}
+	done        chan struct{}
+	lost        atomic.Bool
+	releaseOnce sync.Once
+}
+
+// ProcessLockHolder returns the holder id this process uses for file locks
+func ProcessLockHolder() string {
+	return fmt.Sprintf("%s%d", processLockHolderPrefix, os.Getpid())
+}
+
+// LockHolderPID returns the PID of a cli-process-<pid> lock holder
+func LockHolderPID(heldBy string) (int, bool) {
+	rest, ok := strings.CutPrefix(heldBy, processLockHolderPrefix)
+	if !ok {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(rest)
+	if err != nil || pid <= 0 {
+		return 0, false
+	}
+	return pid, true
+}
+
+// AcquireFileLock waits until it holds the file lock on filePath, or ctx is done.
+// Contenders are served in the order they started waiting. A lock whose lease ran out,
+// or whose holder process or instance is gone, is taken over. ttl <= 0 uses DefaultFileLockTTL.
+func (lm *LockManager) AcquireFileLock(ctx context.Context, filePath, heldBy string, ttl time.Duration) (*FileLock, error) {
+	if err := lm.ensureConnection(); err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultFileLockTTL
+	}
+
+	var waiterID int64
+	defer func() {
+		if waiterID != 0 {
+			lm.db.Exec(common.DeleteLockWaiterSQL, waiterID)
+		}
+	}()
+
+	delay := lockRetryMin
+	for {
+		acquired, err := lm.tryAcquireFileLock(filePath, heldBy, ttl, waiterID)
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			return lm.startLease(filePath, heldBy, ttl), nil
+		}
+
+		// Join the queue on the first failed attempt, then keep our place in it
+		now := time.Now().UnixMilli()
+		if waiterID == 0 {
+			result, err := lm.db.Exec(common.InsertLockWaiterSQL, filePath, heldBy, now)
+			if err != nil {
+				return nil, fmt.Errorf("failed to queue for file lock on %s: %w", filePath, err)
+			}
+			waiterID, _ = result.LastInsertId()
+		} else {
+			lm.db.Exec(common.TouchLockWaiterSQL, now, waiterID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for file lock on %s: %w", filePath, ctx.Err())
+		case <-time.After(delay/2 + rand.N(delay/2+1)):
+		}
+		delay = min(delay*2, lockRetryMax)
+	}
+}
+
+// TryAcquireFileLock takes the file lock on filePath if it is free (or stale) and nobody is
+// waiting for it. Returns nil without an error if the lock is busy.
+func (lm *LockManager) TryAcquireFileLock(filePath, heldBy string, ttl time.Duration) (*FileLock, error) {
+	if err := lm.ensureConnection(); err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultFileLockTTL
+	}
+
+	acquired, err := lm.tryAcquireFileLock(filePath, heldBy, ttl, 0)
+	if err != nil || !acquired {
+		return nil, err
+	}
+	return lm.startLease(filePath, heldBy, ttl), nil
+}
+
+// GetFileLock returns the file lock on filePath, or nil if it isn't held
+func (lm *LockManager) GetFileLock(filePath string) (*common.LockRow, error) {
+	if lm.db == nil {
+		return nil, nil
+	}
+
+	var lock common.LockRow
+	err := lm.db.QueryRow(common.SelectFileLockSQL, filePath).Scan(&lock.ID, &lock.HeldBy, &lock.LockType, &lock.LockTarget, &lock.LockedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file lock on %s: %w", filePath, err)
+	}
+	return &lock, nil
+}
+
+// tryAcquireFileLock makes one attempt at taking the lock. Only the head of the wait queue
+// (or anyone, if nobody is queued) may take it.
+func (lm *LockManager) tryAcquireFileLock(filePath, heldBy string, ttl time.Duration, waiterID int64) (bool, error) {
+	now := time.Now()
+	waiterCutoff := now.Add(-lockWaiterTTL).UnixMilli()
+
+	if _, err := lm.db.Exec(common.DeleteExpiredLockWaitersSQL, waiterCutoff); err != nil {
+		return false, fmt.Errorf("failed to expire lock waiters: %w", err)
+	}
+
+	var head int64
+	err := lm.db.QueryRow(common.SelectLockQueueHeadSQL, filePath, waiterCutoff).Scan(&head)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return false, fmt.Errorf("failed to read lock queue: %w", err)
+	}
+	if head != 0 && head != waiterID {
+		return false, nil
+	}
+
+	var current common.LockRow
+	err = lm.db.QueryRow(common.SelectFileLockSQL, filePath).Scan(&current.ID, &current.HeldBy, &current.LockType, &current.LockTarget, &current.LockedAt)
+	switch {
+	case err == nil:
+		if !lm.fileLockStale(current, ttl, now) {
+			return false, nil
+		}
+		// Only deletes the row if nobody renewed or re-took it in the meantime
+		if _, err := lm.db.Exec(common.DeleteFileLockIfUnchangedSQL, current.ID, current.HeldBy, current.LockedAt); err != nil {
+			return false, fmt.Errorf("failed to take over stale file lock on %s: %w", filePath, err)
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return false, fmt.Errorf("failed to read file lock on %s: %w", filePath, err)
+	}
+
+	result, err := lm.db.Exec(common.InsertFileLockSQL, heldBy, filePath, now.UnixMilli())
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire file lock for %s: %w", filePath, err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire file lock for %s: %w", filePath, err)
+	}
+
+	return inserted == 1, nil
+}
+
+// fileLockStale reports whether a held lock can be taken over: its lease ran out, or its
+// holder (a CLI process or an instance) no longer exists
+func (lm *LockManager) fileLockStale(lock common.LockRow, ttl time.Duration, now time.Time) bool {
+	if now.Sub(time.UnixMilli(lock.LockedAt)) > ttl {
+		return true
+	}
+	if pid, ok := LockHolderPID(lock.HeldBy); ok {
+		return !pidAlive(pid)
+	}
+	if _, _, err := common.ParseHostPort(lock.HeldBy); err == nil {
+		exists, err := lm.HasInstanceAtAddress(lock.HeldBy)
+		return err == nil && !exists
+	}
+	return false
+}
+
+// startLease starts renewing a freshly acquired lock
+func (lm *LockManager) startLease(filePath, heldBy string, ttl time.Duration) *FileLock {
+	lock := &FileLock{
+		lm:     lm,
+		target: filePath,
+		heldBy: heldBy,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(lock.done)
+
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-lock.stop:
+				return
+			case <-ticker.C:
+			}
+
+			result, err := lm.db.Exec(common.RenewFileLockSQL, time.Now().UnixMilli(), heldBy, filePath)
+			if err != nil {
+				// Transient; the lease has two more chances before it runs out
+				continue
+			}
+			if renewed, err := result.RowsAffected(); err == nil && renewed == 0 {
+				lock.lost.Store(true)
+				return
+			}
+		}
+	}()
+
+	return lock
+}
+
+// Lost reports whether the lock was taken over because its lease couldn't be renewed
+func (l *FileLock) Lost() bool {
+	return l.lost.Load()
+}
+
+// Release stops renewing the lease and releases the lock
+func (l *FileLock) Release() error {
+	var err error
+	l.releaseOnce.Do(func() {
+		close(l.stop)
+		<-l.done
+		err = l.lm.ReleaseFileLock(l.target, l.heldBy)
+	})
+	return err
+}
+
+// ReleaseFileLock releases a file lock
+func (lm *LockManager) ReleaseFileLock(filePath, heldBy string) error {
+	if lm.db == nil {
+		return nil
+	}
+
+	query := common.DeleteFileLockSQL
+
+	_, err := lm.db.Exec(query, heldBy, filePath)
+	if err != nil {
+		return fmt.Errorf("failed to release file lock for %s: %w", filePath, err)
+	}
+
+	return nil
+}
+
+// WithFileLock executes a function while holding a file lock, waiting up to
+// defaultFileLockWait for the lock
+func (lm *LockManager) WithFileLock(filePath, heldBy string, fn func() error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultFileLockWait)
+	defer cancel()
+
+	return lm.WithFileLockContext(ctx, filePath, heldBy, fn)
+}
+
+// WithFileLockContext executes a function while holding a file lock, waiting for the lock until ctx is done
+func (lm *LockManager) WithFileLockContext(ctx context.Context, filePath, heldBy string, fn func() error) error {
+	lock, err := lm.AcquireFileLock(ctx, filePath, heldBy, DefaultFileLockTTL)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if releaseErr := lock.Release(); releaseErr != nil {
+			fmt.Printf("Warning: Failed to release file lock for %s: %v\n", filePath, releaseErr)
+		}
+	}()
+
+	return fn()
+}
+
+// pidAlive reports whether a process with the given PID exists
+func pidAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
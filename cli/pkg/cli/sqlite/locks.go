@@ -8,6 +8,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/clica/cli/pkg/common"
@@ -36,6 +37,9 @@ func normalizeAddressVariants(address string) []string {
 	return variants
 }
 
+// lockDBOptions makes writers wait for each other instead of failing with "database is locked"
+const lockDBOptions = "?_pragma=busy_timeout(5000)"
+
 // LockManager provides access to the SQLite locks database
 type LockManager struct {
 	dbPath string
@@ -59,20 +63,13 @@ func NewLockManager(clineDir string) (*LockManager, error) {
 		return &LockManager{dbPath: dbPath, db: nil}, nil
 	}
 
-	// Database exists - open it normally (no schema creation)
-	db, err := sql.Open("sqlite", dbPath)
+	// Database exists - open it normally
+	db, err := openLocksDB(dbPath)
 	if err != nil {
 		// If we can't open existing database, return nil db manager
 		return &LockManager{dbPath: dbPath, db: nil}, nil
 	}
 
-	// Test the connection
-	if err := db.Ping(); err != nil {
-		db.Close()
-		// If connection fails, return nil db manager
-		return &LockManager{dbPath: dbPath, db: nil}, nil
-	}
-
 	return &LockManager{
 		dbPath: dbPath,
 		db:     db,
@@ -92,19 +89,35 @@ func (lm *LockManager) ensureConnection() error {
 	}
 
 	// Database exists, try to connect
-	db, err := sql.Open("sqlite", lm.dbPath)
+	db, err := openLocksDB(lm.dbPath)
+	if err != nil {
+		return err
+	}
+
+	// Success! Update our connection permanently
+	lm.db = db
+	return nil
+}
+
+// openLocksDB connects to the locks database clica-core created and adds the tables the
+// CLI owns. The locks table itself is left to clica-core.
+func openLocksDB(dbPath string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", dbPath+lockDBOptions)
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	if err := db.Ping(); err != nil {
 		db.Close()
-		return fmt.Errorf("database connection failed: %w", err)
+		return nil, fmt.Errorf("database connection failed: %w", err)
 	}
 
-	// Success! Update our connection permanently
-	lm.db = db
-	return nil
+	if _, err := db.Exec(common.CreateLockWaitersTableSQL); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create lock waiters table: %w", err)
+	}
+
+	return db, nil
 }
 
 // Close closes the database connection
@@ -217,7 +230,18 @@ func (lm *LockManager) GetInstanceInfo(address string) (*common.CoreInstanceInfo
 	return nil, fmt.Errorf("instance %s not found", address)
 }
 
-// ListInstancesWithHealthCheck returns all instances with real-time health checks
+// Health check tuning for ListInstancesWithHealthCheck
+const (
+	healthCheckConcurrency  = 8               // maximum probes in flight at once
+	healthCheckProbeTimeout = 2 * time.Second // deadline for a single probe
+	healthCheckRetryDelay   = 1 * time.Second // wait before re-probing an unhealthy instance
+)
+
+// ListInstancesWithHealthCheck returns all instances with real-time health checks.
+// Instances are probed concurrently with a bounded worker pool. If ctx expires before
+// every instance has a definitive result, the instances checked so far are returned;
+// unchecked instances are left out rather than reported as unhealthy, so callers that
+// clean up stale entries never remove an instance that simply wasn't reached in time.
 func (lm *LockManager) ListInstancesWithHealthCheck(ctx context.Context) ([]*common.CoreInstanceInfo, error) {
 	if err := lm.ensureConnection(); err != nil {
 		return []*common.CoreInstanceInfo{}, nil
@@ -229,27 +253,76 @@ func (lm *LockManager) ListInstancesWithHealthCheck(ctx context.Context) ([]*com
 		return nil, fmt.Errorf("failed to get instance locks: %w", err)
 	}
 
-	var instances []*common.CoreInstanceInfo
-
-	for _, lock := range locks {
-		// Create instance info using actual SQLite data
-		status, err := common.PerformHealthCheck(ctx, lock.HeldBy)
-		if status != grpc_health_v1.HealthCheckResponse_SERVING || err != nil {
-			time.Sleep(1 * time.Second)
-			status, err = common.PerformHealthCheck(ctx, lock.HeldBy)
+	results := make([]*common.CoreInstanceInfo, len(locks))
+	sem := make(chan struct{}, healthCheckConcurrency)
+	var wg sync.WaitGroup
+
+	for i, lock := range locks {
+		wg.Add(1)
+		go func(i int, lock common.LockRow) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			status, ok := checkInstanceHealth(ctx, lock.HeldBy)
+			if !ok {
+				return
+			}
+
+			// Create instance info using actual SQLite data
+			results[i] = &common.CoreInstanceInfo{
+				Address:            lock.HeldBy,
+				HostServiceAddress: lock.LockTarget,
+				Status:             status,
+				LastSeen:           time.Unix(lock.LockedAt/1000, 0),
+			}
+		}(i, lock)
+	}
+	wg.Wait()
+
+	// Keep lock order, skipping instances that weren't checked before ctx expired
+	instances := make([]*common.CoreInstanceInfo, 0, len(results))
+	for _, info := range results {
+		if info != nil {
+			instances = append(instances, info)
 		}
+	}
 
-		info := &common.CoreInstanceInfo{
-			Address:            lock.HeldBy,
-			HostServiceAddress: lock.LockTarget,
-			Status:             status,
-			LastSeen:           time.Unix(lock.LockedAt/1000, 0),
-		}
+	return instances, nil
+}
 
-		instances = append(instances, info)
+// checkInstanceHealth probes an instance, re-probing once after a short delay if it
+// isn't serving (a core that just started may not be ready yet). Returns false if
+// ctx expired before a definitive result was available.
+func checkInstanceHealth(ctx context.Context, address string) (grpc_health_v1.HealthCheckResponse_ServingStatus, bool) {
+	status, err := probeInstanceHealth(ctx, address)
+	if err == nil && status == grpc_health_v1.HealthCheckResponse_SERVING {
+		return status, true
 	}
 
-	return instances, nil
+	select {
+	case <-time.After(healthCheckRetryDelay):
+	case <-ctx.Done():
+		return grpc_health_v1.HealthCheckResponse_UNKNOWN, false
+	}
+
+	status, err = probeInstanceHealth(ctx, address)
+	if err != nil && ctx.Err() != nil {
+		return grpc_health_v1.HealthCheckResponse_UNKNOWN, false
+	}
+	return status, true
+}
+
+// probeInstanceHealth performs a single health check bounded by healthCheckProbeTimeout
+func probeInstanceHealth(ctx context.Context, address string) (grpc_health_v1.HealthCheckResponse_ServingStatus, error) {
+	probeCtx, cancel := context.WithTimeout(ctx, healthCheckProbeTimeout)
+	defer cancel()
+	return common.PerformHealthCheck(probeCtx, address)
 }
 
 // GetDefaultInstance reads the default instance from the settings file
@@ -288,7 +361,7 @@ func SetDefaultInstance(clineDir, address string) error {
 	settingsPath := filepath.Join(clineDir, common.SETTINGS_SUBFOLDER, "settings", "cli-default-instance.json")
 
 	// Generate a unique identifier for this CLI process
-	heldBy := fmt.Sprintf("cli-process-%d", os.Getpid())
+	heldBy := ProcessLockHolder()
 
 	// Use file lock for the write operation
 	return lockManager.WithFileLock(settingsPath, heldBy, func() error {
@@ -321,51 +394,39 @@ func writeDefaultInstanceJSONToDisk(clineDir, address string) error {
 	return nil
 }
 
-// AcquireFileLock attempts to acquire a file lock
-func (lm *LockManager) AcquireFileLock(filePath, heldBy string) error {
+// GetHeldLocks returns all file and folder locks
+func (lm *LockManager) GetHeldLocks() ([]common.LockRow, error) {
 	if err := lm.ensureConnection(); err != nil {
-		return err
+		return []common.LockRow{}, nil
 	}
 
-	now := time.Now().Unix() * 1000 // Convert to milliseconds
-
-	query := common.InsertFileLockSQL
-
-	_, err := lm.db.Exec(query, heldBy, filePath, now)
+	rows, err := lm.db.Query(common.SelectHeldLocksSQL)
 	if err != nil {
-		return fmt.Errorf("failed to acquire file lock for %s: %w", filePath, err)
+		return nil, fmt.Errorf("failed to query file locks: %w", err)
 	}
+	defer rows.Close()
 
-	return nil
-}
-
-// ReleaseFileLock releases a file lock
-func (lm *LockManager) ReleaseFileLock(filePath, heldBy string) error {
-	if lm.db == nil {
-		return nil
-	}
-
-	query := common.DeleteFileLockSQL
-
-	_, err := lm.db.Exec(query, heldBy, filePath)
-	if err != nil {
-		return fmt.Errorf("failed to release file lock for %s: %w", filePath, err)
+	var locks []common.LockRow
+	for rows.Next() {
+		var lock common.LockRow
+		if err := rows.Scan(&lock.ID, &lock.HeldBy, &lock.LockType, &lock.LockTarget, &lock.LockedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan lock row: %w", err)
+		}
+		locks = append(locks, lock)
 	}
 
-	return nil
+	return locks, rows.Err()
 }
 
-// WithFileLock executes a function while holding a file lock
-func (lm *LockManager) WithFileLock(filePath, heldBy string, fn func() error) error {
-	if err := lm.AcquireFileLock(filePath, heldBy); err != nil {
-		return err
+// RemoveLock deletes a lock row by id, whoever holds it
+func (lm *LockManager) RemoveLock(id int64) error {
+	if err := lm.ensureConnection(); err != nil {
+		return nil // Gracefully handle missing database for cleanup operations
 	}
 
-	defer func() {
-		if releaseErr := lm.ReleaseFileLock(filePath, heldBy); releaseErr != nil {
-			fmt.Printf("Warning: Failed to release file lock for %s: %v\n", filePath, releaseErr)
-		}
-	}()
+	if _, err := lm.db.Exec(common.DeleteLockByIDSQL, id); err != nil {
+		return fmt.Errorf("failed to remove lock %d: %w", id, err)
+	}
 
-	return fn()
+	return nil
 }
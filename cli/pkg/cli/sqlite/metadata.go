@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/clica/cli/pkg/common"
+)
+
+// ensureMetadataTable creates the instance_metadata table if it doesn't exist yet.
+// Unlike the locks table this one is owned by the CLI, so it is created on demand.
+func (lm *LockManager) ensureMetadataTable() error {
+	if err := lm.ensureConnection(); err != nil {
+		return err
+	}
+
+	if _, err := lm.db.Exec(common.CreateInstanceMetadataTableSQL); err != nil {
+		return fmt.Errorf("failed to create instance metadata table: %w", err)
+	}
+
+	return nil
+}
+
+// SetInstanceMetadata stores the name, workspace and labels of an instance, replacing
+// any previous metadata for its address. Names must be unique among running instances.
+func (lm *LockManager) SetInstanceMetadata(meta common.InstanceMetadata) error {
+	if err := lm.ensureMetadataTable(); err != nil {
+		return err
+	}
+
+	if meta.Name != "" {
+		existing, err := lm.ListInstanceMetadata()
+		if err != nil {
+			return err
+		}
+		for _, other := range existing {
+			if other.Name == meta.Name && other.Address != meta.Address {
+				return fmt.Errorf("name '%s' is already used by instance %s", meta.Name, other.Address)
+			}
+		}
+	}
+
+	if meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	labels, err := json.Marshal(meta.Labels)
+	if err != nil {
+		return fmt.Errorf("failed to marshal labels: %w", err)
+	}
+
+	if meta.CreatedAt == 0 {
+		meta.CreatedAt = time.Now().Unix() * 1000
+	}
+
+	_, err = lm.db.Exec(common.UpsertInstanceMetadataSQL, meta.Address, meta.Name, meta.Workspace, string(labels), meta.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save instance metadata: %w", err)
+	}
+
+	return nil
+}
+
+// ListInstanceMetadata returns the metadata of all instances that still hold an instance lock.
+// Metadata left behind by instances that are gone is ignored.
+func (lm *LockManager) ListInstanceMetadata() ([]common.InstanceMetadata, error) {
+	if err := lm.ensureMetadataTable(); err != nil {
+		return []common.InstanceMetadata{}, nil
+	}
+
+	rows, err := lm.db.Query(common.SelectLiveInstanceMetadataSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query instance metadata: %w", err)
+	}
+	defer rows.Close()
+
+	var metadata []common.InstanceMetadata
+	for rows.Next() {
+		var meta common.InstanceMetadata
+		var labels string
+		if err := rows.Scan(&meta.Address, &meta.Name, &meta.Workspace, &labels, &meta.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan instance metadata row: %w", err)
+		}
+		if err := json.Unmarshal([]byte(labels), &meta.Labels); err != nil {
+			return nil, fmt.Errorf("failed to parse labels of instance %s: %w", meta.Address, err)
+		}
+		metadata = append(metadata, meta)
+	}
+
+	return metadata, rows.Err()
+}
+
+// GetInstanceMetadata returns the metadata of a running instance, or nil if it has none.
+// Handles localhost/127.0.0.1 equivalence like GetInstanceInfo.
+func (lm *LockManager) GetInstanceMetadata(address string) (*common.InstanceMetadata, error) {
+	metadata, err := lm.ListInstanceMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, variant := range normalizeAddressVariants(address) {
+		for i := range metadata {
+			if metadata[i].Address == variant {
+				return &metadata[i], nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// RemoveInstanceMetadata deletes the metadata of an instance
+func (lm *LockManager) RemoveInstanceMetadata(address string) error {
+	if err := lm.ensureMetadataTable(); err != nil {
+		return nil // Gracefully handle missing database for cleanup operations
+	}
+
+	if _, err := lm.db.Exec(common.DeleteInstanceMetadataSQL, address); err != nil {
+		return fmt.Errorf("failed to remove instance metadata: %w", err)
+	}
+
+	return nil
+}
+
+// ListOrphanInstanceMetadata returns the addresses of metadata rows whose instance lock is gone
+func (lm *LockManager) ListOrphanInstanceMetadata() ([]string, error) {
+	if err := lm.ensureMetadataTable(); err != nil {
+		return []string{}, nil
+	}
+
+	rows, err := lm.db.Query(common.SelectOrphanInstanceMetadataSQL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orphaned instance metadata: %w", err)
+	}
+	defer rows.Close()
+
+	var addresses []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, fmt.Errorf("failed to scan instance metadata row: %w", err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, rows.Err()
+}
@@ -10,12 +10,15 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/clica/cli/pkg/cli/auth"
 	"github.com/clica/cli/pkg/cli/config"
 	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/handlers"
 	"github.com/clica/cli/pkg/cli/task"
 	"github.com/clica/cli/pkg/cli/updater"
 	"github.com/clica/grpc-go/clica"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 // TaskOptions contains options for creating a task
@@ -27,6 +30,8 @@ type TaskOptions struct {
 	Yolo     bool
 	Address  string
 	Verbose  bool
+	Detach   bool // create the task and leave it running without following it
+	TUI      bool // follow the task in the full-screen interface
 }
 
 func NewTaskCommand() *cobra.Command {
@@ -52,6 +57,12 @@ func NewTaskCommand() *cobra.Command {
 var taskManager *task.Manager
 
 func ensureTaskManager(ctx context.Context, address string) error {
+	// Accept instance names wherever an address is expected
+	address, err := global.ResolveInstanceAddress(address)
+	if err != nil {
+		return err
+	}
+
 	if taskManager == nil || (address != "" && taskManager.GetCurrentInstance() != address) {
 		var err error
 		var instanceAddress string
@@ -78,11 +89,26 @@ func ensureTaskManager(ctx context.Context, address string) error {
 			return fmt.Errorf("failed to create task manager: %w", err)
 		}
 
-		// Always set the instance we're using as the default
-		registry := global.Clients.GetRegistry()
-		if err := registry.SetDefaultInstance(instanceAddress); err != nil {
-			// Log warning but don't fail - this is not critical
-			fmt.Printf("Warning: failed to set default instance: %v\n", err)
+		// Wire up the interactive /model picker (lives in auth, which imports task)
+		manager := taskManager
+		manager.SetModelPicker(func(ctx context.Context, mode string) (string, error) {
+			return auth.SelectAndUseModelForCurrentTask(ctx, manager, mode)
+		})
+		manager.SetModelLabeler(func(ctx context.Context, mode string) string {
+			return currentModelLabel(ctx, manager, mode)
+		})
+		if err := registerMessageHandlers(manager); err != nil {
+			return err
+		}
+
+		// Set the instance we're using as the default, unless it was only picked
+		// because its workspace contains the current directory
+		if address != "" || instanceAddress != global.WorkspaceInstance() {
+			registry := global.Clients.GetRegistry()
+			if err := registry.SetDefaultInstance(instanceAddress); err != nil {
+				// Log warning but don't fail - this is not critical
+				fmt.Printf("Warning: failed to set default instance: %v\n", err)
+			}
 		}
 	}
 	return nil
@@ -116,7 +142,7 @@ func newTaskNewCommand() *cobra.Command {
 			ctx := cmd.Context()
 
 			// Check if an instance exists when no address specified
-			if address == "" && global.Clients.GetRegistry().GetDefaultInstance() == "" {
+			if address == "" && global.CurrentInstanceAddress() == "" {
 				fmt.Println("No instances available for creating tasks")
 				return nil
 			}
@@ -132,6 +158,12 @@ func newTaskNewCommand() *cobra.Command {
 				return fmt.Errorf("prompt required: provide as argument or pipe via stdin")
 			}
 
+			// Merge -s flags with environment and .clica.yaml settings
+			settings, err = resolveTaskSettings(settings)
+			if err != nil {
+				return err
+			}
+
 			// Ensure task manager is initialized
 			if err := ensureTaskManager(ctx, address); err != nil {
 				return err
@@ -229,7 +261,7 @@ func newTaskSendCommand() *cobra.Command {
 			ctx := cmd.Context()
 
 			// Check if an instance exists when no address specified
-			if address == "" && global.Clients.GetRegistry().GetDefaultInstance() == "" {
+			if address == "" && global.CurrentInstanceAddress() == "" {
 				fmt.Println("No instances available for sending messages")
 				return nil
 			}
@@ -331,7 +363,10 @@ func newTaskSendCommand() *cobra.Command {
 }
 
 func newTaskChatCommand() *cobra.Command {
-	var address string
+	var (
+		address    string
+		recordPath string
+	)
 
 	cmd := &cobra.Command{
 		Use:     "chat",
@@ -358,11 +393,19 @@ func newTaskChatCommand() *cobra.Command {
 				// as the user may want to observe the task
 			}
 
+			if recordPath != "" {
+				if err := task.StartRecording(recordPath); err != nil {
+					return err
+				}
+				defer task.StopRecording()
+			}
+
 			return taskManager.FollowConversation(ctx, taskManager.GetCurrentInstance(), true)
 		},
 	}
 
 	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to use")
+	cmd.Flags().StringVar(&recordPath, "record", "", RecordFlagUsage)
 
 	return cmd
 }
@@ -372,6 +415,7 @@ func newTaskViewCommand() *cobra.Command {
 		follow         bool
 		followComplete bool
 		address        string
+		recordPath     string
 	)
 
 	cmd := &cobra.Command{
@@ -383,12 +427,24 @@ func newTaskViewCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
 
+			// A snapshot has no stream updates to record
+			if recordPath != "" && !follow && !followComplete {
+				return fmt.Errorf("--record needs --follow or --follow-complete")
+			}
+
 			if err := ensureTaskManager(ctx, address); err != nil {
 				return err
 			}
 
 			fmt.Printf("Using instance: %s\n", taskManager.GetCurrentInstance())
 
+			if recordPath != "" {
+				if err := task.StartRecording(recordPath); err != nil {
+					return err
+				}
+				defer task.StopRecording()
+			}
+
 			if follow {
 				// Follow conversation forever (non-interactive)
 				return taskManager.FollowConversation(ctx, taskManager.GetCurrentInstance(), false)
@@ -405,6 +461,7 @@ func newTaskViewCommand() *cobra.Command {
 	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "follow conversation forever")
 	cmd.Flags().BoolVarP(&followComplete, "follow-complete", "c", false, "follow until completion")
 	cmd.Flags().StringVar(&address, "address", "", "specific Clica instance address to use")
+	cmd.Flags().StringVar(&recordPath, "record", "", RecordFlagUsage)
 
 	return cmd
 }
@@ -624,9 +681,92 @@ func NewTaskManagerForAddress(ctx context.Context, address string) (*task.Manage
 	return task.NewManagerForAddress(ctx, address)
 }
 
+// resolveTaskSettings merges -s flag settings with CLICA_SETTING_* environment variables
+// and the project/user config files, then validates the result.
+func resolveTaskSettings(flagSettings []string) ([]string, error) {
+	cwd, _ := os.Getwd()
+	return resolveWorkspaceSettings(cwd, flagSettings)
+}
+
+// resolveWorkspaceSettings is resolveTaskSettings for a task running in another workspace,
+// whose project config applies instead of the current directory's
+func resolveWorkspaceSettings(workspace string, flagSettings []string) ([]string, error) {
+	resolved, err := config.ResolveSettingsIn(workspace, flagSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := resolved.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid settings:\n%w", err)
+	}
+
+	return resolved.Flags(), nil
+}
+
+// registerMessageHandlers adds the subprocess message handlers configured in the user config to a
+// task manager. Handlers set anywhere else are skipped with a warning, so a cloned repository's
+// project config can't break, or run commands from, task commands.
+func registerMessageHandlers(manager *task.Manager) error {
+	resolved, err := config.ResolveSettings(nil)
+	if err != nil {
+		return err
+	}
+
+	configs, ignored, err := resolved.MessageHandlers()
+	if err != nil {
+		return fmt.Errorf("invalid message handler settings:\n%w", err)
+	}
+	for _, setting := range ignored {
+		fmt.Fprintf(os.Stderr, "Warning: skipping %v\n", config.IgnoredMessageHandlerError(setting))
+	}
+	for _, handlerConfig := range configs {
+		manager.RegisterHandler(handlers.NewSubprocessHandler(handlerConfig))
+	}
+	return nil
+}
+
+// currentModelLabel returns the provider and model an instance uses in a mode, or "" if unknown
+func currentModelLabel(ctx context.Context, manager *task.Manager, mode string) string {
+	providers, err := auth.GetProviderConfigurations(ctx, manager)
+	if err != nil {
+		return ""
+	}
+
+	provider := providers.PlanProvider
+	if mode == "act" {
+		provider = providers.ActProvider
+	}
+	if provider == nil || provider.ModelID == "" {
+		return ""
+	}
+	return auth.GetProviderIDForEnum(provider.Provider) + "/" + provider.ModelID
+}
+
+// ConfiguredMode returns the mode set through -s flags, environment or config files, or "" if unset.
+// Used by the root command when --mode isn't given explicitly.
+func ConfiguredMode(flagSettings []string) string {
+	resolved, err := config.ResolveSettings(flagSettings)
+	if err != nil {
+		return ""
+	}
+
+	if setting, ok := resolved.Get("mode"); ok {
+		return setting.Value
+	}
+	return ""
+}
+
 // CreateAndFollowTask creates a new task and immediately follows it in interactive mode
-// This is used by the root command to provide a streamlined UX
+// This is used by the root command to provide a streamlined UX.
+// Returns task.ErrDetached if the task was left running, with --detach or Ctrl+\.
 func CreateAndFollowTask(ctx context.Context, prompt string, opts TaskOptions) error {
+	// Merge -s flags with environment and .clica.yaml settings
+	resolvedSettings, err := resolveTaskSettings(opts.Settings)
+	if err != nil {
+		return err
+	}
+	opts.Settings = resolvedSettings
+
 	// Initialize task manager with the provided instance address
 	if err := ensureTaskManager(ctx, opts.Address); err != nil {
 		return err
@@ -652,6 +792,15 @@ func CreateAndFollowTask(ctx context.Context, prompt string, opts TaskOptions) e
 		opts.Settings = append(opts.Settings, "yolo_mode_toggled=true")
 	}
 
+	useTUI := opts.TUI && !opts.Detach && term.IsTerminal(int(os.Stdout.Fd())) && global.Config.OutputFormat != "json"
+	if useTUI && prompt == "" {
+		// The TUI creates the task from its first message
+		return runTaskTUI(ctx, "", opts)
+	}
+	if prompt == "" {
+		return fmt.Errorf("prompt required")
+	}
+
 	// Create the task
 	taskID, err := taskManager.CreateTask(ctx, prompt, opts.Images, opts.Files, opts.Settings)
 	if err != nil {
@@ -665,11 +814,55 @@ func CreateAndFollowTask(ctx context.Context, prompt string, opts TaskOptions) e
 	// Check for updates in background after task is created
 	updater.CheckAndUpdate(opts.Verbose)
 
+	if opts.Detach {
+		printDetachHint(taskManager.GetCurrentInstance(), taskID)
+		return task.ErrDetached
+	}
+
+	if useTUI {
+		return runTaskTUI(ctx, taskID, opts)
+	}
+
 	// If yolo mode is enabled, follow until completion (non-interactive)
 	// Otherwise, follow in interactive mode
 	if opts.Yolo {
 		return taskManager.FollowConversationUntilCompletion(ctx)
-	} else {
-		return taskManager.FollowConversation(ctx, taskManager.GetCurrentInstance(), true)
 	}
+
+	err = taskManager.FollowConversation(ctx, taskManager.GetCurrentInstance(), true)
+	if errors.Is(err, task.ErrDetached) {
+		printDetachHint(taskManager.GetCurrentInstance(), taskID)
+	}
+	return err
+}
+
+// runTaskTUI follows the current task of the task manager's instance in the full-screen interface
+func runTaskTUI(ctx context.Context, taskID string, opts TaskOptions) error {
+	address := taskManager.GetCurrentInstance()
+	err := taskManager.RunTUI(ctx, address, task.TUIOptions{
+		Images:   opts.Images,
+		Files:    opts.Files,
+		Settings: opts.Settings,
+	})
+	if errors.Is(err, task.ErrDetached) {
+		if taskID == "" {
+			// The task was created in the TUI
+			taskID = currentTaskID(ctx)
+		}
+		printDetachHint(address, taskID)
+	}
+	return err
+}
+
+// currentTaskID returns the ID of the task manager's current task, or "" if it can't be read
+func currentTaskID(ctx context.Context) string {
+	state, err := taskManager.GetClient().State.GetLatestState(ctx, &clica.EmptyRequest{})
+	if err != nil {
+		return ""
+	}
+	status, err := task.TaskStatusFromState(state.StateJson)
+	if err != nil || status == nil {
+		return ""
+	}
+	return status.TaskID
 }
@@ -0,0 +1,245 @@
+package task
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/theme"
+	"github.com/clica/cli/pkg/cli/types"
+	"github.com/muesli/termenv"
+)
+
+// Golden tests replay the session recordings in testdata through the same handlers and
+// streaming display as a followed task, and compare the output to testdata/golden.
+// After an intended change to the output, rewrite the golden files with:
+//
+//	go test ./pkg/cli/task -run Golden -update
+//
+// New recordings can be made with 'clica --record testdata/<name>.clrec ...'.
+var update = flag.Bool("update", false, "rewrite the golden files in testdata/golden")
+
+// goldenWidth is the terminal width golden output is rendered at
+const goldenWidth = 100
+
+// goldenFormats are the output formats recordings are replayed in, "accessible" being plain output with --accessible
+var goldenFormats = []string{"rich", "plain", "json", "accessible"}
+
+func TestMain(m *testing.M) {
+	// Checkpoint times are shown in the local time zone
+	time.Local = time.UTC
+	os.Exit(m.Run())
+}
+
+func TestGoldenReplay(t *testing.T) {
+	recordings := goldenRecordings(t)
+
+	for _, path := range recordings {
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		_, frames, err := ReadRecording(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, format := range goldenFormats {
+			t.Run(name+"/"+format, func(t *testing.T) {
+				got := replayOutput(t, frames, format)
+				checkGolden(t, filepath.Join("testdata", "golden", name+"."+format+".golden"), got)
+			})
+		}
+	}
+}
+
+// TestGoldenCoverage checks that the recordings exercise every ask and say type in types/messages.go,
+// so a new message type can't be added without golden output for it
+func TestGoldenCoverage(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, path := range goldenRecordings(t) {
+		_, frames, err := ReadRecording(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, frame := range frames {
+			messages := []*types.ClicaMessage{frame.Message}
+			if frame.StateJSON != "" {
+				if messages, err = types.ExtractMessagesFromStateJSON(frame.StateJSON); err != nil {
+					t.Fatalf("%s: %v", path, err)
+				}
+			}
+			for _, msg := range messages {
+				if msg == nil {
+					continue
+				}
+				if msg.IsAsk() {
+					seen["AskType:"+msg.Ask] = true
+				} else {
+					seen["SayType:"+msg.Say] = true
+				}
+			}
+		}
+	}
+
+	for _, constant := range messageTypeConstants(t) {
+		if !seen[constant] {
+			t.Errorf("no recording in testdata has a message of %s", constant)
+		}
+	}
+}
+
+func goldenRecordings(t *testing.T) []string {
+	t.Helper()
+	recordings, err := filepath.Glob(filepath.Join("testdata", "*.clrec"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recordings) == 0 {
+		t.Fatal("no recordings in testdata")
+	}
+	return recordings
+}
+
+// replayOutput replays a recording in an output format and returns what it printed.
+// Rich output is rendered for a dark true color terminal of goldenWidth columns; with fewer
+// colors, syntax highlighting picks between equally close colors at random.
+func replayOutput(t *testing.T, frames []RecordingFrame, format string) string {
+	t.Helper()
+
+	previousConfig := global.Config
+	global.Config = &global.GlobalConfig{OutputFormat: format}
+	defer func() { global.Config = previousConfig }()
+	if format == "accessible" {
+		global.Config.OutputFormat, global.Config.Accessible = "plain", true
+	}
+
+	t.Setenv("NO_COLOR", "")
+	dark, _ := theme.Builtin(theme.DefaultName)
+	theme.Set(dark)
+	profile := termenv.TrueColor
+	if global.Config.OutputFormat == "plain" {
+		profile = termenv.Ascii
+	}
+	lipgloss.SetColorProfile(profile)
+	lipgloss.SetHasDarkBackground(true)
+	defer display.UseFakeTerminal(goldenWidth)()
+
+	return captureStdout(t, func() {
+		manager := NewManager(nil)
+		defer manager.Cleanup()
+		if err := manager.Replay(context.Background(), frames, ReplayOptions{}); err != nil {
+			t.Fatalf("replay failed: %v", err)
+		}
+	})
+}
+
+// captureStdout returns everything fn writes to stdout, including output that bypasses the output coordinator
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	file, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	stdout := os.Stdout
+	os.Stdout = file
+	func() {
+		defer func() { os.Stdout = stdout }()
+		fn()
+	}()
+
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func checkGolden(t *testing.T, path, got string) {
+	t.Helper()
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run with -update to create it): %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s (run with -update if the change is intended):\n%s", path, firstDifference(string(want), got))
+	}
+}
+
+// firstDifference describes the first line that differs, quoted so escape codes are visible
+func firstDifference(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; ; i++ {
+		if i >= len(wantLines) || i >= len(gotLines) {
+			return fmt.Sprintf("want %d lines, got %d", len(wantLines), len(gotLines))
+		}
+		if wantLines[i] != gotLines[i] {
+			return fmt.Sprintf("line %d:\nwant %q\ngot  %q", i+1, wantLines[i], gotLines[i])
+		}
+	}
+}
+
+// messageTypeConstants returns the AskType and SayType constants declared in types/messages.go
+// as "AskType:<value>" and "SayType:<value>"
+func messageTypeConstants(t *testing.T) []string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), filepath.Join("..", "types", "messages.go"), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var constants []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			typ, ok := value.Type.(*ast.Ident)
+			if !ok || (typ.Name != "AskType" && typ.Name != "SayType") {
+				continue
+			}
+			for _, v := range value.Values {
+				lit, ok := v.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				s, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				constants = append(constants, typ.Name+":"+s)
+			}
+		}
+	}
+	if len(constants) == 0 {
+		t.Fatal("found no AskType or SayType constants in types/messages.go")
+	}
+	return constants
+}
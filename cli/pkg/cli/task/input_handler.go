@@ -10,9 +10,9 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 	"github.com/clica/cli/pkg/cli/global"
 	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/theme"
 	"github.com/clica/cli/pkg/cli/types"
 )
 
@@ -29,6 +29,7 @@ type InputHandler struct {
 	programDoneChan  chan struct{} // Signals when program actually exits
 	resultChan       chan output.InputSubmitMsg
 	cancelChan       chan struct{}
+	detachChan       chan struct{}
 	feedbackApproval bool                // Track if we're in feedback after approval
 	feedbackApproved bool                // Track the approval decision
 	approvalMessage  *types.ClicaMessage // Store the approval message for determining action
@@ -45,6 +46,7 @@ func NewInputHandler(manager *Manager, coordinator *StreamCoordinator, cancelFun
 		pollTicker:   time.NewTicker(500 * time.Millisecond),
 		resultChan:   make(chan output.InputSubmitMsg, 1),
 		cancelChan:   make(chan struct{}, 1),
+		detachChan:   make(chan struct{}, 1),
 	}
 }
 
@@ -85,6 +87,10 @@ func (ih *InputHandler) Start(ctx context.Context, errChan chan error) {
 				approved, feedback, err := ih.promptForApproval(ctx, approvalMsg)
 
 				if err != nil {
+					if errors.Is(err, ErrDetached) {
+						ih.detach()
+						return
+					}
 					// Check if the error is due to interrupt (Ctrl+C) or context cancellation
 					if errors.Is(err, context.Canceled) || ctx.Err() != nil {
 						// User pressed Ctrl+C - cancel context to exit FollowConversation
@@ -147,6 +153,10 @@ func (ih *InputHandler) Start(ctx context.Context, errChan chan error) {
 			message, shouldSend, err := ih.promptForInput(ctx)
 
 			if err != nil {
+				if errors.Is(err, ErrDetached) {
+					ih.detach()
+					return
+				}
 				// Check if the error is due to interrupt (Ctrl+C) or context cancellation
 				if errors.Is(err, context.Canceled) || ctx.Err() != nil {
 					// User pressed Ctrl+C - cancel context to exit FollowConversation
@@ -163,11 +173,12 @@ func (ih *InputHandler) Start(ctx context.Context, errChan chan error) {
 
 			if shouldSend {
 				// Check for mode switch commands first
-				newMode, remainingMessage, isModeSwitch := ih.parseModeSwitch(message)
+				newMode, remainingMessage, isModeSwitch := parseModeSwitch(message)
 				if isModeSwitch {
 					// Create styles for mode switch messages (respect global color profile)
-					actStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")).Bold(true)
-					planStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("3")).Bold(true)
+					t := theme.Current()
+					actStyle := t.Style(t.Act).Bold(true)
+					planStyle := t.Style(t.Plan).Bold(true)
 
 					if remainingMessage != "" {
 						// Switching with a message - behavior differs by mode
@@ -274,8 +285,29 @@ func determineAutoApprovalAction(msg *types.ClicaMessage) (string, error) {
 	}
 }
 
+// enableAutoApproval stops asking for approval of requests like msg for the rest of the task
+func (ih *InputHandler) enableAutoApproval(ctx context.Context, msg *types.ClicaMessage) {
+	// Determine which auto-approval action to enable
+	action, err := determineAutoApprovalAction(msg)
+	if err != nil {
+		output.Printf("\nWarning: Could not determine auto-approval action: %v\n", err)
+		return
+	}
+
+	// Enable the auto-approval action
+	if err := ih.manager.UpdateTaskAutoApprovalAction(ctx, action); err != nil {
+		output.Printf("\nWarning: Could not update auto-approval: %v\n", err)
+	} else {
+		output.Printf("\nAuto-approval enabled for %s\n", action)
+	}
+}
+
 // promptForInput displays an interactive prompt and waits for user input
 func (ih *InputHandler) promptForInput(ctx context.Context) (string, bool, error) {
+	if global.Config.Accessible {
+		return ih.promptForInputLine(ctx)
+	}
+
 	currentMode := ih.manager.GetCurrentMode()
 
 	model := output.NewInputModel(
@@ -292,7 +324,11 @@ func (ih *InputHandler) promptForInput(ctx context.Context) (string, bool, error
 func (ih *InputHandler) promptForApproval(ctx context.Context, msg *types.ClicaMessage) (bool, string, error) {
 	// Store the approval message for later use in determining auto-approval action
 	ih.approvalMessage = msg
-	
+
+	if global.Config.Accessible {
+		return ih.promptForApprovalLine(ctx, msg)
+	}
+
 	model := output.NewInputModel(
 		output.InputTypeApproval,
 		"Let Clica use this tool?",
@@ -366,6 +402,13 @@ func (ih *InputHandler) runInputProgram(ctx context.Context, model output.InputM
 		ih.mu.Unlock()
 		return "", false, context.Canceled
 
+	case <-ih.detachChan:
+		ih.mu.Lock()
+		output.SetInputVisible(false)
+		ih.programRunning = false
+		ih.mu.Unlock()
+		return "", false, ErrDetached
+
 	case err := <-programErrChan:
 		ih.mu.Lock()
 		output.SetInputVisible(false)
@@ -395,18 +438,7 @@ func (ih *InputHandler) runInputProgram(ctx context.Context, model output.InputM
 			
 			// Check if NoAskAgain was selected
 			if result.NoAskAgain && result.Approved && ih.approvalMessage != nil {
-				// Determine which auto-approval action to enable
-				action, err := determineAutoApprovalAction(ih.approvalMessage)
-				if err != nil {
-					output.Printf("\nWarning: Could not determine auto-approval action: %v\n", err)
-				} else {
-					// Enable the auto-approval action
-					if err := ih.manager.UpdateTaskAutoApprovalAction(ctx, action); err != nil {
-						output.Printf("\nWarning: Could not update auto-approval: %v\n", err)
-					} else {
-						output.Printf("\nAuto-approval enabled for %s\n", action)
-					}
-				}
+				ih.enableAutoApproval(ctx, ih.approvalMessage)
 			}
 			
 			// Store approval state for when feedback comes back
@@ -457,6 +489,17 @@ func (w *inputProgramWrapper) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return w, tea.Quit
 
+	case tea.KeyMsg:
+		// The terminal is in raw mode while input is shown, so Ctrl+\ arrives as a key, not SIGQUIT
+		if msg.Type == tea.KeyCtrlBackslash {
+			w.handler.detachChan <- struct{}{}
+			clearCodes := w.model.ClearScreen()
+			if clearCodes != "" {
+				fmt.Print(clearCodes)
+			}
+			return w, tea.Quit
+		}
+
 	case output.ChangeInputTypeMsg:
 		// Change input type (approval -> feedback)
 		_, cmd := w.model.Update(msg)
@@ -472,8 +515,14 @@ func (w *inputProgramWrapper) View() string {
 	return w.model.View()
 }
 
+// detach ends follow mode without touching the task
+func (ih *InputHandler) detach() {
+	ih.manager.markDetached()
+	ih.cancelFunc()
+}
+
 // parseModeSwitch checks if message starts with /act or /plan and extracts the mode and remaining message
-func (ih *InputHandler) parseModeSwitch(message string) (string, string, bool) {
+func parseModeSwitch(message string) (string, string, bool) {
 	trimmed := strings.TrimSpace(message)
 	lower := strings.ToLower(trimmed)
 
@@ -490,7 +539,7 @@ func (ih *InputHandler) parseModeSwitch(message string) (string, string, bool) {
 	return "", message, false
 }
 
-// handleSpecialCommand processes special commands like /cancel, /exit
+// handleSpecialCommand processes special commands like /cancel, /exit, /model
 func (ih *InputHandler) handleSpecialCommand(ctx context.Context, message string) bool {
 	switch strings.ToLower(strings.TrimSpace(message)) {
 	case "/cancel":
@@ -504,6 +553,23 @@ func (ih *InputHandler) handleSpecialCommand(ctx context.Context, message string
 	case "/exit", "/quit":
 		output.Println("\nExiting follow mode...")
 		return true
+	case "/model":
+		picker := ih.manager.GetModelPicker()
+		if picker == nil {
+			output.Println("\nModel switching is not available in this session")
+			return true
+		}
+		mode := ih.manager.GetCurrentMode()
+		if mode == "" {
+			mode = "plan"
+		}
+		modelID, err := picker(ctx, mode)
+		if err != nil {
+			output.Printf("\nError switching model: %v\n", err)
+			return true
+		}
+		output.Printf("\nSwitched %s mode to %s for this task\n", mode, modelID)
+		return true
 	default:
 		return false
 	}
@@ -0,0 +1,76 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/types"
+)
+
+// Accessible prompts (--accessible) are plain questions answered with a line of text, instead of
+// forms that are redrawn with every key press and navigated with the arrow keys.
+
+// promptForInputLine asks for the next message on a single line
+func (ih *InputHandler) promptForInputLine(ctx context.Context) (string, bool, error) {
+	mode := ih.manager.GetCurrentMode()
+	if mode == "" {
+		mode = "plan"
+	}
+
+	message, err := ih.readLine(ctx, fmt.Sprintf("\nClica is ready for your message, in %s mode. Type /plan or /act to switch modes, then press Enter.\n", mode))
+	if err != nil {
+		return "", false, err
+	}
+	return message, message != "", nil
+}
+
+// promptForApprovalLine asks whether to approve msg, taking y, a or n. Denying asks for optional feedback.
+func (ih *InputHandler) promptForApprovalLine(ctx context.Context, msg *types.ClicaMessage) (bool, string, error) {
+	question := fmt.Sprintf("\nApprove the request to %s? Type y for yes, a for yes and don't ask again for this task, or n for no, then press Enter.\n",
+		display.DescribeApproval(msg))
+
+	for {
+		answer, err := ih.readLine(ctx, question)
+		if err != nil {
+			return false, "", err
+		}
+
+		switch strings.ToLower(answer) {
+		case "y", "yes":
+			return true, "", nil
+		case "a", "always":
+			ih.enableAutoApproval(ctx, msg)
+			return true, "", nil
+		case "n", "no":
+			feedback, err := ih.readLine(ctx, "Feedback for Clica, or press Enter to skip:\n")
+			if err != nil {
+				return false, "", err
+			}
+			return false, feedback, nil
+		}
+
+		question = "Please type y, a or n, then press Enter.\n"
+	}
+}
+
+// readLine prints prompt and returns the next line typed, trimmed. Lines come from the
+// session's single stdin reader, which accessible forms such as the /model picker share, so a
+// prompt abandoned when the context ends doesn't consume the answer to the next one.
+func (ih *InputHandler) readLine(ctx context.Context, prompt string) (string, error) {
+	output.Print(prompt)
+
+	line, err := output.ReadStdinLine(ctx)
+	if errors.Is(err, io.EOF) {
+		// Ctrl+D or a closed stdin ends the session like Ctrl+C
+		return "", context.Canceled
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
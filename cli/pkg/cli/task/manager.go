@@ -38,9 +38,20 @@ type Manager struct {
 	handlerRegistry  *handlers.HandlerRegistry
 	isStreamingMode  bool
 	isInteractive    bool
+	detached         bool   // the user detached from the last FollowConversation
 	currentMode      string // "plan" or "act"
+	modelPicker      ModelPicker
+	modelLabeler     ModelLabeler
 }
 
+// ModelPicker prompts for a model and applies it to the current task for the given mode.
+// Returns the selected model ID. Set by the cli package to avoid an import cycle with auth.
+type ModelPicker func(ctx context.Context, mode string) (string, error)
+
+// ModelLabeler returns the provider and model used in the given mode, for the TUI status bar.
+// Set by the cli package for the same reason as ModelPicker.
+type ModelLabeler func(ctx context.Context, mode string) string
+
 // NewManager creates a new task manager
 func NewManager(client *client.ClicaClient) *Manager {
 	state := types.NewConversationState()
@@ -49,10 +60,8 @@ func NewManager(client *client.ClicaClient) *Manager {
 	systemRenderer := display.NewSystemMessageRenderer(renderer, renderer.GetMdRenderer(), global.Config.OutputFormat)
 	streamingDisplay := display.NewStreamingDisplay(state, renderer)
 
-	// Create handler registry and register handlers
-	registry := handlers.NewHandlerRegistry()
-	registry.Register(handlers.NewAskHandler())
-	registry.Register(handlers.NewSayHandler())
+	// Built-in handlers plus those registered with handlers.RegisterHandler
+	registry := handlers.NewDefaultRegistry()
 
 	return &Manager{
 		client:           client,
@@ -88,9 +97,9 @@ func NewManagerForDefault(ctx context.Context) (*Manager, error) {
 
 	manager := NewManager(client)
 
-	// Get the default instance address
+	// Get the address of the selected or default instance
 	if global.Clients != nil {
-		manager.clientAddress = global.Clients.GetRegistry().GetDefaultInstance()
+		manager.clientAddress = global.CurrentInstanceAddress()
 	}
 
 	return manager, nil
@@ -170,6 +179,7 @@ func (m *Manager) CreateTask(ctx context.Context, prompt string, images, files [
 	if err != nil {
 		return "", fmt.Errorf("failed to create task: %w", err)
 	}
+	global.TouchInstanceActivity(m.clientAddress)
 
 	taskID := resp.Value
 
@@ -416,6 +426,7 @@ func (m *Manager) SendMessage(ctx context.Context, message string, images, files
 	if err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
+	global.TouchInstanceActivity(m.GetCurrentInstance())
 
 	return nil
 }
@@ -450,6 +461,7 @@ func (m *Manager) SetMode(ctx context.Context, mode string, message *string, ima
 	if err != nil {
 		return fmt.Errorf("failed to set mode to '%s': %w", mode, err)
 	}
+	global.TouchInstanceActivity(m.GetCurrentInstance())
 
 	return nil
 }
@@ -566,6 +578,7 @@ func (m *Manager) ResumeTask(ctx context.Context, taskID string) error {
 	if err := m.ReinitExistingTaskFromId(ctx, taskID); err != nil {
 		return fmt.Errorf("failed to resume task %s: %w", taskID, err)
 	}
+	global.TouchInstanceActivity(m.clientAddress)
 
 	fmt.Printf("Task %s resumed successfully\n", taskID)
 
@@ -659,16 +672,20 @@ func (m *Manager) FollowConversation(ctx context.Context, instanceAddress string
 	m.mu.Lock()
 	m.isStreamingMode = true
 	m.isInteractive = interactive
+	m.detached = false
 	m.mu.Unlock()
 
 	if global.Config.OutputFormat != "plain" {
 		markdown := fmt.Sprintf("*Using instance: %s*\n*Press Ctrl+C to exit*", instanceAddress)
+		if interactive {
+			markdown = fmt.Sprintf("*Using instance: %s*\n*Press Ctrl+C to exit, `Ctrl+\\` to detach*", instanceAddress)
+		}
 		rendered := m.renderer.RenderMarkdown(markdown)
 		fmt.Printf("%s", rendered)
 	} else {
 		fmt.Printf("Using instance: %s\n", instanceAddress)
 		if interactive {
-			fmt.Println("Following task conversation in interactive mode... (Press Ctrl+C to exit)")
+			fmt.Println("Following task conversation in interactive mode... (Press Ctrl+C to exit, Ctrl+\\ to detach)")
 		} else {
 			fmt.Println("Following task conversation... (Press Ctrl+C to exit)")
 		}
@@ -704,16 +721,25 @@ func (m *Manager) FollowConversation(ctx context.Context, instanceAddress string
 		}
 	}
 
-	// Handle Ctrl+C signals
+	// Handle Ctrl+C signals, and Ctrl+\ (SIGQUIT) to detach from an interactive session
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	if interactive {
+		signal.Notify(sigChan, syscall.SIGQUIT)
+	}
 	go func() {
 		defer signal.Stop(sigChan) // Clean up signal handler when goroutine exits
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-sigChan:
+			case sig := <-sigChan:
+				if sig == syscall.SIGQUIT {
+					// Leave the task running on its instance
+					m.markDetached()
+					cancel()
+					return
+				}
 				if interactive {
 					// Interactive mode (task chat)
 					// Check if input is currently being shown
@@ -741,6 +767,9 @@ func (m *Manager) FollowConversation(ctx context.Context, instanceAddress string
 	// Wait for either stream to error or context cancellation
 	select {
 	case <-ctx.Done():
+		if m.wasDetached() {
+			return ErrDetached
+		}
 		// Check if this was a user-initiated cancellation (Ctrl+C)
 		// Return nil for clean exit instead of context.Canceled error
 		if ctx.Err() == context.Canceled {
@@ -753,6 +782,20 @@ func (m *Manager) FollowConversation(ctx context.Context, instanceAddress string
 	}
 }
 
+// markDetached records that the user detached from the conversation being followed
+func (m *Manager) markDetached() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.detached = true
+}
+
+// wasDetached reports whether the user detached from the conversation being followed
+func (m *Manager) wasDetached() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.detached
+}
+
 // FollowConversationUntilCompletion streams conversation updates until task completion
 func (m *Manager) FollowConversationUntilCompletion(ctx context.Context) error {
 	// Enable streaming mode
@@ -819,6 +862,9 @@ func (m *Manager) handleStateStream(ctx context.Context, coordinator *StreamCoor
 				errChan <- fmt.Errorf("failed to receive state update: %w", err)
 				return
 			}
+			currentRecorder().recordState(StreamState, m.GetCurrentInstance(), stateUpdate.StateJson)
+			// A followed task is in use while it streams, even without input
+			global.TouchInstanceActivity(m.GetCurrentInstance())
 
 			var pErr error
 
@@ -903,6 +949,7 @@ func (m *Manager) processStateUpdateJsonMode(stateUpdate *clica.State, coordinat
 func (m *Manager) processStateUpdate(stateUpdate *clica.State, coordinator *StreamCoordinator, completionChan chan bool) error {
 	// Update current mode from state
 	m.updateMode(stateUpdate.StateJson)
+	updateWorkspaceRoot(stateUpdate.StateJson)
 
 	messages, err := m.extractMessagesFromState(stateUpdate.StateJson)
 	if err != nil {
@@ -1055,6 +1102,7 @@ func (m *Manager) handlePartialMessageStream(ctx context.Context, coordinator *S
 
 			// Convert proto message to our Message struct
 			msg := types.ConvertProtoToMessage(protoMsg)
+			currentRecorder().recordPartial(m.GetCurrentInstance(), msg)
 
 			// Debug: Log received message (always show for debugging)
 			m.renderer.RenderDebug("Received streaming message: type=%s, partial=%v, text_len=%d",
@@ -1118,9 +1166,18 @@ func (m *Manager) displayMessage(msg *types.ClicaMessage, isLast, isPartial bool
 	}
 }
 
-// outputMessageAsJSON prints a single clica message as json
+// outputMessageAsJSON prints a single clica message as json.
+// MCP messages get an "mcp" field with the server, tool, arguments and response parts.
 func (m *Manager) outputMessageAsJSON(msg *types.ClicaMessage) error {
-	jsonBytes, err := json.MarshalIndent(msg, "", "  ")
+	var value interface{} = msg
+	if mcp := handlers.DescribeMcpMessage(msg, m.state); mcp != nil {
+		value = struct {
+			*types.ClicaMessage
+			Mcp *types.McpDetails `json:"mcp"`
+		}{msg, mcp}
+	}
+
+	jsonBytes, err := json.MarshalIndent(value, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal message as JSON: %w", err)
 	}
@@ -1136,9 +1193,17 @@ func (m *Manager) loadAndDisplayRecentHistory(ctx context.Context) (int, error)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get state: %w", err)
 	}
+	currentRecorder().recordState(StreamHistory, m.GetCurrentInstance(), state.StateJson)
+
+	return m.displayHistory(state.StateJson)
+}
+
+// displayHistory displays the recent messages of a state and returns the total number of messages
+func (m *Manager) displayHistory(stateJson string) (int, error) {
+	updateWorkspaceRoot(stateJson)
 
 	// Parse the state JSON to extract messages
-	messages, err := m.extractMessagesFromState(state.StateJson)
+	messages, err := m.extractMessagesFromState(stateJson)
 	if err != nil {
 		return 0, fmt.Errorf("failed to extract messages: %w", err)
 	}
@@ -1215,6 +1280,44 @@ func (m *Manager) GetCurrentMode() string {
 	return m.currentMode
 }
 
+// SetModelPicker sets the picker used by the interactive /model command
+func (m *Manager) SetModelPicker(picker ModelPicker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.modelPicker = picker
+}
+
+// GetModelPicker returns the picker used by the interactive /model command, or nil if unset
+func (m *Manager) GetModelPicker() ModelPicker {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.modelPicker
+}
+
+// SetModelLabeler sets the function the TUI status bar gets the current model from
+func (m *Manager) SetModelLabeler(labeler ModelLabeler) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.modelLabeler = labeler
+}
+
+// GetModelLabeler returns the function the TUI status bar gets the current model from, or nil if unset
+func (m *Manager) GetModelLabeler() ModelLabeler {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.modelLabeler
+}
+
+// RegisterHandler adds a message handler to this manager only
+func (m *Manager) RegisterHandler(handler handlers.MessageHandler) {
+	m.handlerRegistry.Register(handler)
+}
+
+// GetHandlerRegistry returns the registry messages are displayed through
+func (m *Manager) GetHandlerRegistry() *handlers.HandlerRegistry {
+	return m.handlerRegistry
+}
+
 // extractModeFromState extracts the current mode from state JSON
 func (m *Manager) extractModeFromState(stateJson string) string {
 	var rawState map[string]interface{}
@@ -1237,6 +1340,20 @@ func (m *Manager) updateMode(stateJson string) {
 	m.mu.Unlock()
 }
 
+// updateWorkspaceRoot points edit diffs at the instance's workspace, which relative edit paths
+// are resolved against. States without workspace roots leave it unchanged.
+func updateWorkspaceRoot(stateJson string) {
+	var state struct {
+		WorkspaceRoots []struct {
+			Path string `json:"path"`
+		} `json:"workspaceRoots"`
+	}
+	if err := json.Unmarshal([]byte(stateJson), &state); err != nil || len(state.WorkspaceRoots) == 0 {
+		return
+	}
+	display.SetWorkspaceRoot(state.WorkspaceRoots[0].Path)
+}
+
 // UpdateTaskAutoApprovalAction enables a specific auto-approval action for the current task
 func (m *Manager) UpdateTaskAutoApprovalAction(ctx context.Context, actionKey string) error {
 	settings := &clica.Settings{
@@ -0,0 +1,184 @@
+package task
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/types"
+)
+
+// RecordingVersion is the version of the session recording format
+const RecordingVersion = 1
+
+// Streams of recorded frames
+const (
+	// StreamHistory is the state loaded before following a conversation
+	StreamHistory = "history"
+	// StreamState is a SubscribeToState update
+	StreamState = "state"
+	// StreamPartial is a SubscribeToPartialMessage update
+	StreamPartial = "partial"
+)
+
+// RecordingHeader is the first line of a session recording
+type RecordingHeader struct {
+	Version    int       `json:"clrec"`
+	CliVersion string    `json:"cli_version"`
+	Instance   string    `json:"instance,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+}
+
+// RecordingFrame is one stream update of a session recording, one JSON object per line
+type RecordingFrame struct {
+	Offset    int64               `json:"t"` // milliseconds since the recording started
+	Stream    string              `json:"stream"`
+	StateJSON string              `json:"state,omitempty"`
+	Message   *types.ClicaMessage `json:"message,omitempty"`
+}
+
+// Recorder writes stream updates to a session recording. Frames are written as they
+// arrive, so a recording cut short by a crash or Ctrl+C is still readable.
+type Recorder struct {
+	mu            sync.Mutex
+	file          *os.File
+	encoder       *json.Encoder
+	start         time.Time
+	headerWritten bool
+}
+
+var (
+	activeRecorder   *Recorder
+	activeRecorderMu sync.Mutex
+)
+
+// StartRecording records the streams of every task manager created afterwards to a file
+func StartRecording(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create recording file: %w", err)
+	}
+
+	activeRecorderMu.Lock()
+	defer activeRecorderMu.Unlock()
+	activeRecorder = &Recorder{
+		file:    file,
+		encoder: json.NewEncoder(file),
+		start:   time.Now(),
+	}
+	return nil
+}
+
+// StopRecording closes the recording started with StartRecording, if any
+func StopRecording() error {
+	activeRecorderMu.Lock()
+	defer activeRecorderMu.Unlock()
+	if activeRecorder == nil {
+		return nil
+	}
+	err := activeRecorder.file.Close()
+	activeRecorder = nil
+	return err
+}
+
+// currentRecorder returns the active recorder, or nil when not recording
+func currentRecorder() *Recorder {
+	activeRecorderMu.Lock()
+	defer activeRecorderMu.Unlock()
+	return activeRecorder
+}
+
+// recordState records a state update. Safe to call on a nil recorder.
+func (r *Recorder) recordState(stream, instance, stateJSON string) {
+	if r == nil {
+		return
+	}
+	r.write(instance, RecordingFrame{Stream: stream, StateJSON: stateJSON})
+}
+
+// recordPartial records a streamed message update. Safe to call on a nil recorder.
+func (r *Recorder) recordPartial(instance string, msg *types.ClicaMessage) {
+	if r == nil {
+		return
+	}
+	r.write(instance, RecordingFrame{Stream: StreamPartial, Message: msg})
+}
+
+func (r *Recorder) write(instance string, frame RecordingFrame) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// The header goes in front of the first frame, once the instance is known
+	if !r.headerWritten {
+		header := RecordingHeader{
+			Version:    RecordingVersion,
+			CliVersion: global.CliVersion,
+			Instance:   instance,
+			StartedAt:  r.start,
+		}
+		if err := r.encoder.Encode(header); err != nil {
+			return
+		}
+		r.headerWritten = true
+	}
+
+	frame.Offset = time.Since(r.start).Milliseconds()
+	r.encoder.Encode(frame)
+}
+
+// ReadRecording reads a session recording written with --record
+func ReadRecording(path string) (*RecordingHeader, []RecordingFrame, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// State updates carry the whole conversation, so lines can be long
+	scanner.Buffer(make([]byte, 0, 1024*1024), 256*1024*1024)
+
+	var header *RecordingHeader
+	var frames []RecordingFrame
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		if header == nil {
+			header = &RecordingHeader{}
+			if err := json.Unmarshal(scanner.Bytes(), header); err != nil || header.Version == 0 {
+				return nil, nil, fmt.Errorf("%s is not a session recording", path)
+			}
+			if header.Version > RecordingVersion {
+				return nil, nil, fmt.Errorf("recording version %d is newer than this CLI supports (%d); update clica to replay it", header.Version, RecordingVersion)
+			}
+			continue
+		}
+
+		var frame RecordingFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse recording line %d: %w", line, err)
+		}
+		switch frame.Stream {
+		case StreamHistory, StreamState, StreamPartial:
+		default:
+			return nil, nil, fmt.Errorf("recording line %d: unknown stream '%s'", line, frame.Stream)
+		}
+		frames = append(frames, frame)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	if header == nil {
+		return nil, nil, fmt.Errorf("recording %s is empty", path)
+	}
+
+	return header, frames, nil
+}
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/grpc-go/clica"
+)
+
+// ReplayOptions controls the pacing of a replayed recording
+type ReplayOptions struct {
+	// Speed divides the recorded delays between frames; 0 replays without delays
+	Speed float64
+	// MaxDelay caps the delay between two frames, so long waits on the model don't stall the replay. 0 for no cap.
+	MaxDelay time.Duration
+}
+
+// Replay feeds the frames of a session recording through the same processing as a followed
+// conversation, so it renders as it did live without an instance. Frames from both streams are
+// processed in recorded order, which makes replays deterministic.
+func (m *Manager) Replay(ctx context.Context, frames []RecordingFrame, opts ReplayOptions) error {
+	m.mu.Lock()
+	m.isStreamingMode = true
+	m.isInteractive = false
+	m.mu.Unlock()
+
+	coordinator := NewStreamCoordinator()
+	defer m.streamingDisplay.FreezeActiveSegment()
+
+	var previous int64
+	for _, frame := range frames {
+		if !waitForFrame(ctx, time.Duration(frame.Offset-previous)*time.Millisecond, opts) {
+			return nil
+		}
+		previous = frame.Offset
+
+		switch frame.Stream {
+		case StreamHistory:
+			total, err := m.displayHistory(frame.StateJSON)
+			if err != nil {
+				m.renderer.RenderDebug("Warning: Failed to load conversation history: %v", err)
+				total = 0
+			}
+			coordinator.SetConversationTurnStartIndex(total)
+
+		case StreamState:
+			state := &clica.State{StateJson: frame.StateJSON}
+			var err error
+			if global.Config.OutputFormat == "json" {
+				err = m.processStateUpdateJsonMode(state, coordinator, nil)
+			} else {
+				err = m.processStateUpdate(state, coordinator, nil)
+			}
+			if err != nil {
+				m.renderer.RenderDebug("State processing error: %v", err)
+			}
+
+		case StreamPartial:
+			// JSON output follows only the state stream, as when live
+			if global.Config.OutputFormat == "json" || frame.Message == nil {
+				continue
+			}
+			if err := m.handleStreamingMessage(frame.Message, coordinator); err != nil {
+				m.renderer.RenderDebug("Error handling streaming message: %v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// waitForFrame sleeps for the scaled delay before a frame. Returns false if the context is done.
+func waitForFrame(ctx context.Context, delay time.Duration, opts ReplayOptions) bool {
+	if opts.Speed <= 0 || delay <= 0 {
+		return ctx.Err() == nil
+	}
+
+	delay = time.Duration(float64(delay) / opts.Speed)
+	if opts.MaxDelay > 0 && delay > opts.MaxDelay {
+		delay = opts.MaxDelay
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -2,13 +2,85 @@ package task
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/clica/grpc-go/clica"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+// SettingKey describes a key accepted by -s flags
+type SettingKey struct {
+	Key         string   `json:"key"`
+	Type        string   `json:"type"`
+	Values      []string `json:"values,omitempty"` // valid values for enum settings
+	Secret      bool     `json:"secret,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// settingKeyAliases maps flattened keys to their path in the proto messages
+var settingKeyAliases = map[string]string{
+	"browser_settings.viewport_width":  "browser_settings.viewport.width",
+	"browser_settings.viewport_height": "browser_settings.viewport.height",
+}
+
+// enumValueAliases maps extra accepted names to enum value names, keyed by enum
+var enumValueAliases = map[protoreflect.FullName]map[string]string{
+	clica.ApiProvider(0).Descriptor().FullName(): {
+		"grok":         "xai",
+		"sap_ai_core":  "sapaicore",
+		"hugging_face": "huggingface",
+		"lm_studio":    "lmstudio",
+		"lite_llm":     "litellm",
+		"deep_seek":    "deepseek",
+	},
+}
+
+// settingDescriptions describes commonly used settings. Other keys get a description derived from their name.
+var settingDescriptions = map[string]string{
+	"mode":                                                 "Mode to start in (plan or act)",
+	"plan_mode_api_provider":                               "API provider used in plan mode",
+	"act_mode_api_provider":                                "API provider used in act mode",
+	"plan_mode_api_model_id":                               "Model ID used in plan mode for providers without a dedicated model field",
+	"act_mode_api_model_id":                                "Model ID used in act mode for providers without a dedicated model field",
+	"plan_act_separate_models_setting":                     "Use different models for plan and act mode",
+	"api_key":                                              "Anthropic API key",
+	"aws_region":                                           "AWS region for Bedrock",
+	"open_ai_base_url":                                     "Base URL for OpenAI-compatible providers",
+	"ollama_base_url":                                      "Base URL of the Ollama server",
+	"lm_studio_base_url":                                   "Base URL of the LM Studio server",
+	"telemetry_setting":                                    "Telemetry preference (enabled, disabled or unset)",
+	"enable_checkpoints_setting":                           "Create workspace checkpoints during tasks",
+	"request_timeout_ms":                                   "Timeout for API requests in milliseconds",
+	"shell_integration_timeout":                            "Time to wait for shell integration in milliseconds",
+	"terminal_output_line_limit":                           "Maximum number of terminal output lines sent to the model",
+	"max_consecutive_mistakes":                             "Mistakes in a row before the task asks for guidance",
+	"strict_plan_mode_enabled":                             "Prevent file edits while in plan mode",
+	"yolo_mode_toggled":                                    "Approve every action without asking",
+	"use_auto_condense":                                    "Condense the conversation automatically when the context fills up",
+	"auto_condense_threshold":                              "Context usage (0-1) at which auto condense kicks in",
+	"preferred_language":                                   "Language the model should respond in",
+	"custom_prompt":                                        "Custom system prompt",
+	"openai_reasoning_effort":                              "Reasoning effort for OpenAI reasoning models",
+	"auto_approval_settings.enabled":                       "Enable auto approval",
+	"auto_approval_settings.max_requests":                  "Maximum auto-approved requests before asking",
+	"auto_approval_settings.enable_notifications":          "Show notifications for approvals and task completion",
+	"auto_approval_settings.actions.read_files":            "Auto-approve reading files in the workspace",
+	"auto_approval_settings.actions.edit_files":            "Auto-approve editing files in the workspace",
+	"auto_approval_settings.actions.execute_safe_commands": "Auto-approve commands the model marks as safe",
+	"auto_approval_settings.actions.execute_all_commands":  "Auto-approve all commands",
+	"auto_approval_settings.actions.use_browser":           "Auto-approve browser use",
+	"auto_approval_settings.actions.use_mcp":               "Auto-approve MCP tool use",
+	"browser_settings.viewport_width":                      "Browser viewport width in pixels",
+	"browser_settings.viewport_height":                     "Browser viewport height in pixels",
+}
 
+// ParseTaskSettings parses key=value settings flags into Settings and Secrets.
+// Keys are resolved against the protobuf definitions, so every field of clica.Settings
+// and clica.Secrets can be set. Keys may be kebab-case or snake_case; nested fields
+// use dots (e.g. auto-approval-settings.actions.read-files=true). Lists are
+// comma-separated and maps are comma-separated key:value pairs.
 func ParseTaskSettings(settingsFlags []string) (*clica.Settings, *clica.Secrets, error) {
 	if len(settingsFlags) == 0 {
 		return nil, nil, nil
@@ -16,7 +88,6 @@ func ParseTaskSettings(settingsFlags []string) (*clica.Settings, *clica.Secrets,
 
 	settings := &clica.Settings{}
 	secrets := &clica.Secrets{}
-	nestedSettings := make(map[string]map[string]string)
 
 	for _, flag := range settingsFlags {
 		// Parse key=value
@@ -25,43 +96,99 @@ func ParseTaskSettings(settingsFlags []string) (*clica.Settings, *clica.Secrets,
 			return nil, nil, fmt.Errorf("invalid setting format '%s': expected key=value", flag)
 		}
 
-		key := strings.TrimSpace(parts[0])
+		key := normalizeSettingKey(parts[0])
 		value := strings.TrimSpace(parts[1])
 
-		// Convert kebab-case to snake_case
-		key = kebabToSnake(key)
+		if err := setSetting(settings.ProtoReflect(), secrets.ProtoReflect(), key, value); err != nil {
+			return nil, nil, err
+		}
+	}
 
-		// Check if this is a nested setting (contains a dot)
-		if strings.Contains(key, ".") {
-			dotParts := strings.SplitN(key, ".", 2)
-			parentField := dotParts[0]
-			childField := dotParts[1]
+	return settings, secrets, nil
+}
 
-			if nestedSettings[parentField] == nil {
-				nestedSettings[parentField] = make(map[string]string)
-			}
-			nestedSettings[parentField][childField] = value
-		} else {
-			// Check if it's a secret field first, then settings field
-			if err := setSecretField(secrets, key, value); err == nil {
-				// Successfully set as secret, continue
-				continue
-			}
-			// Not a secret, try as a settings field
-			if err := setSimpleField(settings, key, value); err != nil {
-				return nil, nil, fmt.Errorf("error setting field '%s': %w", key, err)
-			}
+// IsSecretSetting reports whether a setting key (kebab or snake case) is stored as a secret
+func IsSecretSetting(key string) bool {
+	key = normalizeSettingKey(key)
+	if strings.Contains(key, ".") {
+		return false
+	}
+	return secretsDescriptor().Fields().ByName(protoreflect.Name(key)) != nil
+}
+
+// IsMapSetting reports whether a setting key (kebab or snake case) refers to a map field,
+// whose value is a set of key:value pairs
+func IsMapSetting(key string) bool {
+	fd := settingField(normalizeSettingKey(key))
+	return fd != nil && fd.IsMap()
+}
+
+// settingField returns the field a snake_case key refers to, or nil if there is none
+func settingField(key string) protoreflect.FieldDescriptor {
+	if target, ok := settingKeyAliases[key]; ok {
+		key = target
+	}
+	if !strings.Contains(key, ".") {
+		if fd := secretsDescriptor().Fields().ByName(protoreflect.Name(key)); fd != nil {
+			return fd
 		}
 	}
 
-	// Process nested settings
-	for parentField, childFields := range nestedSettings {
-		if err := setNestedField(settings, parentField, childFields); err != nil {
-			return nil, nil, fmt.Errorf("error setting nested field '%s': %w", parentField, err)
+	md := settingsDescriptor()
+	path := strings.Split(key, ".")
+	for i, name := range path {
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return nil
+		}
+		if i == len(path)-1 {
+			return fd
 		}
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return nil
+		}
+		md = fd.Message()
 	}
+	return nil
+}
 
-	return settings, secrets, nil
+// ListSettingKeys returns every key accepted by ParseTaskSettings, sorted by key
+func ListSettingKeys() []SettingKey {
+	var keys []SettingKey
+	keys = appendSettingKeys(keys, "", secretsDescriptor(), true, map[protoreflect.FullName]bool{})
+	keys = appendSettingKeys(keys, "", settingsDescriptor(), false, map[protoreflect.FullName]bool{})
+
+	// Aliases are listed under the name users are most likely to type
+	for alias, target := range settingKeyAliases {
+		for _, key := range keys {
+			if key.Key == snakeToKebab(target) {
+				key.Key = snakeToKebab(alias)
+				key.Description = describeSetting(alias, false)
+				keys = append(keys, key)
+				break
+			}
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Key < keys[j].Key
+	})
+	return keys
+}
+
+// settingsDescriptor returns the message descriptor for clica.Settings
+func settingsDescriptor() protoreflect.MessageDescriptor {
+	return (&clica.Settings{}).ProtoReflect().Descriptor()
+}
+
+// secretsDescriptor returns the message descriptor for clica.Secrets
+func secretsDescriptor() protoreflect.MessageDescriptor {
+	return (&clica.Secrets{}).ProtoReflect().Descriptor()
+}
+
+// normalizeSettingKey trims and lowercases a key and converts kebab-case to snake_case
+func normalizeSettingKey(key string) string {
+	return strings.ToLower(kebabToSnake(strings.TrimSpace(key)))
 }
 
 // kebabToSnake converts kebab-case to snake_case
@@ -69,468 +196,310 @@ func kebabToSnake(s string) string {
 	return strings.ReplaceAll(s, "-", "_")
 }
 
-// Pointer helper functions for optional protobuf fields
-func strPtr(s string) *string       { return &s }
-func boolPtr(b bool) *bool          { return &b }
-func int32Ptr(i int32) *int32       { return &i }
-func int64Ptr(i int64) *int64       { return &i }
-func float64Ptr(f float64) *float64 { return &f }
-
-// setSimpleField sets a simple (non-nested) field on Settings
-func setSimpleField(settings *clica.Settings, key, value string) error {
-	switch key {
-	// String fields
-	case "aws_region":
-		settings.AwsRegion = strPtr(value)
-	case "aws_bedrock_endpoint":
-		settings.AwsBedrockEndpoint = strPtr(value)
-	case "aws_profile":
-		settings.AwsProfile = strPtr(value)
-	case "aws_authentication":
-		settings.AwsAuthentication = strPtr(value)
-	case "vertex_project_id":
-		settings.VertexProjectId = strPtr(value)
-	case "vertex_region":
-		settings.VertexRegion = strPtr(value)
-	case "requesty_base_url":
-		settings.RequestyBaseUrl = strPtr(value)
-	case "open_ai_base_url":
-		settings.OpenAiBaseUrl = strPtr(value)
-	case "ollama_base_url":
-		settings.OllamaBaseUrl = strPtr(value)
-	case "ollama_api_options_ctx_num":
-		settings.OllamaApiOptionsCtxNum = strPtr(value)
-	case "lm_studio_base_url":
-		settings.LmStudioBaseUrl = strPtr(value)
-	case "lm_studio_max_tokens":
-		settings.LmStudioMaxTokens = strPtr(value)
-	case "anthropic_base_url":
-		settings.AnthropicBaseUrl = strPtr(value)
-	case "gemini_base_url":
-		settings.GeminiBaseUrl = strPtr(value)
-	case "azure_api_version":
-		settings.AzureApiVersion = strPtr(value)
-	case "open_router_provider_sorting":
-		settings.OpenRouterProviderSorting = strPtr(value)
-	case "lite_llm_base_url":
-		settings.LiteLlmBaseUrl = strPtr(value)
-	case "qwen_api_line":
-		settings.QwenApiLine = strPtr(value)
-	case "moonshot_api_line":
-		settings.MoonshotApiLine = strPtr(value)
-	case "zai_api_line":
-		settings.ZaiApiLine = strPtr(value)
-	case "telemetry_setting":
-		settings.TelemetrySetting = strPtr(value)
-	case "asksage_api_url":
-		settings.AsksageApiUrl = strPtr(value)
-	case "default_terminal_profile":
-		settings.DefaultTerminalProfile = strPtr(value)
-	case "sap_ai_core_token_url":
-		settings.SapAiCoreTokenUrl = strPtr(value)
-	case "sap_ai_core_base_url":
-		settings.SapAiCoreBaseUrl = strPtr(value)
-	case "sap_ai_resource_group":
-		settings.SapAiResourceGroup = strPtr(value)
-	case "claude_code_path":
-		settings.ClaudeCodePath = strPtr(value)
-	case "qwen_code_oauth_path":
-		settings.QwenCodeOauthPath = strPtr(value)
-	case "preferred_language":
-		settings.PreferredLanguage = strPtr(value)
-	case "custom_prompt":
-		settings.CustomPrompt = strPtr(value)
-	case "dify_base_url":
-		settings.DifyBaseUrl = strPtr(value)
-	case "oca_base_url":
-		settings.OcaBaseUrl = strPtr(value)
-	case "plan_mode_api_model_id":
-		settings.PlanModeApiModelId = strPtr(value)
-	case "plan_mode_reasoning_effort":
-		settings.PlanModeReasoningEffort = strPtr(value)
-	case "plan_mode_aws_bedrock_custom_model_base_id":
-		settings.PlanModeAwsBedrockCustomModelBaseId = strPtr(value)
-	case "plan_mode_open_router_model_id":
-		settings.PlanModeOpenRouterModelId = strPtr(value)
-	case "plan_mode_open_ai_model_id":
-		settings.PlanModeOpenAiModelId = strPtr(value)
-	case "plan_mode_ollama_model_id":
-		settings.PlanModeOllamaModelId = strPtr(value)
-	case "plan_mode_lm_studio_model_id":
-		settings.PlanModeLmStudioModelId = strPtr(value)
-	case "plan_mode_lite_llm_model_id":
-		settings.PlanModeLiteLlmModelId = strPtr(value)
-	case "plan_mode_requesty_model_id":
-		settings.PlanModeRequestyModelId = strPtr(value)
-	case "plan_mode_together_model_id":
-		settings.PlanModeTogetherModelId = strPtr(value)
-	case "plan_mode_fireworks_model_id":
-		settings.PlanModeFireworksModelId = strPtr(value)
-	case "plan_mode_sap_ai_core_model_id":
-		settings.PlanModeSapAiCoreModelId = strPtr(value)
-	case "plan_mode_sap_ai_core_deployment_id":
-		settings.PlanModeSapAiCoreDeploymentId = strPtr(value)
-	case "plan_mode_groq_model_id":
-		settings.PlanModeGroqModelId = strPtr(value)
-	case "plan_mode_baseten_model_id":
-		settings.PlanModeBasetenModelId = strPtr(value)
-	case "plan_mode_hugging_face_model_id":
-		settings.PlanModeHuggingFaceModelId = strPtr(value)
-	case "plan_mode_huawei_cloud_maas_model_id":
-		settings.PlanModeHuaweiCloudMaasModelId = strPtr(value)
-	case "plan_mode_oca_model_id":
-		settings.PlanModeOcaModelId = strPtr(value)
-	case "plan_mode_vercel_ai_gateway_model_id":
-		settings.PlanModeVercelAiGatewayModelId = strPtr(value)
-	case "act_mode_api_model_id":
-		settings.ActModeApiModelId = strPtr(value)
-	case "act_mode_reasoning_effort":
-		settings.ActModeReasoningEffort = strPtr(value)
-	case "act_mode_aws_bedrock_custom_model_base_id":
-		settings.ActModeAwsBedrockCustomModelBaseId = strPtr(value)
-	case "act_mode_open_router_model_id":
-		settings.ActModeOpenRouterModelId = strPtr(value)
-	case "act_mode_open_ai_model_id":
-		settings.ActModeOpenAiModelId = strPtr(value)
-	case "act_mode_ollama_model_id":
-		settings.ActModeOllamaModelId = strPtr(value)
-	case "act_mode_lm_studio_model_id":
-		settings.ActModeLmStudioModelId = strPtr(value)
-	case "act_mode_lite_llm_model_id":
-		settings.ActModeLiteLlmModelId = strPtr(value)
-	case "act_mode_requesty_model_id":
-		settings.ActModeRequestyModelId = strPtr(value)
-	case "act_mode_together_model_id":
-		settings.ActModeTogetherModelId = strPtr(value)
-	case "act_mode_fireworks_model_id":
-		settings.ActModeFireworksModelId = strPtr(value)
-	case "act_mode_sap_ai_core_model_id":
-		settings.ActModeSapAiCoreModelId = strPtr(value)
-	case "act_mode_sap_ai_core_deployment_id":
-		settings.ActModeSapAiCoreDeploymentId = strPtr(value)
-	case "act_mode_groq_model_id":
-		settings.ActModeGroqModelId = strPtr(value)
-	case "act_mode_baseten_model_id":
-		settings.ActModeBasetenModelId = strPtr(value)
-	case "act_mode_hugging_face_model_id":
-		settings.ActModeHuggingFaceModelId = strPtr(value)
-	case "act_mode_huawei_cloud_maas_model_id":
-		settings.ActModeHuaweiCloudMaasModelId = strPtr(value)
-	case "act_mode_oca_model_id":
-		settings.ActModeOcaModelId = strPtr(value)
-	case "act_mode_vercel_ai_gateway_model_id":
-		settings.ActModeVercelAiGatewayModelId = strPtr(value)
-
-	// Boolean fields
-	case "aws_use_cross_region_inference":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
-		}
-		settings.AwsUseCrossRegionInference = boolPtr(val)
-	case "aws_bedrock_use_prompt_cache":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
-		}
-		settings.AwsBedrockUsePromptCache = boolPtr(val)
-	case "aws_use_profile":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
-		}
-		settings.AwsUseProfile = boolPtr(val)
-	case "lite_llm_use_prompt_cache":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
-		}
-		settings.LiteLlmUsePromptCache = boolPtr(val)
-	case "plan_act_separate_models_setting":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
-		}
-		settings.PlanActSeparateModelsSetting = boolPtr(val)
-	case "enable_checkpoints_setting":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
-		}
-		settings.EnableCheckpointsSetting = boolPtr(val)
-	case "sap_ai_core_use_orchestration_mode":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
-		}
-		settings.SapAiCoreUseOrchestrationMode = boolPtr(val)
-	case "strict_plan_mode_enabled":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
+// snakeToKebab converts snake_case to kebab-case
+func snakeToKebab(s string) string {
+	return strings.ReplaceAll(s, "_", "-")
+}
+
+// setSetting resolves a snake_case key against Secrets (top-level keys only) and Settings
+// and sets the field it refers to. Message fields are walked with dotted paths.
+func setSetting(settings, secrets protoreflect.Message, key, value string) error {
+	if target, ok := settingKeyAliases[key]; ok {
+		key = target
+	}
+
+	msg := settings
+	if !strings.Contains(key, ".") && secrets.Descriptor().Fields().ByName(protoreflect.Name(key)) != nil {
+		msg = secrets
+	}
+
+	path := strings.Split(key, ".")
+	for i, name := range path {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return unknownSettingError(key)
 		}
-		settings.StrictPlanModeEnabled = boolPtr(val)
-	case "yolo_mode_toggled":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
+
+		last := i == len(path)-1
+		if fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() {
+			if last {
+				return fmt.Errorf("setting '%s' groups several settings and requires nested dot notation (e.g., %s.<field>=value)",
+					snakeToKebab(key), snakeToKebab(key))
+			}
+			msg = msg.Mutable(fd).Message()
+			continue
 		}
-		settings.YoloModeToggled = boolPtr(val)
-	case "use_auto_condense":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
+
+		if !last {
+			return unknownSettingError(key)
 		}
-		settings.UseAutoCondense = boolPtr(val)
-	case "plan_mode_aws_bedrock_custom_selected":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
+
+		if err := setFieldValue(msg, fd, value); err != nil {
+			return fmt.Errorf("error setting field '%s': %w", snakeToKebab(key), err)
 		}
-		settings.PlanModeAwsBedrockCustomSelected = boolPtr(val)
-	case "act_mode_aws_bedrock_custom_selected":
-		val, err := parseBool(value)
-		if err != nil {
-			return err
+	}
+
+	return nil
+}
+
+// setFieldValue parses value according to the field's type and sets it on msg
+func setFieldValue(msg protoreflect.Message, fd protoreflect.FieldDescriptor, value string) error {
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Kind() == protoreflect.MessageKind {
+			return fmt.Errorf("maps of messages are not supported via -s flags")
+		}
+		// Each flag replaces the whole map
+		msg.Clear(fd)
+		entries := msg.Mutable(fd).Map()
+		for _, entry := range splitListValue(value) {
+			k, v, ok := strings.Cut(entry, ":")
+			if !ok {
+				return fmt.Errorf("invalid map entry '%s': expected key:value", entry)
+			}
+			mapKey, err := parseScalarValue(fd.MapKey(), strings.TrimSpace(k))
+			if err != nil {
+				return err
+			}
+			mapValue, err := parseScalarValue(fd.MapValue(), strings.TrimSpace(v))
+			if err != nil {
+				return err
+			}
+			entries.Set(mapKey.MapKey(), mapValue)
 		}
-		settings.ActModeAwsBedrockCustomSelected = boolPtr(val)
 
-	// Integer fields
-	case "request_timeout_ms":
-		val, err := parseInt32(value)
-		if err != nil {
-			return err
+	case fd.IsList():
+		if fd.Kind() == protoreflect.MessageKind {
+			return fmt.Errorf("lists of messages are not supported via -s flags")
 		}
-		settings.RequestTimeoutMs = int32Ptr(val)
-	case "shell_integration_timeout":
-		val, err := parseInt32(value)
-		if err != nil {
-			return err
+		// Each flag replaces the whole list
+		msg.Clear(fd)
+		list := msg.Mutable(fd).List()
+		for _, item := range splitListValue(value) {
+			val, err := parseScalarValue(fd, item)
+			if err != nil {
+				return err
+			}
+			list.Append(val)
 		}
-		settings.ShellIntegrationTimeout = int32Ptr(val)
-	case "terminal_output_line_limit":
-		val, err := parseInt32(value)
+
+	default:
+		val, err := parseScalarValue(fd, value)
 		if err != nil {
 			return err
 		}
-		settings.TerminalOutputLineLimit = int32Ptr(val)
-	case "max_consecutive_mistakes":
+		msg.Set(fd, val)
+	}
+
+	return nil
+}
+
+// parseScalarValue parses a single value for a scalar or enum field
+func parseScalarValue(fd protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(value)), nil
+	case protoreflect.BoolKind:
+		val, err := parseBool(value)
+		return protoreflect.ValueOfBool(val), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
 		val, err := parseInt32(value)
+		return protoreflect.ValueOfInt32(val), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		val, err := parseInt64(value)
+		return protoreflect.ValueOfInt64(val), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		val, err := strconv.ParseUint(value, 10, 32)
 		if err != nil {
-			return err
+			return protoreflect.Value{}, fmt.Errorf("invalid unsigned integer value '%s': %w", value, err)
 		}
-		settings.MaxConsecutiveMistakes = int32Ptr(val)
-	case "fireworks_model_max_completion_tokens":
-		val, err := parseInt32(value)
+		return protoreflect.ValueOfUint32(uint32(val)), nil
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		val, err := strconv.ParseUint(value, 10, 64)
 		if err != nil {
-			return err
+			return protoreflect.Value{}, fmt.Errorf("invalid unsigned integer value '%s': %w", value, err)
 		}
-		settings.FireworksModelMaxCompletionTokens = int32Ptr(val)
-	case "fireworks_model_max_tokens":
-		val, err := parseInt32(value)
+		return protoreflect.ValueOfUint64(val), nil
+	case protoreflect.FloatKind:
+		val, err := strconv.ParseFloat(value, 32)
 		if err != nil {
-			return err
+			return protoreflect.Value{}, fmt.Errorf("invalid float value '%s': %w", value, err)
 		}
-		settings.FireworksModelMaxTokens = int32Ptr(val)
+		return protoreflect.ValueOfFloat32(float32(val)), nil
+	case protoreflect.DoubleKind:
+		val, err := parseFloat64(value)
+		return protoreflect.ValueOfFloat64(val), err
+	case protoreflect.EnumKind:
+		val, err := parseEnumValue(fd.Enum(), value)
+		return protoreflect.ValueOfEnum(val), err
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field type %s", fd.Kind())
+	}
+}
 
-	// Int64 fields
-	case "plan_mode_thinking_budget_tokens":
-		val, err := parseInt64(value)
-		if err != nil {
-			return err
-		}
-		settings.PlanModeThinkingBudgetTokens = int64Ptr(val)
-	case "act_mode_thinking_budget_tokens":
-		val, err := parseInt64(value)
-		if err != nil {
-			return err
-		}
-		settings.ActModeThinkingBudgetTokens = int64Ptr(val)
+// parseEnumValue matches a value against an enum's value names, case-insensitively.
+// Dashes are treated as underscores, so provider IDs like openai-native work.
+func parseEnumValue(ed protoreflect.EnumDescriptor, value string) (protoreflect.EnumNumber, error) {
+	name := normalizeSettingKey(value)
+	if alias, ok := enumValueAliases[ed.FullName()][name]; ok {
+		name = alias
+	}
 
-	// Double fields
-	case "auto_condense_threshold":
-		val, err := parseFloat64(value)
-		if err != nil {
-			return err
+	if ev := ed.Values().ByName(protoreflect.Name(strings.ToUpper(name))); ev != nil {
+		return ev.Number(), nil
+	}
+
+	return 0, fmt.Errorf("invalid value '%s': expected one of %s", value, strings.Join(enumValueNames(ed), ", "))
+}
+
+// enumValueNames returns the lowercase names of an enum's values
+func enumValueNames(ed protoreflect.EnumDescriptor) []string {
+	values := ed.Values()
+	names := make([]string, values.Len())
+	for i := 0; i < values.Len(); i++ {
+		names[i] = strings.ToLower(string(values.Get(i).Name()))
+	}
+	return names
+}
+
+// splitListValue splits a comma-separated value, dropping empty items
+func splitListValue(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
 		}
-		settings.AutoCondenseThreshold = float64Ptr(val)
-
-	// Enum fields
-	// Note: We can use &val directly for enums because the parser functions return a new local variable.
-	// This is different from using &value (the loop variable), which would cause all fields to share
-	// the same memory address.
-	case "openai_reasoning_effort":
-		val, err := parseOpenaiReasoningEffort(value)
-		if err != nil {
-			return err
+	}
+	return items
+}
+
+// appendSettingKeys adds the keys for every field of md, walking into nested messages.
+// seen guards against recursive message types.
+func appendSettingKeys(keys []SettingKey, prefix string, md protoreflect.MessageDescriptor, secret bool, seen map[protoreflect.FullName]bool) []SettingKey {
+	if seen[md.FullName()] {
+		return keys
+	}
+	seen[md.FullName()] = true
+	defer delete(seen, md.FullName())
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		key := prefix + string(fd.Name())
+
+		if fd.Kind() == protoreflect.MessageKind && !fd.IsList() && !fd.IsMap() {
+			keys = appendSettingKeys(keys, key+".", fd.Message(), secret, seen)
+			continue
 		}
-		settings.OpenaiReasoningEffort = &val
-	case "mode":
-		val, err := parsePlanActMode(value)
-		if err != nil {
-			return err
+		if (fd.IsList() && fd.Kind() == protoreflect.MessageKind) || (fd.IsMap() && fd.MapValue().Kind() == protoreflect.MessageKind) {
+			continue
 		}
-		settings.Mode = &val
-	case "plan_mode_api_provider":
-		val, err := parseApiProvider(value)
-		if err != nil {
-			return err
+
+		settingKey := SettingKey{
+			Key:         snakeToKebab(key),
+			Type:        settingTypeName(fd),
+			Secret:      secret,
+			Description: describeSetting(key, secret),
 		}
-		settings.PlanModeApiProvider = &val
-	case "act_mode_api_provider":
-		val, err := parseApiProvider(value)
-		if err != nil {
-			return err
+		if fd.Kind() == protoreflect.EnumKind {
+			settingKey.Values = enumValueNames(fd.Enum())
 		}
-		settings.ActModeApiProvider = &val
+		keys = append(keys, settingKey)
+	}
+
+	return keys
+}
 
+// settingTypeName returns a short type name for a field, e.g. "bool" or "list<string>"
+func settingTypeName(fd protoreflect.FieldDescriptor) string {
+	switch {
+	case fd.IsMap():
+		return fmt.Sprintf("map<%s,%s>", fd.MapKey().Kind(), fd.MapValue().Kind())
+	case fd.IsList():
+		return fmt.Sprintf("list<%s>", fd.Kind())
 	default:
-		return fmt.Errorf("unsupported field '%s'", key)
+		return fd.Kind().String()
 	}
-
-	return nil
 }
 
-// setNestedField sets a nested field on Settings
-// Currently supports: auto_approval_settings, browser_settings
-func setNestedField(settings *clica.Settings, parentField string, childFields map[string]string) error {
-	switch parentField {
-	case "auto_approval_settings":
-		if settings.AutoApprovalSettings == nil {
-			settings.AutoApprovalSettings = &clica.AutoApprovalSettings{}
-		}
-		return setAutoApprovalSettings(settings.AutoApprovalSettings, childFields)
+// describeSetting returns the description for a snake_case key
+func describeSetting(key string, secret bool) string {
+	if description, ok := settingDescriptions[key]; ok {
+		return description
+	}
+	if target, ok := settingKeyAliases[key]; ok {
+		return "Alias for " + snakeToKebab(target)
+	}
 
-	case "browser_settings":
-		if settings.BrowserSettings == nil {
-			settings.BrowserSettings = &clica.BrowserSettings{}
-		}
-		return setBrowserSettings(settings.BrowserSettings, childFields)
+	name := key
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
 
-	default:
-		return fmt.Errorf("unsupported nested field '%s' (complex nested types are not supported via -s flags)", parentField)
+	suffix := ""
+	switch {
+	case secret:
+		suffix = " (stored as a secret)"
+	case strings.HasPrefix(name, "plan_mode_"):
+		name = strings.TrimPrefix(name, "plan_mode_")
+		suffix = " for plan mode"
+	case strings.HasPrefix(name, "act_mode_"):
+		name = strings.TrimPrefix(name, "act_mode_")
+		suffix = " for act mode"
 	}
-}
 
-// setAutoApprovalSettings sets fields on AutoApprovalSettings
-func setAutoApprovalSettings(settings *clica.AutoApprovalSettings, fields map[string]string) error {
-	for key, value := range fields {
-		switch key {
-		case "enabled":
-			val, err := parseBool(value)
-			if err != nil {
-				return err
-			}
-			settings.Enabled = val
-		case "max_requests":
-			val, err := parseInt32(value)
-			if err != nil {
-				return err
-			}
-			settings.MaxRequests = val
-		case "enable_notifications":
-			val, err := parseBool(value)
-			if err != nil {
-				return err
-			}
-			settings.EnableNotifications = val
-		case "actions":
-			return fmt.Errorf("auto_approval_settings.actions requires nested dot notation (e.g., auto-approval-settings.actions.read-files=true)")
-		default:
-			// Check if this is an action field (actions.*)
-			if strings.HasPrefix(key, "actions.") {
-				actionField := strings.TrimPrefix(key, "actions.")
-				if settings.Actions == nil {
-					settings.Actions = &clica.AutoApprovalActions{}
-				}
-				if err := setAutoApprovalAction(settings.Actions, actionField, value); err != nil {
-					return err
-				}
-				// Continue processing other fields
-			} else {
-				return fmt.Errorf("unsupported auto_approval_settings field '%s'", key)
-			}
-		}
+	words := strings.ReplaceAll(name, "_", " ")
+	if words == "" {
+		return ""
 	}
-	return nil
+	return strings.ToUpper(words[:1]) + words[1:] + suffix
 }
 
-// setAutoApprovalAction sets fields on AutoApprovalActions
-func setAutoApprovalAction(actions *clica.AutoApprovalActions, key, value string) error {
-	val, err := parseBool(value)
-	if err != nil {
-		return err
-	}
-
-	switch key {
-	case "read_files":
-		actions.ReadFiles = boolPtr(val)
-	case "read_files_externally":
-		actions.ReadFilesExternally = boolPtr(val)
-	case "edit_files":
-		actions.EditFiles = boolPtr(val)
-	case "edit_files_externally":
-		actions.EditFilesExternally = boolPtr(val)
-	case "execute_safe_commands":
-		actions.ExecuteSafeCommands = boolPtr(val)
-	case "execute_all_commands":
-		actions.ExecuteAllCommands = boolPtr(val)
-	case "use_browser":
-		actions.UseBrowser = boolPtr(val)
-	case "use_mcp":
-		actions.UseMcp = boolPtr(val)
-	default:
-		return fmt.Errorf("unsupported auto_approval_actions field '%s'", key)
+// unknownSettingError reports an unknown key, suggesting the closest valid one
+func unknownSettingError(key string) error {
+	display := snakeToKebab(key)
+	if suggestion := closestSettingKey(display); suggestion != "" {
+		return fmt.Errorf("unknown setting '%s' (did you mean '%s'?)", display, suggestion)
 	}
+	return fmt.Errorf("unknown setting '%s' (run 'clica config list-keys' to see all settings)", display)
+}
 
-	return nil
+// closestSettingKey returns the valid key closest to key by edit distance, or "" if none is close
+func closestSettingKey(key string) string {
+	best := ""
+	bestDistance := len(key)/3 + 2
+	for _, candidate := range ListSettingKeys() {
+		if distance := levenshtein(key, candidate.Key); distance < bestDistance {
+			best = candidate.Key
+			bestDistance = distance
+		}
+	}
+	return best
 }
 
-// setBrowserSettings sets fields on BrowserSettings
-func setBrowserSettings(settings *clica.BrowserSettings, fields map[string]string) error {
-	for key, value := range fields {
-		switch key {
-		case "viewport_width":
-			val, err := parseInt32(value)
-			if err != nil {
-				return err
-			}
-			if settings.Viewport == nil {
-				settings.Viewport = &clica.Viewport{}
-			}
-			settings.Viewport.Width = val
-		case "viewport_height":
-			val, err := parseInt32(value)
-			if err != nil {
-				return err
-			}
-			if settings.Viewport == nil {
-				settings.Viewport = &clica.Viewport{}
-			}
-			settings.Viewport.Height = val
-		case "remote_browser_host":
-			settings.RemoteBrowserHost = strPtr(value)
-		case "remote_browser_enabled":
-			val, err := parseBool(value)
-			if err != nil {
-				return err
-			}
-			settings.RemoteBrowserEnabled = boolPtr(val)
-		case "chrome_executable_path":
-			settings.ChromeExecutablePath = strPtr(value)
-		case "disable_tool_use":
-			val, err := parseBool(value)
-			if err != nil {
-				return err
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
 			}
-			settings.DisableToolUse = boolPtr(val)
-		case "custom_args":
-			settings.CustomArgs = strPtr(value)
-		default:
-			return fmt.Errorf("unsupported browser_settings field '%s'", key)
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
 		}
+		prev, curr = curr, prev
 	}
-	return nil
+
+	return prev[len(b)]
 }
 
 // Type parsing helpers
@@ -569,200 +538,3 @@ func parseFloat64(value string) (float64, error) {
 	}
 	return val, nil
 }
-
-// Enum parsing helpers
-func parseOpenaiReasoningEffort(value string) (clica.OpenaiReasoningEffort, error) {
-	lower := strings.ToLower(value)
-	switch lower {
-	case "low":
-		return clica.OpenaiReasoningEffort_LOW, nil
-	case "medium":
-		return clica.OpenaiReasoningEffort_MEDIUM, nil
-	case "high":
-		return clica.OpenaiReasoningEffort_HIGH, nil
-	default:
-		return clica.OpenaiReasoningEffort_LOW, fmt.Errorf("invalid openai_reasoning_effort '%s': expected low/medium/high", value)
-	}
-}
-
-func parsePlanActMode(value string) (clica.PlanActMode, error) {
-	lower := strings.ToLower(value)
-	switch lower {
-	case "plan":
-		return clica.PlanActMode_PLAN, nil
-	case "act":
-		return clica.PlanActMode_ACT, nil
-	default:
-		return clica.PlanActMode_ACT, fmt.Errorf("invalid mode '%s': expected plan/act", value)
-	}
-}
-
-func parseApiProvider(value string) (clica.ApiProvider, error) {
-	lower := strings.ToLower(value)
-	switch lower {
-	case "anthropic":
-		return clica.ApiProvider_ANTHROPIC, nil
-	case "openrouter":
-		return clica.ApiProvider_OPENROUTER, nil
-	case "bedrock":
-		return clica.ApiProvider_BEDROCK, nil
-	case "vertex":
-		return clica.ApiProvider_VERTEX, nil
-	case "openai":
-		return clica.ApiProvider_OPENAI, nil
-	case "ollama":
-		return clica.ApiProvider_OLLAMA, nil
-	case "lmstudio":
-		return clica.ApiProvider_LMSTUDIO, nil
-	case "gemini":
-		return clica.ApiProvider_GEMINI, nil
-	case "openai_native":
-		return clica.ApiProvider_OPENAI_NATIVE, nil
-	case "requesty":
-		return clica.ApiProvider_REQUESTY, nil
-	case "together":
-		return clica.ApiProvider_TOGETHER, nil
-	case "deepseek":
-		return clica.ApiProvider_DEEPSEEK, nil
-	case "qwen":
-		return clica.ApiProvider_QWEN, nil
-	case "doubao":
-		return clica.ApiProvider_DOUBAO, nil
-	case "mistral":
-		return clica.ApiProvider_MISTRAL, nil
-	case "vscode_lm":
-		return clica.ApiProvider_VSCODE_LM, nil
-	case "clica":
-		return clica.ApiProvider_CLICA, nil
-	case "litellm":
-		return clica.ApiProvider_LITELLM, nil
-	case "nebius":
-		return clica.ApiProvider_NEBIUS, nil
-	case "fireworks":
-		return clica.ApiProvider_FIREWORKS, nil
-	case "asksage":
-		return clica.ApiProvider_ASKSAGE, nil
-	case "xai", "grok":
-		return clica.ApiProvider_XAI, nil
-	case "sambanova":
-		return clica.ApiProvider_SAMBANOVA, nil
-	case "cerebras":
-		return clica.ApiProvider_CEREBRAS, nil
-	case "groq":
-		return clica.ApiProvider_GROQ, nil
-	case "sapaicore", "sap_ai_core":
-		return clica.ApiProvider_SAPAICORE, nil
-	case "claude_code":
-		return clica.ApiProvider_CLAUDE_CODE, nil
-	case "moonshot":
-		return clica.ApiProvider_MOONSHOT, nil
-	case "huggingface":
-		return clica.ApiProvider_HUGGINGFACE, nil
-	case "huawei_cloud_maas":
-		return clica.ApiProvider_HUAWEI_CLOUD_MAAS, nil
-	case "baseten":
-		return clica.ApiProvider_BASETEN, nil
-	case "zai":
-		return clica.ApiProvider_ZAI, nil
-	case "vercel_ai_gateway":
-		return clica.ApiProvider_VERCEL_AI_GATEWAY, nil
-	case "qwen_code":
-		return clica.ApiProvider_QWEN_CODE, nil
-	case "dify":
-		return clica.ApiProvider_DIFY, nil
-	case "oca":
-		return clica.ApiProvider_OCA, nil
-	default:
-		return clica.ApiProvider_ANTHROPIC, fmt.Errorf("invalid api_provider '%s'", value)
-	}
-}
-
-// setSecretField sets a secret field on Secrets
-// All secret fields are optional strings
-// Returns nil if field was successfully set, error otherwise
-func setSecretField(secrets *clica.Secrets, key, value string) error {
-	switch key {
-	case "api_key":
-		secrets.ApiKey = strPtr(value)
-	case "open_router_api_key":
-		secrets.OpenRouterApiKey = strPtr(value)
-	case "aws_access_key":
-		secrets.AwsAccessKey = strPtr(value)
-	case "aws_secret_key":
-		secrets.AwsSecretKey = strPtr(value)
-	case "aws_session_token":
-		secrets.AwsSessionToken = strPtr(value)
-	case "aws_bedrock_api_key":
-		secrets.AwsBedrockApiKey = strPtr(value)
-	case "open_ai_api_key":
-		secrets.OpenAiApiKey = strPtr(value)
-	case "gemini_api_key":
-		secrets.GeminiApiKey = strPtr(value)
-	case "open_ai_native_api_key":
-		secrets.OpenAiNativeApiKey = strPtr(value)
-	case "ollama_api_key":
-		secrets.OllamaApiKey = strPtr(value)
-	case "deep_seek_api_key":
-		secrets.DeepSeekApiKey = strPtr(value)
-	case "requesty_api_key":
-		secrets.RequestyApiKey = strPtr(value)
-	case "together_api_key":
-		secrets.TogetherApiKey = strPtr(value)
-	case "fireworks_api_key":
-		secrets.FireworksApiKey = strPtr(value)
-	case "qwen_api_key":
-		secrets.QwenApiKey = strPtr(value)
-	case "doubao_api_key":
-		secrets.DoubaoApiKey = strPtr(value)
-	case "mistral_api_key":
-		secrets.MistralApiKey = strPtr(value)
-	case "lite_llm_api_key":
-		secrets.LiteLlmApiKey = strPtr(value)
-	case "auth_nonce":
-		secrets.AuthNonce = strPtr(value)
-	case "asksage_api_key":
-		secrets.AsksageApiKey = strPtr(value)
-	case "xai_api_key":
-		secrets.XaiApiKey = strPtr(value)
-	case "moonshot_api_key":
-		secrets.MoonshotApiKey = strPtr(value)
-	case "zai_api_key":
-		secrets.ZaiApiKey = strPtr(value)
-	case "hugging_face_api_key":
-		secrets.HuggingFaceApiKey = strPtr(value)
-	case "nebius_api_key":
-		secrets.NebiusApiKey = strPtr(value)
-	case "sambanova_api_key":
-		secrets.SambanovaApiKey = strPtr(value)
-	case "cerebras_api_key":
-		secrets.CerebrasApiKey = strPtr(value)
-	case "sap_ai_core_client_id":
-		secrets.SapAiCoreClientId = strPtr(value)
-	case "sap_ai_core_client_secret":
-		secrets.SapAiCoreClientSecret = strPtr(value)
-	case "groq_api_key":
-		secrets.GroqApiKey = strPtr(value)
-	case "huawei_cloud_maas_api_key":
-		secrets.HuaweiCloudMaasApiKey = strPtr(value)
-	case "baseten_api_key":
-		secrets.BasetenApiKey = strPtr(value)
-	case "vercel_ai_gateway_api_key":
-		secrets.VercelAiGatewayApiKey = strPtr(value)
-	case "dify_api_key":
-		secrets.DifyApiKey = strPtr(value)
-	case "oca_api_key":
-		secrets.OcaApiKey = strPtr(value)
-	case "oca_refresh_token":
-		secrets.OcaRefreshToken = strPtr(value)
-	default:
-		return fmt.Errorf("unsupported secret field '%s'", key)
-	}
-
-	return nil
-}
-
-// Note: message types not supported via -s flags:
-// - OpenRouterModelInfo, OpenAiCompatibleModelInfo, LiteLLMModelInfo, OcaModelInfo
-// - LanguageModelChatSelector
-// - DictationSettings
-// - FocusChainSettings
@@ -0,0 +1,283 @@
+package task
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/clica/grpc-go/clica"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseTaskSettings(t *testing.T) {
+	provider := func(p clica.ApiProvider) *clica.ApiProvider { return &p }
+	mode := func(m clica.PlanActMode) *clica.PlanActMode { return &m }
+	effort := func(e clica.OpenaiReasoningEffort) *clica.OpenaiReasoningEffort { return &e }
+
+	tests := []struct {
+		name     string
+		flags    []string
+		settings *clica.Settings
+		secrets  *clica.Secrets
+	}{
+		{
+			name:     "string field",
+			flags:    []string{"aws-region=us-west-2"},
+			settings: &clica.Settings{AwsRegion: proto.String("us-west-2")},
+		},
+		{
+			name:     "snake case key",
+			flags:    []string{"open_ai_base_url=http://localhost:8080/v1"},
+			settings: &clica.Settings{OpenAiBaseUrl: proto.String("http://localhost:8080/v1")},
+		},
+		{
+			name:     "upper case key and spaces",
+			flags:    []string{" OLLAMA-BASE-URL = http://localhost:11434 "},
+			settings: &clica.Settings{OllamaBaseUrl: proto.String("http://localhost:11434")},
+		},
+		{
+			name:     "value containing =",
+			flags:    []string{"custom-prompt=a=b"},
+			settings: &clica.Settings{CustomPrompt: proto.String("a=b")},
+		},
+		{
+			name:     "bool field",
+			flags:    []string{"yolo-mode-toggled=true", "strict-plan-mode-enabled=no", "use-auto-condense=1"},
+			settings: &clica.Settings{YoloModeToggled: proto.Bool(true), StrictPlanModeEnabled: proto.Bool(false), UseAutoCondense: proto.Bool(true)},
+		},
+		{
+			name:     "integer fields",
+			flags:    []string{"request-timeout-ms=30000", "plan-mode-thinking-budget-tokens=8192"},
+			settings: &clica.Settings{RequestTimeoutMs: proto.Int32(30000), PlanModeThinkingBudgetTokens: proto.Int64(8192)},
+		},
+		{
+			name:     "double field",
+			flags:    []string{"auto-condense-threshold=0.75"},
+			settings: &clica.Settings{AutoCondenseThreshold: proto.Float64(0.75)},
+		},
+		{
+			name:     "mode",
+			flags:    []string{"mode=PLAN"},
+			settings: &clica.Settings{Mode: mode(clica.PlanActMode_PLAN)},
+		},
+		{
+			name:     "reasoning effort",
+			flags:    []string{"openai-reasoning-effort=high"},
+			settings: &clica.Settings{OpenaiReasoningEffort: effort(clica.OpenaiReasoningEffort_HIGH)},
+		},
+		{
+			name:     "provider",
+			flags:    []string{"plan-mode-api-provider=anthropic", "act-mode-api-provider=openrouter"},
+			settings: &clica.Settings{PlanModeApiProvider: provider(clica.ApiProvider_ANTHROPIC), ActModeApiProvider: provider(clica.ApiProvider_OPENROUTER)},
+		},
+		{
+			name:     "provider openai_native",
+			flags:    []string{"act-mode-api-provider=openai_native"},
+			settings: &clica.Settings{ActModeApiProvider: provider(clica.ApiProvider_OPENAI_NATIVE)},
+		},
+		{
+			name:     "provider openai-native",
+			flags:    []string{"act-mode-api-provider=openai-native"},
+			settings: &clica.Settings{ActModeApiProvider: provider(clica.ApiProvider_OPENAI_NATIVE)},
+		},
+		{
+			name:     "provider alias grok",
+			flags:    []string{"act-mode-api-provider=grok"},
+			settings: &clica.Settings{ActModeApiProvider: provider(clica.ApiProvider_XAI)},
+		},
+		{
+			name:     "provider alias sap_ai_core",
+			flags:    []string{"plan-mode-api-provider=sap_ai_core", "act-mode-api-provider=sapaicore"},
+			settings: &clica.Settings{PlanModeApiProvider: provider(clica.ApiProvider_SAPAICORE), ActModeApiProvider: provider(clica.ApiProvider_SAPAICORE)},
+		},
+		{
+			name:     "provider claude_code",
+			flags:    []string{"act-mode-api-provider=claude-code"},
+			settings: &clica.Settings{ActModeApiProvider: provider(clica.ApiProvider_CLAUDE_CODE)},
+		},
+		{
+			name:    "secrets",
+			flags:   []string{"api-key=sk-ant", "open_router_api_key=sk-or", "sap-ai-core-client-secret=s3cret"},
+			secrets: &clica.Secrets{ApiKey: proto.String("sk-ant"), OpenRouterApiKey: proto.String("sk-or"), SapAiCoreClientSecret: proto.String("s3cret")},
+		},
+		{
+			name:  "auto approval settings",
+			flags: []string{"auto-approval-settings.enabled=true", "auto-approval-settings.max-requests=20", "auto_approval_settings.enable_notifications=false"},
+			settings: &clica.Settings{AutoApprovalSettings: &clica.AutoApprovalSettings{
+				Enabled:             true,
+				MaxRequests:         20,
+				EnableNotifications: false,
+			}},
+		},
+		{
+			name:  "auto approval actions",
+			flags: []string{"auto-approval-settings.actions.read-files=true", "auto-approval-settings.actions.execute-all-commands=false", "auto-approval-settings.actions.use-mcp=yes"},
+			settings: &clica.Settings{AutoApprovalSettings: &clica.AutoApprovalSettings{Actions: &clica.AutoApprovalActions{
+				ReadFiles:          proto.Bool(true),
+				ExecuteAllCommands: proto.Bool(false),
+				UseMcp:             proto.Bool(true),
+			}}},
+		},
+		{
+			name:     "list field",
+			flags:    []string{"auto-approval-settings.favorites=readFiles, editFiles,"},
+			settings: &clica.Settings{AutoApprovalSettings: &clica.AutoApprovalSettings{Favorites: []string{"readFiles", "editFiles"}}},
+		},
+		{
+			name:     "list field replaced by a later flag",
+			flags:    []string{"auto-approval-settings.favorites=readFiles", "auto-approval-settings.favorites=useMcp"},
+			settings: &clica.Settings{AutoApprovalSettings: &clica.AutoApprovalSettings{Favorites: []string{"useMcp"}}},
+		},
+		{
+			name:  "browser settings",
+			flags: []string{"browser-settings.viewport-width=1280", "browser-settings.viewport-height=720", "browser-settings.remote-browser-enabled=true", "browser-settings.custom-args=--headless"},
+			settings: &clica.Settings{BrowserSettings: &clica.BrowserSettings{
+				Viewport:             &clica.Viewport{Width: 1280, Height: 720},
+				RemoteBrowserEnabled: proto.Bool(true),
+				CustomArgs:           proto.String("--headless"),
+			}},
+		},
+		{
+			name:     "browser viewport by path",
+			flags:    []string{"browser-settings.viewport.width=800"},
+			settings: &clica.Settings{BrowserSettings: &clica.BrowserSettings{Viewport: &clica.Viewport{Width: 800}}},
+		},
+		{
+			name:     "settings and secrets together",
+			flags:    []string{"mode=act", "api-key=sk-ant"},
+			settings: &clica.Settings{Mode: mode(clica.PlanActMode_ACT)},
+			secrets:  &clica.Secrets{ApiKey: proto.String("sk-ant")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, secrets, err := ParseTaskSettings(tt.flags)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			wantSettings, wantSecrets := tt.settings, tt.secrets
+			if wantSettings == nil {
+				wantSettings = &clica.Settings{}
+			}
+			if wantSecrets == nil {
+				wantSecrets = &clica.Secrets{}
+			}
+			if !proto.Equal(settings, wantSettings) {
+				t.Errorf("settings:\ngot  %v\nwant %v", settings, wantSettings)
+			}
+			if !proto.Equal(secrets, wantSecrets) {
+				t.Errorf("secrets:\ngot  %v\nwant %v", secrets, wantSecrets)
+			}
+		})
+	}
+}
+
+func TestParseTaskSettingsNoFlags(t *testing.T) {
+	settings, secrets, err := ParseTaskSettings(nil)
+	if settings != nil || secrets != nil || err != nil {
+		t.Errorf("got %v, %v, %v, want all nil", settings, secrets, err)
+	}
+}
+
+func TestParseTaskSettingsErrors(t *testing.T) {
+	tests := []struct {
+		flag string
+		want string
+	}{
+		{"mode", "invalid setting format 'mode': expected key=value"},
+		{"mdoe=act", "unknown setting 'mdoe' (did you mean 'mode'?)"},
+		{"auto-aproval-settings.enabled=true", "did you mean 'auto-approval-settings.enabled'?"},
+		{"completely-unrelated-setting-name=1", "run 'clica config list-keys' to see all settings"},
+		{"yolo-mode-toggled=maybe", "invalid boolean value 'maybe': expected true/false"},
+		{"request-timeout-ms=soon", "invalid integer value 'soon'"},
+		{"request-timeout-ms=99999999999", "invalid integer value '99999999999'"},
+		{"auto-condense-threshold=high", "invalid float value 'high'"},
+		{"mode=build", "invalid value 'build': expected one of"},
+		{"act-mode-api-provider=acme", "invalid value 'acme': expected one of"},
+		{"auto-approval-settings=true", "setting 'auto-approval-settings' groups several settings and requires nested dot notation (e.g., auto-approval-settings.<field>=value)"},
+		{"auto-approval-settings.actions=true", "requires nested dot notation"},
+		{"auto-approval-settings.actions.fly=true", "unknown setting 'auto-approval-settings.actions.fly'"},
+		{"mode.plan=true", "unknown setting 'mode.plan'"},
+		{"api-key.value=x", "unknown setting 'api-key.value'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.flag, func(t *testing.T) {
+			_, _, err := ParseTaskSettings([]string{tt.flag})
+			if err == nil {
+				t.Fatalf("expected an error containing %q", tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("got error %q, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetFieldValueMap(t *testing.T) {
+	toggles := &clica.ClicaRulesToggles{Toggles: map[string]bool{"old.md": true}}
+	fd := toggles.ProtoReflect().Descriptor().Fields().ByName("toggles")
+
+	if err := setFieldValue(toggles.ProtoReflect(), fd, "rules.md:true, style.md:no"); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"rules.md": true, "style.md": false}; len(toggles.Toggles) != len(want) ||
+		toggles.Toggles["rules.md"] != true || toggles.Toggles["style.md"] != false {
+		t.Errorf("got %v, want %v replacing the old entries", toggles.Toggles, want)
+	}
+
+	for value, want := range map[string]string{
+		"rules.md":       "invalid map entry 'rules.md': expected key:value",
+		"rules.md:maybe": "invalid boolean value 'maybe'",
+	} {
+		if err := setFieldValue(toggles.ProtoReflect(), fd, value); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: got error %v, want it to contain %q", value, err, want)
+		}
+	}
+}
+
+func TestIsSecretSetting(t *testing.T) {
+	for key, want := range map[string]bool{
+		"api-key":                        true,
+		"open_router_api_key":            true,
+		"aws-region":                     false,
+		"auto-approval-settings.enabled": false,
+		"no-such-setting":                false,
+	} {
+		if got := IsSecretSetting(key); got != want {
+			t.Errorf("IsSecretSetting(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestListSettingKeys(t *testing.T) {
+	keys := make(map[string]SettingKey)
+	for _, key := range ListSettingKeys() {
+		keys[key.Key] = key
+	}
+
+	// Every key the hand-written parser accepted is still listed
+	for _, name := range []string{
+		"mode", "api-key", "aws-region", "act-mode-api-provider", "request-timeout-ms",
+		"auto-approval-settings.enabled", "auto-approval-settings.actions.read-files",
+		"browser-settings.viewport-width", "browser-settings.custom-args", "sap-ai-core-client-secret",
+	} {
+		if _, ok := keys[name]; !ok {
+			t.Errorf("%s isn't listed", name)
+		}
+	}
+
+	if key := keys["api-key"]; !key.Secret {
+		t.Errorf("api-key isn't marked as a secret")
+	}
+	if key := keys["mode"]; key.Type != "enum" || strings.Join(key.Values, ",") != "plan,act" {
+		t.Errorf("got mode %+v, want an enum of plan and act", key)
+	}
+	if key := keys["auto-approval-settings.favorites"]; key.Type != "list<string>" {
+		t.Errorf("got favorites type %q, want list<string>", key.Type)
+	}
+	if key := keys["browser-settings.viewport-width"]; key.Description != "Browser viewport width in pixels" {
+		t.Errorf("got viewport-width description %q", key.Description)
+	}
+}
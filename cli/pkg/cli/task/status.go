@@ -0,0 +1,94 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/clica/cli/pkg/cli/types"
+)
+
+// ErrDetached is returned by FollowConversation when the user detaches with Ctrl+\.
+// The task keeps running on its instance.
+var ErrDetached = errors.New("detached from task")
+
+// Task statuses reported by 'clica ps'
+const (
+	StatusStreaming       = "streaming"        // the model or a command is working
+	StatusWaitingApproval = "waiting_approval" // a tool, command or MCP call needs approval
+	StatusWaitingInput    = "waiting_input"    // a question or plan awaits a reply
+	StatusFailed          = "failed"           // stopped on an error that needs a retry
+	StatusCompleted       = "completed"        // finished with a completion result
+)
+
+// TaskStatus summarizes the current task of an instance
+type TaskStatus struct {
+	TaskID       string    `json:"task_id"`
+	Status       string    `json:"status"`
+	Task         string    `json:"task"`
+	LastActivity time.Time `json:"last_activity"`
+}
+
+// TaskStatusFromState returns the status of the current task in a state JSON, or nil if there is none
+func TaskStatusFromState(stateJSON string) (*TaskStatus, error) {
+	var state types.ExtensionState
+	if err := json.Unmarshal([]byte(stateJSON), &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state: %w", err)
+	}
+	if state.CurrentTaskItem == nil || state.CurrentTaskItem.Id == "" {
+		return nil, nil
+	}
+
+	messages, err := types.ExtractMessagesFromStateJSON(stateJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract messages: %w", err)
+	}
+
+	status := &TaskStatus{
+		TaskID: state.CurrentTaskItem.Id,
+		Status: messagesStatus(messages),
+	}
+	for _, msg := range messages {
+		if msg.Type == types.MessageTypeSay && msg.Say == string(types.SayTypeTask) {
+			status.Task = msg.Text
+			break
+		}
+	}
+	if len(messages) > 0 {
+		status.LastActivity = time.UnixMilli(messages[len(messages)-1].Timestamp)
+	}
+	return status, nil
+}
+
+// messagesStatus derives a task status from its last message, following the same rules
+// as CheckSendEnabled and CheckNeedsApproval
+func messagesStatus(messages []*types.ClicaMessage) string {
+	if len(messages) == 0 {
+		return StatusStreaming
+	}
+
+	last := messages[len(messages)-1]
+	if last.Partial || last.Type != types.MessageTypeAsk {
+		if last.Type == types.MessageTypeSay && last.Say == string(types.SayTypeCompletionResult) && !last.Partial {
+			return StatusCompleted
+		}
+		return StatusStreaming
+	}
+
+	switch types.AskType(last.Ask) {
+	case types.AskTypeTool, types.AskTypeCommand, types.AskTypeBrowserActionLaunch, types.AskTypeUseMcpServer:
+		return StatusWaitingApproval
+	case types.AskTypeCompletionResult, types.AskTypeResumeCompletedTask:
+		return StatusCompleted
+	case types.AskTypeCommandOutput:
+		return StatusStreaming
+	}
+
+	failed := []types.AskType{types.AskTypeAPIReqFailed, types.AskTypeMistakeLimitReached, types.AskTypeAutoApprovalMaxReached}
+	if slices.Contains(failed, types.AskType(last.Ask)) {
+		return StatusFailed
+	}
+	return StatusWaitingInput
+}
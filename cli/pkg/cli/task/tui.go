@@ -0,0 +1,584 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/clica/cli/pkg/cli/global"
+	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/theme"
+	"github.com/clica/cli/pkg/cli/types"
+	"github.com/clica/grpc-go/clica"
+)
+
+// TUIOptions configures a full-screen task session
+type TUIOptions struct {
+	// Images, files and settings for the task created from the first message,
+	// when the instance has no task yet
+	Images   []string
+	Files    []string
+	Settings []string
+}
+
+// Messages sent to the TUI program
+type (
+	tuiStateMsg   struct{ stateJSON string }
+	tuiPartialMsg struct{ msg *types.ClicaMessage }
+	tuiStreamErr  struct{ err error }
+	tuiSentMsg    struct {
+		err    error
+		notice string
+	}
+	tuiModelLabelMsg struct{ mode, label string }
+)
+
+// tuiBlock is one rendered message in the conversation viewport
+type tuiBlock struct {
+	ts       int64
+	tool     bool   // tool, command, browser or MCP message, collapsible and listed in the tool panel
+	title    string // first line, shown when collapsed
+	rendered string
+}
+
+// tuiRender caches a message rendered by the handlers
+type tuiRender struct {
+	key string
+	out string
+}
+
+// tuiModel is the bubbletea model of the full-screen session
+type tuiModel struct {
+	ctx     context.Context
+	manager *Manager
+	address string
+	opts    TUIOptions
+
+	viewport viewport.Model
+	input    textarea.Model
+	width    int
+	height   int
+
+	messages []*types.ClicaMessage
+	hasTask  bool
+	status   string
+	usage    types.TaskUsage
+	mode     string
+	model    string
+	labelFor string // mode the model label was fetched for
+
+	blocks   []*tuiBlock
+	offsets  []int // first viewport line of each block
+	renders  map[int]tuiRender
+	expanded map[int64]bool // tool blocks the user expanded, by message timestamp
+
+	selected   int  // selected block when navigating, -1 for none
+	focusInput bool // keys go to the input box, otherwise to the conversation
+	follow     bool // keep the viewport scrolled to the newest message
+	showTools  bool
+	notice     string
+
+	detached bool
+	err      error
+}
+
+// RunTUI runs a full-screen session on the instance's current task, with a scrollable conversation,
+// a tool panel, a status bar and a persistent input box. Returns ErrDetached if the user detached.
+func (m *Manager) RunTUI(ctx context.Context, instanceAddress string, opts TUIOptions) error {
+	if global.Config.Accessible {
+		return fmt.Errorf("the full-screen interface can't be used with --accessible")
+	}
+
+	// Messages are rendered whole by the handlers, not streamed segment by segment
+	m.mu.Lock()
+	m.isStreamingMode = false
+	m.isInteractive = true
+	m.detached = false
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	applyTUITheme(theme.Current())
+	model := newTUIModel(ctx, m, instanceAddress, opts)
+	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithContext(ctx))
+
+	go m.streamStateToTUI(ctx, program)
+	go m.streamPartialsToTUI(ctx, program)
+
+	final, err := program.Run()
+	if err != nil && !errors.Is(err, tea.ErrProgramKilled) {
+		return fmt.Errorf("TUI failed: %w", err)
+	}
+
+	result, ok := final.(*tuiModel)
+	if !ok {
+		return nil
+	}
+	if result.detached {
+		m.markDetached()
+		return ErrDetached
+	}
+	return result.err
+}
+
+// streamStateToTUI forwards state updates to the TUI
+func (m *Manager) streamStateToTUI(ctx context.Context, program *tea.Program) {
+	stream, err := m.client.State.SubscribeToState(ctx, &clica.EmptyRequest{})
+	if err != nil {
+		program.Send(tuiStreamErr{fmt.Errorf("failed to subscribe to state: %w", err)})
+		return
+	}
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				program.Send(tuiStreamErr{fmt.Errorf("failed to receive state update: %w", err)})
+			}
+			return
+		}
+		currentRecorder().recordState(StreamState, m.GetCurrentInstance(), update.StateJson)
+		program.Send(tuiStateMsg{stateJSON: update.StateJson})
+	}
+}
+
+// streamPartialsToTUI forwards streamed message updates to the TUI
+func (m *Manager) streamPartialsToTUI(ctx context.Context, program *tea.Program) {
+	stream, err := m.client.Ui.SubscribeToPartialMessage(ctx, &clica.EmptyRequest{})
+	if err != nil {
+		program.Send(tuiStreamErr{fmt.Errorf("failed to subscribe to partial messages: %w", err)})
+		return
+	}
+
+	for {
+		protoMsg, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				program.Send(tuiStreamErr{fmt.Errorf("failed to receive partial message: %w", err)})
+			}
+			return
+		}
+		msg := types.ConvertProtoToMessage(protoMsg)
+		currentRecorder().recordPartial(m.GetCurrentInstance(), msg)
+		program.Send(tuiPartialMsg{msg: msg})
+	}
+}
+
+func newTUIModel(ctx context.Context, manager *Manager, address string, opts TUIOptions) *tuiModel {
+	input := textarea.New()
+	input.ShowLineNumbers = false
+	input.Prompt = ""
+	input.CharLimit = 0
+	input.SetHeight(tuiInputHeight)
+	input.KeyMap.InsertNewline = key.NewBinding(key.WithKeys("alt+enter", "ctrl+j"))
+	input.Focus()
+
+	return &tuiModel{
+		ctx:        ctx,
+		manager:    manager,
+		address:    address,
+		opts:       opts,
+		viewport:   viewport.New(0, 0),
+		input:      input,
+		mode:       manager.GetCurrentMode(),
+		renders:    make(map[int]tuiRender),
+		expanded:   make(map[int64]bool),
+		selected:   -1,
+		focusInput: true,
+		follow:     true,
+		showTools:  true,
+	}
+}
+
+func (t *tuiModel) Init() tea.Cmd {
+	return tea.Batch(textarea.Blink, t.fetchState(), t.fetchModelLabel())
+}
+
+// fetchState loads the current state, so the conversation shows before the first update arrives
+func (t *tuiModel) fetchState() tea.Cmd {
+	client := t.manager.GetClient()
+	ctx := t.ctx
+	return func() tea.Msg {
+		state, err := client.State.GetLatestState(ctx, &clica.EmptyRequest{})
+		if err != nil {
+			return tuiSentMsg{err: fmt.Errorf("failed to get state: %w", err)}
+		}
+		currentRecorder().recordState(StreamHistory, t.address, state.StateJson)
+		return tuiStateMsg{stateJSON: state.StateJson}
+	}
+}
+
+// fetchModelLabel looks up the model of the current mode for the status bar
+func (t *tuiModel) fetchModelLabel() tea.Cmd {
+	labeler := t.manager.GetModelLabeler()
+	if labeler == nil || t.labelFor == t.mode {
+		return nil
+	}
+	t.labelFor = t.mode
+
+	ctx, mode := t.ctx, t.mode
+	return func() tea.Msg {
+		return tuiModelLabelMsg{mode: mode, label: labeler(ctx, mode)}
+	}
+}
+
+func (t *tuiModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		t.width, t.height = msg.Width, msg.Height
+		t.renders = make(map[int]tuiRender) // markdown wraps to the terminal width
+		t.rebuild()
+		return t, nil
+
+	case tuiStateMsg:
+		t.applyState(msg.stateJSON)
+		return t, t.fetchModelLabel()
+
+	case tuiPartialMsg:
+		t.applyPartial(msg.msg)
+		return t, nil
+
+	case tuiModelLabelMsg:
+		if msg.mode == t.mode {
+			t.model = msg.label
+		}
+		return t, nil
+
+	case tuiSentMsg:
+		t.notice = msg.notice
+		if msg.err != nil {
+			t.notice = "Error: " + msg.err.Error()
+		}
+		return t, nil
+
+	case tuiStreamErr:
+		t.err = msg.err
+		return t, tea.Quit
+
+	case tea.MouseMsg:
+		var cmd tea.Cmd
+		t.viewport, cmd = t.viewport.Update(msg)
+		t.follow = t.viewport.AtBottom()
+		return t, cmd
+
+	case tea.KeyMsg:
+		return t.handleKey(msg)
+	}
+
+	var cmd tea.Cmd
+	t.input, cmd = t.input.Update(msg)
+	return t, cmd
+}
+
+// handleKey handles global keys, then conversation navigation or input editing
+func (t *tuiModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		if t.status == StatusStreaming {
+			t.notice = "Cancelling task..."
+			return t, t.send(func(ctx context.Context) error { return t.manager.CancelTask(ctx) }, "Task cancelled")
+		}
+		return t, tea.Quit
+	case "ctrl+d":
+		return t, tea.Quit
+	case "ctrl+\\":
+		// Leave the task running on its instance
+		t.detached = true
+		return t, tea.Quit
+	case "tab":
+		t.setFocusInput(!t.focusInput)
+		return t, nil
+	case "ctrl+t":
+		t.showTools = !t.showTools
+		t.layout()
+		return t, nil
+	case "pgup", "pgdown":
+		var cmd tea.Cmd
+		t.viewport, cmd = t.viewport.Update(msg)
+		t.follow = t.viewport.AtBottom()
+		return t, cmd
+	}
+
+	if !t.focusInput {
+		return t, t.handleNavigationKey(msg)
+	}
+
+	// Alt+Enter arrives as an Enter key too, and inserts a newline
+	if msg.String() == "enter" {
+		return t, t.submit()
+	}
+
+	var cmd tea.Cmd
+	t.input, cmd = t.input.Update(msg)
+	return t, cmd
+}
+
+// handleNavigationKey moves between messages and expands or collapses tool output
+func (t *tuiModel) handleNavigationKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "k", "shift+tab":
+		if t.selected < 0 {
+			t.selectBlock(len(t.blocks) - 1)
+		} else {
+			t.selectBlock(t.selected - 1)
+		}
+	case "down", "j":
+		if t.selected >= 0 && t.selected < len(t.blocks)-1 {
+			t.selectBlock(t.selected + 1)
+		}
+	case "home", "g":
+		t.selectBlock(0)
+	case "end", "G":
+		t.selected = -1
+		t.follow = true
+		t.refreshViewport()
+	case "enter", " ":
+		if t.selected >= 0 && t.selected < len(t.blocks) && t.blocks[t.selected].tool {
+			ts := t.blocks[t.selected].ts
+			t.expanded[ts] = !t.expanded[ts]
+			t.refreshViewport()
+			t.viewport.SetYOffset(t.offsets[t.selected])
+		}
+	case "esc", "i":
+		t.setFocusInput(true)
+	}
+	return nil
+}
+
+func (t *tuiModel) setFocusInput(focus bool) {
+	t.focusInput = focus
+	if focus {
+		t.input.Focus()
+		t.selected = -1
+		t.follow = true
+	} else {
+		t.input.Blur()
+		if t.selected < 0 {
+			t.selected = len(t.blocks) - 1
+		}
+		t.follow = false
+	}
+	t.refreshViewport()
+	if !focus && t.selected >= 0 {
+		t.viewport.SetYOffset(t.offsets[t.selected])
+	}
+}
+
+// selectBlock selects a message and scrolls to it
+func (t *tuiModel) selectBlock(i int) {
+	if len(t.blocks) == 0 {
+		return
+	}
+	t.selected = max(0, min(i, len(t.blocks)-1))
+	t.follow = false
+	t.refreshViewport()
+	t.viewport.SetYOffset(t.offsets[t.selected])
+}
+
+// submit handles the input box: slash commands, approvals, the first task message and replies
+func (t *tuiModel) submit() tea.Cmd {
+	text := strings.TrimSpace(t.input.Value())
+	approvalPending := t.hasTask && t.status == StatusWaitingApproval
+	if text == "" {
+		// An approval is only answered explicitly, Enter alone doesn't deny it
+		if approvalPending {
+			t.notice = "Type y to approve, or n or feedback to deny, then press Enter"
+		}
+		return nil
+	}
+
+	switch strings.ToLower(text) {
+	case "/exit", "/quit":
+		return tea.Quit
+	case "/cancel":
+		t.input.Reset()
+		return t.send(func(ctx context.Context) error { return t.manager.CancelTask(ctx) }, "Task cancelled")
+	case "/model":
+		t.notice = "Switch models with /model outside --tui"
+		return nil
+	}
+
+	if newMode, message, ok := parseModeSwitch(text); ok {
+		t.input.Reset()
+		return t.send(func(ctx context.Context) error {
+			if message == "" || newMode == "act" {
+				var msg *string
+				if message != "" {
+					msg = &message
+				}
+				return t.manager.SetMode(ctx, newMode, msg, nil, nil)
+			}
+			// Plan mode: switch first, then send the message separately
+			if err := t.manager.SetMode(ctx, newMode, nil, nil, nil); err != nil {
+				return err
+			}
+			time.Sleep(500 * time.Millisecond)
+			return t.manager.SendMessage(ctx, message, nil, nil, "")
+		}, fmt.Sprintf("Switched to %s mode", newMode))
+	}
+
+	if !t.hasTask {
+		images, files, settings := t.opts.Images, t.opts.Files, t.opts.Settings
+		t.opts.Images, t.opts.Files = nil, nil
+		t.input.Reset()
+		t.follow = true
+		return t.send(func(ctx context.Context) error {
+			_, err := t.manager.CreateTask(ctx, text, images, files, settings)
+			return err
+		}, "")
+	}
+
+	approve := ""
+	switch {
+	case approvalPending:
+		switch strings.ToLower(text) {
+		case "y", "yes":
+			approve, text = "true", ""
+		case "n", "no":
+			approve, text = "false", ""
+		default:
+			// Anything else denies the action with the text as feedback
+			approve = "false"
+		}
+	case t.status == StatusStreaming:
+		t.notice = "Clica is working. Wait for it to finish, or press Ctrl+C to cancel"
+		return nil
+	}
+
+	t.input.Reset()
+	t.follow = true
+	return t.send(func(ctx context.Context) error {
+		return t.manager.SendMessage(ctx, text, nil, nil, approve)
+	}, "")
+}
+
+// send runs a request to the instance in the background and reports its outcome in the status bar
+func (t *tuiModel) send(fn func(ctx context.Context) error, notice string) tea.Cmd {
+	ctx := t.ctx
+	return func() tea.Msg {
+		if err := fn(ctx); err != nil {
+			return tuiSentMsg{err: err}
+		}
+		return tuiSentMsg{notice: notice}
+	}
+}
+
+// applyState replaces the conversation with the messages of a state update
+func (t *tuiModel) applyState(stateJSON string) {
+	messages, err := types.ExtractMessagesFromStateJSON(stateJSON)
+	if err != nil {
+		return
+	}
+
+	var state types.ExtensionState
+	if err := json.Unmarshal([]byte(stateJSON), &state); err == nil {
+		t.hasTask = state.CurrentTaskItem != nil && state.CurrentTaskItem.Id != ""
+	}
+
+	t.manager.updateMode(stateJSON)
+	updateWorkspaceRoot(stateJSON)
+	t.mode = t.manager.GetCurrentMode()
+	t.messages = messages
+	t.rebuild()
+}
+
+// applyPartial updates a streaming message in place, ahead of the next state update
+func (t *tuiModel) applyPartial(msg *types.ClicaMessage) {
+	for i := len(t.messages) - 1; i >= 0; i-- {
+		if t.messages[i].Timestamp == msg.Timestamp {
+			t.messages[i] = msg
+			t.rebuild()
+			return
+		}
+	}
+	if len(t.messages) == 0 || msg.Timestamp > t.messages[len(t.messages)-1].Timestamp {
+		t.messages = append(t.messages, msg)
+		t.rebuild()
+	}
+}
+
+// rebuild re-derives the task status and the message blocks
+func (t *tuiModel) rebuild() {
+	t.status = ""
+	if t.hasTask {
+		t.status = messagesStatus(t.messages)
+	}
+	t.usage = types.UsageFromMessages(t.messages)
+
+	t.blocks = t.blocks[:0]
+	for i, msg := range t.messages {
+		rendered := strings.Trim(t.render(msg, i), "\n")
+		if strings.TrimSpace(ansi.Strip(rendered)) == "" {
+			continue
+		}
+		t.blocks = append(t.blocks, &tuiBlock{
+			ts:       msg.Timestamp,
+			tool:     isToolMessage(msg),
+			title:    blockTitle(rendered),
+			rendered: rendered,
+		})
+	}
+	if t.selected >= len(t.blocks) {
+		t.selected = len(t.blocks) - 1
+	}
+
+	t.layout()
+}
+
+// render renders a message with the handlers, reusing the last rendering if it hasn't changed
+func (t *tuiModel) render(msg *types.ClicaMessage, index int) string {
+	// Handlers render the last message differently, e.g. a pending ask
+	isLast := index == len(t.messages)-1
+	cacheKey := fmt.Sprintf("%d:%d:%t:%t:%s", msg.Timestamp, len(msg.Text), msg.Partial, isLast, msg.Ask)
+	if cached, ok := t.renders[index]; ok && cached.key == cacheKey {
+		return cached.out
+	}
+
+	out := output.Capture(func() {
+		if err := t.manager.displayMessage(msg, isLast, msg.Partial, index); err != nil && global.Config.Verbose {
+			output.Printf("Error rendering message: %v\n", err)
+		}
+	})
+	t.renders[index] = tuiRender{key: cacheKey, out: out}
+	return out
+}
+
+// isToolMessage reports whether a message belongs in the tool panel
+func isToolMessage(msg *types.ClicaMessage) bool {
+	if msg.Type == types.MessageTypeAsk {
+		switch types.AskType(msg.Ask) {
+		case types.AskTypeTool, types.AskTypeCommand, types.AskTypeCommandOutput,
+			types.AskTypeBrowserActionLaunch, types.AskTypeUseMcpServer:
+			return true
+		}
+		return false
+	}
+
+	switch types.SayType(msg.Say) {
+	case types.SayTypeTool, types.SayTypeCommand, types.SayTypeCommandOutput,
+		types.SayTypeBrowserActionLaunch, types.SayTypeBrowserAction, types.SayTypeBrowserActionResult,
+		types.SayTypeMcpServerRequestStarted, types.SayTypeMcpServerResponse,
+		types.SayTypeUseMcpServer, types.SayTypeMcpNotification:
+		return true
+	}
+	return false
+}
+
+// blockTitle is the first non-empty line of a rendered message, without styling
+func blockTitle(rendered string) string {
+	for _, line := range strings.Split(ansi.Strip(rendered), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
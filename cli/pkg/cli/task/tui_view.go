@@ -0,0 +1,241 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/clica/cli/pkg/cli/theme"
+)
+
+const (
+	// tuiInputHeight is the number of text rows of the input box
+	tuiInputHeight = 3
+	// tuiMaxToolRows caps how many tool calls the tool panel lists
+	tuiMaxToolRows = 6
+)
+
+var (
+	tuiBorderColor   lipgloss.TerminalColor
+	tuiAccentColor   lipgloss.TerminalColor
+	tuiSelectedStyle lipgloss.Style
+	tuiDimStyle      lipgloss.Style
+	tuiStatusStyle   lipgloss.Style
+	tuiPlanBadge     lipgloss.Style
+	tuiActBadge      lipgloss.Style
+	tuiPanelStyle    lipgloss.Style
+)
+
+// applyTUITheme sets the TUI styles from the selected theme
+func applyTUITheme(t *theme.Theme) {
+	badge := t.Style(t.Badge).Bold(true).Padding(0, 1)
+
+	tuiBorderColor = t.Border.Lipgloss()
+	tuiAccentColor = t.Accent.Lipgloss()
+	tuiSelectedStyle = t.Style(t.Accent).Bold(true)
+	tuiDimStyle = t.Style(t.Dim)
+	tuiStatusStyle = t.Style(t.Text).Background(t.StatusBar.Lipgloss())
+	tuiPlanBadge = badge.Background(t.Plan.Lipgloss())
+	tuiActBadge = badge.Background(t.Act.Lipgloss())
+	tuiPanelStyle = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(tuiBorderColor)
+}
+
+// layout sizes the viewport, tool panel and input box to the window
+func (t *tuiModel) layout() {
+	if t.width == 0 || t.height == 0 {
+		return
+	}
+
+	t.input.SetWidth(t.width - 2)
+	t.input.Placeholder = t.inputPlaceholder()
+
+	// Status bar, hint line and the bordered input box
+	used := 1 + 1 + tuiInputHeight + 2
+	if rows := t.toolRows(); rows > 0 {
+		used += rows + 2
+	}
+
+	t.viewport.Width = t.width
+	t.viewport.Height = max(1, t.height-used)
+	t.refreshViewport()
+}
+
+// toolRows is the number of rows the tool panel shows, 0 when it is hidden or empty
+func (t *tuiModel) toolRows() int {
+	if !t.showTools {
+		return 0
+	}
+	count := 0
+	for _, b := range t.blocks {
+		if b.tool {
+			count++
+		}
+	}
+	return min(count, tuiMaxToolRows)
+}
+
+// refreshViewport lays out the message blocks, collapsing tool output the user hasn't expanded
+func (t *tuiModel) refreshViewport() {
+	var b strings.Builder
+	t.offsets = t.offsets[:0]
+	line := 0
+
+	for i, block := range t.blocks {
+		text := t.blockView(i, block)
+		t.offsets = append(t.offsets, line)
+		b.WriteString(text)
+		b.WriteString("\n\n")
+		line += strings.Count(text, "\n") + 2
+	}
+
+	t.viewport.SetContent(strings.TrimRight(b.String(), "\n"))
+	if t.follow {
+		t.viewport.GotoBottom()
+	}
+}
+
+// blockView renders one block, marking it when selected
+func (t *tuiModel) blockView(i int, block *tuiBlock) string {
+	text := block.rendered
+	if block.tool && !t.isExpanded(i, block) {
+		text = tuiDimStyle.Render("▸ " + ansi.Truncate(block.title, max(10, t.width-4), "…"))
+	}
+
+	marker := "  "
+	if !t.focusInput && i == t.selected {
+		marker = tuiSelectedStyle.Render("▌ ")
+	}
+
+	lines := strings.Split(text, "\n")
+	for j, line := range lines {
+		lines[j] = marker + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isExpanded reports whether a tool block shows its full output. The pending approval
+// always does, so the user sees what they are approving.
+func (t *tuiModel) isExpanded(i int, block *tuiBlock) bool {
+	if t.expanded[block.ts] {
+		return true
+	}
+	return i == len(t.blocks)-1 && t.status == StatusWaitingApproval
+}
+
+func (t *tuiModel) View() string {
+	if t.width == 0 {
+		return "Loading..."
+	}
+
+	sections := []string{t.viewport.View()}
+	if t.toolRows() > 0 {
+		sections = append(sections, t.toolPanelView())
+	}
+	sections = append(sections,
+		t.hintView(),
+		tuiPanelStyle.BorderForeground(t.inputBorderColor()).Render(t.input.View()),
+		t.statusBarView(),
+	)
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// toolPanelView lists the most recent tool calls, with the selected one highlighted
+func (t *tuiModel) toolPanelView() string {
+	var indexes []int
+	for i, b := range t.blocks {
+		if b.tool {
+			indexes = append(indexes, i)
+		}
+	}
+	if len(indexes) > tuiMaxToolRows {
+		indexes = indexes[len(indexes)-tuiMaxToolRows:]
+	}
+
+	width := t.width - 4
+	rows := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		row := ansi.Truncate(t.blocks[i].title, width, "…")
+		if !t.focusInput && i == t.selected {
+			row = tuiSelectedStyle.Render(row)
+		} else {
+			row = tuiDimStyle.Render(row)
+		}
+		rows = append(rows, row)
+	}
+
+	return tuiPanelStyle.Width(t.width - 2).Render(strings.Join(rows, "\n"))
+}
+
+// hintView shows what the input box does right now, or the latest notice
+func (t *tuiModel) hintView() string {
+	hint := t.notice
+	if hint == "" {
+		switch {
+		case !t.focusInput:
+			hint = "↑/↓ select · enter expand · g/G top/bottom · esc back to input"
+		case t.hasTask && t.status == StatusWaitingApproval:
+			hint = "Approve? y / n, or type feedback to deny with it"
+		case t.status == StatusStreaming:
+			hint = "Clica is working · ctrl+c cancel"
+		default:
+			hint = "enter send · alt+enter newline · /plan /act /cancel /exit"
+		}
+	}
+	return tuiDimStyle.Render(ansi.Truncate(" "+hint, t.width, "…"))
+}
+
+func (t *tuiModel) inputPlaceholder() string {
+	if !t.hasTask {
+		return "What should Clica do?"
+	}
+	return "Reply to Clica..."
+}
+
+func (t *tuiModel) inputBorderColor() lipgloss.TerminalColor {
+	if t.focusInput {
+		return tuiAccentColor
+	}
+	return tuiBorderColor
+}
+
+// statusBarView shows the mode, model, token usage, cost, instance and task status
+func (t *tuiModel) statusBarView() string {
+	badge := tuiActBadge.Render("ACT")
+	if t.mode == "plan" {
+		badge = tuiPlanBadge.Render("PLAN")
+	}
+
+	parts := []string{}
+	if t.model != "" {
+		parts = append(parts, t.model)
+	}
+	parts = append(parts,
+		fmt.Sprintf("↑%s ↓%s", formatTokenCount(t.usage.TokensIn), formatTokenCount(t.usage.TokensOut)),
+		fmt.Sprintf("$%.4f", t.usage.Cost),
+		t.address,
+	)
+	if t.status != "" {
+		parts = append(parts, t.status)
+	}
+
+	help := "tab focus · ctrl+t tools · ctrl+\\ detach · ctrl+d quit"
+	left := " " + strings.Join(parts, " · ")
+	space := t.width - lipgloss.Width(badge) - lipgloss.Width(left) - lipgloss.Width(help) - 1
+	if space < 1 {
+		help, space = "", max(0, t.width-lipgloss.Width(badge)-lipgloss.Width(left))
+	}
+	bar := ansi.Truncate(left+strings.Repeat(" ", space)+help+" ", max(0, t.width-lipgloss.Width(badge)), "…")
+	return badge + tuiStatusStyle.Render(bar)
+}
+
+// formatTokenCount abbreviates token counts for the status bar
+func formatTokenCount(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	}
+	return fmt.Sprintf("%d", n)
+}
@@ -0,0 +1,57 @@
+package terminal
+
+import (
+	"os"
+	"strings"
+
+	"github.com/clica/cli/pkg/cli/display"
+	"golang.org/x/term"
+)
+
+// inlineImagesEnv opts in to inline images in terminals that can show them only when a setting
+// is on, which can't be detected: VS Code's terminal.integrated.enableImages is off by default
+const inlineImagesEnv = "CLICA_INLINE_IMAGES"
+
+// DetectImageProtocol returns the graphics protocol the current terminal can show images with,
+// or ImageProtocolNone if stdout isn't a terminal or the terminal has no known image support
+func DetectImageProtocol() display.ImageProtocol {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return display.ImageProtocolNone
+	}
+
+	// Images inside tmux or screen need passthrough that is usually off
+	if os.Getenv("TMUX") != "" || strings.HasPrefix(os.Getenv("TERM"), "screen") {
+		return display.ImageProtocolNone
+	}
+
+	switch DetectTerminal() {
+	case "kitty", "ghostty":
+		return display.ImageProtocolKitty
+	case "iterm2", "wezterm":
+		return display.ImageProtocolITerm
+	case "vscode":
+		if os.Getenv(inlineImagesEnv) == "1" {
+			return display.ImageProtocolITerm
+		}
+		return display.ImageProtocolNone
+	}
+
+	// Sixel terminals rarely identify themselves except through TERM
+	termName := os.Getenv("TERM")
+	for _, name := range []string{"foot", "mlterm", "contour", "yaft"} {
+		if strings.Contains(termName, name) {
+			return display.ImageProtocolSixel
+		}
+	}
+
+	return display.ImageProtocolNone
+}
+
+// ImageHint tells how to show images inline in a terminal that needs an opt-in for them, or is
+// empty if the terminal shows them already or can't show them at all
+func ImageHint() string {
+	if DetectTerminal() == "vscode" && os.Getenv(inlineImagesEnv) != "1" {
+		return "turn on terminal.integrated.enableImages and set " + inlineImagesEnv + "=1 to show images inline"
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+package theme
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2/styles"
+	glamourstyles "github.com/charmbracelet/glamour/styles"
+	"gopkg.in/yaml.v3"
+)
+
+// ThemesDir is the directory under the config path user themes are looked up in by name
+const ThemesDir = "themes"
+
+// themeFile is a user theme. Colors override those of the theme it extends, e.g.:
+//
+//	extends: solarized
+//	syntax: dracula
+//	colors:
+//	  accent: "#ff8800"
+//	  subtle: "238/248"   # dark/light
+type themeFile struct {
+	Extends  string            `yaml:"extends"`
+	Markdown string            `yaml:"markdown"`
+	Syntax   string            `yaml:"syntax"`
+	Colors   map[string]string `yaml:"colors"`
+}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Load returns the theme for a name or path: a built-in theme, a user theme in
+// <configDir>/themes/<name>.yaml, or a theme file. An empty ref is the default theme.
+func Load(ref, configDir string) (*Theme, error) {
+	if ref == "" {
+		ref = DefaultName
+	}
+	if t, ok := Builtin(ref); ok {
+		return t, nil
+	}
+
+	path := ref
+	if !strings.ContainsAny(ref, `/\`) && filepath.Ext(ref) == "" && configDir != "" {
+		for _, ext := range []string{".yaml", ".yml"} {
+			candidate := filepath.Join(configDir, ThemesDir, ref+ext)
+			if _, err := os.Stat(candidate); err == nil {
+				path = candidate
+				break
+			}
+		}
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("unknown theme '%s': expected one of %s, or a theme file", ref, strings.Join(Names(), ", "))
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads a user theme file
+func LoadFile(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read theme file %s: %w", path, err)
+	}
+
+	var file themeFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse theme file %s: %w", path, err)
+	}
+
+	base := file.Extends
+	if base == "" {
+		base = DefaultName
+	}
+	t, ok := Builtin(base)
+	if !ok {
+		return nil, fmt.Errorf("theme file %s: unknown base theme '%s': expected one of %s", path, base, strings.Join(Names(), ", "))
+	}
+	t.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	var errs []error
+	if file.Markdown != "" {
+		markdown := file.Markdown
+		if _, ok := glamourstyles.DefaultStyles[markdown]; !ok && markdown != glamourstyles.AutoStyle {
+			// A glamour JSON style, relative to the theme file
+			if !filepath.IsAbs(markdown) {
+				markdown = filepath.Join(filepath.Dir(path), markdown)
+			}
+			if _, err := os.Stat(markdown); err != nil {
+				errs = append(errs, fmt.Errorf("unknown markdown style '%s': expected a glamour style name or JSON file", file.Markdown))
+			}
+		}
+		t.Markdown = markdown
+	}
+	if file.Syntax != "" {
+		if _, ok := styles.Registry[file.Syntax]; !ok {
+			errs = append(errs, fmt.Errorf("unknown syntax style '%s'", file.Syntax))
+		}
+		t.Syntax = file.Syntax
+	}
+
+	roles := t.roles()
+	names := make([]string, 0, len(file.Colors))
+	for name := range file.Colors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		color, ok := roles[name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("unknown color role '%s'", name))
+			continue
+		}
+		parsed, err := parseColor(file.Colors[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("color '%s': %w", name, err))
+			continue
+		}
+		*color = parsed
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid theme file %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// parseColor parses "<color>" or "<dark>/<light>", each an ANSI color number or #rrggbb
+func parseColor(value string) (Color, error) {
+	dark, light, _ := strings.Cut(strings.TrimSpace(value), "/")
+	dark, light = strings.TrimSpace(dark), strings.TrimSpace(light)
+	if !isValidColor(dark) {
+		return Color{}, fmt.Errorf("invalid color '%s': expected an ANSI color number (0-255) or #rrggbb", dark)
+	}
+	if light != "" && !isValidColor(light) {
+		return Color{}, fmt.Errorf("invalid color '%s': expected an ANSI color number (0-255) or #rrggbb", light)
+	}
+	return Color{Dark: dark, Light: light}, nil
+}
+
+func isValidColor(c string) bool {
+	if hexColor.MatchString(c) {
+		return true
+	}
+	n, err := strconv.Atoi(c)
+	return err == nil && n >= 0 && n <= 255
+}
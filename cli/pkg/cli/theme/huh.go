@@ -0,0 +1,58 @@
+package theme
+
+import (
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Huh returns the theme's colors for huh forms, laid out like huh's Charm theme.
+// With NO_COLOR it is huh's base theme, which styles forms without colors.
+func (t *Theme) Huh() *huh.Theme {
+	h := huh.ThemeBase()
+	if NoColor() {
+		return h
+	}
+
+	text := t.Text.Lipgloss()
+	accent := t.Accent.Lipgloss()
+	dim := t.Dim.Lipgloss()
+	errorColor := t.Error.Lipgloss()
+	success := t.Success.Lipgloss()
+
+	f := &h.Focused
+	f.Base = f.Base.BorderForeground(t.Border.Lipgloss())
+	f.Card = f.Base
+	f.Title = f.Title.Foreground(accent).Bold(true)
+	f.NoteTitle = f.NoteTitle.Foreground(accent).Bold(true).MarginBottom(1)
+	f.Directory = f.Directory.Foreground(accent)
+	f.Description = f.Description.Foreground(dim)
+	f.ErrorIndicator = f.ErrorIndicator.Foreground(errorColor)
+	f.ErrorMessage = f.ErrorMessage.Foreground(errorColor)
+	f.SelectSelector = f.SelectSelector.Foreground(accent)
+	f.NextIndicator = f.NextIndicator.Foreground(accent)
+	f.PrevIndicator = f.PrevIndicator.Foreground(accent)
+	f.Option = f.Option.Foreground(text)
+	f.MultiSelectSelector = f.MultiSelectSelector.Foreground(accent)
+	f.SelectedOption = f.SelectedOption.Foreground(success)
+	f.SelectedPrefix = lipgloss.NewStyle().Foreground(success).SetString("✓ ")
+	f.UnselectedPrefix = lipgloss.NewStyle().Foreground(dim).SetString("• ")
+	f.UnselectedOption = f.UnselectedOption.Foreground(text)
+	f.FocusedButton = f.FocusedButton.Foreground(t.Badge.Lipgloss()).Background(accent)
+	f.Next = f.FocusedButton
+	f.BlurredButton = f.BlurredButton.Foreground(text).Background(t.StatusBar.Lipgloss())
+
+	f.TextInput.Cursor = f.TextInput.Cursor.Foreground(accent)
+	f.TextInput.Placeholder = f.TextInput.Placeholder.Foreground(t.Subtle.Lipgloss())
+	f.TextInput.Prompt = f.TextInput.Prompt.Foreground(accent)
+	f.TextInput.Text = f.TextInput.Text.Foreground(text)
+
+	h.Blurred = h.Focused
+	h.Blurred.Base = h.Focused.Base.BorderStyle(lipgloss.HiddenBorder())
+	h.Blurred.Card = h.Blurred.Base
+	h.Blurred.NextIndicator = lipgloss.NewStyle()
+	h.Blurred.PrevIndicator = lipgloss.NewStyle()
+
+	h.Group.Title = h.Focused.Title
+	h.Group.Description = h.Focused.Description
+	return h
+}
@@ -0,0 +1,249 @@
+package theme
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Color is a theme color: an ANSI color number or #rrggbb, with an optional
+// different color for terminals with a light background
+type Color struct {
+	Dark  string
+	Light string // empty to use Dark everywhere
+}
+
+// Lipgloss returns the color for lipgloss styles
+func (c Color) Lipgloss() lipgloss.TerminalColor {
+	if c.Light == "" || c.Light == c.Dark {
+		return lipgloss.Color(c.Dark)
+	}
+	return lipgloss.AdaptiveColor{Light: c.Light, Dark: c.Dark}
+}
+
+// String returns the color for the terminal's background, for renderers that take color strings
+func (c Color) String() string {
+	if c.Light == "" || lipgloss.HasDarkBackground() {
+		return c.Dark
+	}
+	return c.Light
+}
+
+// Theme holds the colors of each style role and the markdown and syntax highlighting styles
+type Theme struct {
+	Name string
+
+	// Markdown is a glamour style (dark, light, dracula, tokyo-night, ...) or a path to a glamour JSON style
+	Markdown string
+	// Syntax is the chroma style of code in diffs and markdown code blocks. Empty keeps
+	// the markdown style's own code colors, with diffs in monokai.
+	Syntax string
+
+	Text    Color // body text
+	Title   Color // headings such as the banner title
+	Dim     Color // secondary text and gutters
+	Subtle  Color // barely visible text such as placeholders
+	Border  Color
+	Accent  Color // links, selections and info
+	Success Color
+	Error   Color
+	Warning Color
+	Plan    Color // plan mode
+	Act     Color // act mode
+
+	Badge     Color // text on the plan and act badges and on focused buttons
+	StatusBar Color // background of the status bar and of unfocused buttons
+
+	DiffDelete     Color // background of deleted lines
+	DiffDeleteWord Color // background of deleted words
+	DiffInsert     Color // background of inserted lines
+	DiffInsertWord Color // background of inserted words
+}
+
+// roles maps the role names used in theme files to the theme's colors
+func (t *Theme) roles() map[string]*Color {
+	return map[string]*Color{
+		"text":             &t.Text,
+		"title":            &t.Title,
+		"dim":              &t.Dim,
+		"subtle":           &t.Subtle,
+		"border":           &t.Border,
+		"accent":           &t.Accent,
+		"success":          &t.Success,
+		"error":            &t.Error,
+		"warning":          &t.Warning,
+		"plan":             &t.Plan,
+		"act":              &t.Act,
+		"badge":            &t.Badge,
+		"status-bar":       &t.StatusBar,
+		"diff-delete":      &t.DiffDelete,
+		"diff-delete-word": &t.DiffDeleteWord,
+		"diff-insert":      &t.DiffInsert,
+		"diff-insert-word": &t.DiffInsertWord,
+	}
+}
+
+// Style returns a style with a role's color as its foreground
+func (t *Theme) Style(c Color) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(c.Lipgloss())
+}
+
+// ModeColor returns the color of a mode, plan or act
+func (t *Theme) ModeColor(mode string) Color {
+	if mode == "act" {
+		return t.Act
+	}
+	return t.Plan
+}
+
+// DefaultName is the theme used when none is selected
+const DefaultName = "dark"
+
+var builtins = map[string]Theme{
+	"dark": {
+		Markdown:       "dark",
+		Text:           Color{Dark: "252"},
+		Title:          Color{Dark: "15"},
+		Dim:            Color{Dark: "8"},
+		Subtle:         Color{Dark: "238", Light: "248"},
+		Border:         Color{Dark: "240"},
+		Accent:         Color{Dark: "39"},
+		Success:        Color{Dark: "2"},
+		Error:          Color{Dark: "1"},
+		Warning:        Color{Dark: "3"},
+		Plan:           Color{Dark: "3"},
+		Act:            Color{Dark: "39"},
+		Badge:          Color{Dark: "0"},
+		StatusBar:      Color{Dark: "236", Light: "254"},
+		DiffDelete:     Color{Dark: "#3c1618", Light: "#ffebe9"},
+		DiffDeleteWord: Color{Dark: "#7d2a2e", Light: "#ffc1c0"},
+		DiffInsert:     Color{Dark: "#12351c", Light: "#e6ffec"},
+		DiffInsertWord: Color{Dark: "#1f6b35", Light: "#abf2bc"},
+	},
+	"light": {
+		Markdown:       "light",
+		Syntax:         "github",
+		Text:           Color{Dark: "235"},
+		Title:          Color{Dark: "0"},
+		Dim:            Color{Dark: "244"},
+		Subtle:         Color{Dark: "248"},
+		Border:         Color{Dark: "250"},
+		Accent:         Color{Dark: "26"},
+		Success:        Color{Dark: "28"},
+		Error:          Color{Dark: "160"},
+		Warning:        Color{Dark: "130"},
+		Plan:           Color{Dark: "130"},
+		Act:            Color{Dark: "26"},
+		Badge:          Color{Dark: "15"},
+		StatusBar:      Color{Dark: "254"},
+		DiffDelete:     Color{Dark: "#ffebe9"},
+		DiffDeleteWord: Color{Dark: "#ffc1c0"},
+		DiffInsert:     Color{Dark: "#e6ffec"},
+		DiffInsertWord: Color{Dark: "#abf2bc"},
+	},
+	"high-contrast": {
+		Markdown:       "dark",
+		Syntax:         "hr_high_contrast",
+		Text:           Color{Dark: "15", Light: "0"},
+		Title:          Color{Dark: "15", Light: "0"},
+		Dim:            Color{Dark: "250", Light: "238"},
+		Subtle:         Color{Dark: "248", Light: "240"},
+		Border:         Color{Dark: "15", Light: "0"},
+		Accent:         Color{Dark: "14", Light: "19"},
+		Success:        Color{Dark: "10", Light: "22"},
+		Error:          Color{Dark: "9", Light: "124"},
+		Warning:        Color{Dark: "11", Light: "94"},
+		Plan:           Color{Dark: "11", Light: "94"},
+		Act:            Color{Dark: "14", Light: "19"},
+		Badge:          Color{Dark: "0", Light: "15"},
+		StatusBar:      Color{Dark: "236", Light: "254"},
+		DiffDelete:     Color{Dark: "#5f0000", Light: "#ffd7d7"},
+		DiffDeleteWord: Color{Dark: "#af0000", Light: "#ff8787"},
+		DiffInsert:     Color{Dark: "#005f00", Light: "#d7ffd7"},
+		DiffInsertWord: Color{Dark: "#008700", Light: "#87ff87"},
+	},
+	"solarized": {
+		Markdown:       "dark",
+		Syntax:         "solarized-dark",
+		Text:           Color{Dark: "#839496"},
+		Title:          Color{Dark: "#93a1a1"},
+		Dim:            Color{Dark: "#586e75"},
+		Subtle:         Color{Dark: "#586e75"},
+		Border:         Color{Dark: "#586e75"},
+		Accent:         Color{Dark: "#268bd2"},
+		Success:        Color{Dark: "#859900"},
+		Error:          Color{Dark: "#dc322f"},
+		Warning:        Color{Dark: "#b58900"},
+		Plan:           Color{Dark: "#b58900"},
+		Act:            Color{Dark: "#268bd2"},
+		Badge:          Color{Dark: "#002b36"},
+		StatusBar:      Color{Dark: "#073642"},
+		DiffDelete:     Color{Dark: "#3d1f22"},
+		DiffDeleteWord: Color{Dark: "#6e2a28"},
+		DiffInsert:     Color{Dark: "#1e3a1a"},
+		DiffInsertWord: Color{Dark: "#3f5a12"},
+	},
+	// Okabe-Ito colors, which stay distinct with red-green color blindness:
+	// changes are orange and blue rather than red and green
+	"colorblind-safe": {
+		Markdown:       "dark",
+		Text:           Color{Dark: "252"},
+		Title:          Color{Dark: "15"},
+		Dim:            Color{Dark: "8"},
+		Subtle:         Color{Dark: "238", Light: "248"},
+		Border:         Color{Dark: "240"},
+		Accent:         Color{Dark: "#56b4e9"},
+		Success:        Color{Dark: "#56b4e9"},
+		Error:          Color{Dark: "#d55e00"},
+		Warning:        Color{Dark: "#f0e442"},
+		Plan:           Color{Dark: "#e69f00"},
+		Act:            Color{Dark: "#56b4e9"},
+		Badge:          Color{Dark: "0"},
+		StatusBar:      Color{Dark: "236", Light: "254"},
+		DiffDelete:     Color{Dark: "#4d2a00"},
+		DiffDeleteWord: Color{Dark: "#8a4b00"},
+		DiffInsert:     Color{Dark: "#0b2e4d"},
+		DiffInsertWord: Color{Dark: "#154f80"},
+	},
+}
+
+// Builtin returns a copy of a built-in theme
+func Builtin(name string) (*Theme, bool) {
+	t, ok := builtins[name]
+	if !ok {
+		return nil, false
+	}
+	t.Name = name
+	return &t, true
+}
+
+// Names returns the names of the built-in themes
+func Names() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var current, _ = Builtin(DefaultName)
+
+// Current returns the selected theme
+func Current() *Theme {
+	return current
+}
+
+// Set selects the theme used by renderers created afterwards
+func Set(t *Theme) {
+	if t != nil {
+		current = t
+	}
+}
+
+// NoColor reports whether colors are turned off with the NO_COLOR environment variable (https://no-color.org)
+func NoColor() bool {
+	return strings.TrimSpace(os.Getenv("NO_COLOR")) != ""
+}
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"encoding/json"
+	"mime"
+	"path"
+	"strings"
+)
+
+// MCP request types in use_mcp_server messages
+const (
+	McpTypeUseTool        = "use_mcp_tool"
+	McpTypeAccessResource = "access_mcp_resource"
+)
+
+// McpRequest is the text of use_mcp_server asks and says
+type McpRequest struct {
+	ServerName string `json:"serverName"`
+	Type       string `json:"type"`
+	ToolName   string `json:"toolName,omitempty"`
+	Arguments  string `json:"arguments,omitempty"`
+	URI        string `json:"uri,omitempty"`
+}
+
+// ParseMcpRequest parses the text of a use_mcp_server message
+func ParseMcpRequest(text string) (*McpRequest, error) {
+	var req McpRequest
+	if err := json.Unmarshal([]byte(text), &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// IsResourceRead reports whether the request reads a resource rather than calling a tool
+func (r *McpRequest) IsResourceRead() bool {
+	return r.Type == McpTypeAccessResource
+}
+
+// MimeType guesses the MIME type of the resource a request reads from its URI, or ""
+func (r *McpRequest) MimeType() string {
+	if r.URI == "" {
+		return ""
+	}
+	mimeType := mime.TypeByExtension(path.Ext(r.URI))
+	mediaType, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// McpContent is one part of an MCP server response
+type McpContent struct {
+	Type     string `json:"type"` // text, image or resource
+	Text     string `json:"text,omitempty"`
+	URI      string `json:"uri,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+	SavedTo  string `json:"saved_to,omitempty"` // where an image was saved with --save-screenshots
+	DataURL  string `json:"-"`                  // image data
+}
+
+// McpResponse is an MCP server response split into its parts
+type McpResponse struct {
+	IsError bool         `json:"is_error,omitempty"`
+	Content []McpContent `json:"content"`
+}
+
+// mcpEmbeddedResource is how embedded resources appear in response text
+type mcpEmbeddedResource struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+// ParseMcpResponse splits the text of an mcp_server_response message into text, embedded
+// resources and images. The core joins the parts with blank lines, appends images as
+// data URLs and prefixes failed tool calls with "Error:".
+func ParseMcpResponse(text string) *McpResponse {
+	resp := &McpResponse{}
+
+	if rest, ok := strings.CutPrefix(text, "Error:\n"); ok {
+		resp.IsError = true
+		text = rest
+	}
+
+	// Images come last, one per paragraph
+	var images []McpContent
+	for {
+		i := strings.LastIndex(text, "\n\n")
+		last := strings.TrimSpace(text[i+1:]) // the whole text when there is no blank line
+		if !strings.HasPrefix(last, "data:image/") || strings.ContainsAny(last, " \n") {
+			break
+		}
+
+		mimeType, _, _ := strings.Cut(strings.TrimPrefix(last, "data:"), ";")
+		images = append([]McpContent{{Type: "image", MimeType: mimeType, DataURL: last}}, images...)
+		if i < 0 {
+			text = ""
+			break
+		}
+		text = text[:i]
+	}
+
+	// Paragraphs that are embedded resources become their own parts; the rest is text
+	var paragraphs []string
+	flushText := func() {
+		if len(paragraphs) > 0 {
+			resp.Content = append(resp.Content, McpContent{Type: "text", Text: strings.Join(paragraphs, "\n\n")})
+			paragraphs = nil
+		}
+	}
+	if text != "" {
+		for _, paragraph := range strings.Split(text, "\n\n") {
+			var resource mcpEmbeddedResource
+			if strings.HasPrefix(paragraph, "{") && json.Unmarshal([]byte(paragraph), &resource) == nil && resource.URI != "" {
+				flushText()
+				resp.Content = append(resp.Content, McpContent{
+					Type:     "resource",
+					URI:      resource.URI,
+					MimeType: resource.MimeType,
+					Text:     resource.Text,
+				})
+				continue
+			}
+			paragraphs = append(paragraphs, paragraph)
+		}
+		flushText()
+	}
+
+	resp.Content = append(resp.Content, images...)
+	return resp
+}
+
+// ParseMcpNotification splits the text of an mcp_notification message, "[server] message",
+// into the server name and message
+func ParseMcpNotification(text string) (server, message string) {
+	if strings.HasPrefix(text, "[") {
+		if end := strings.Index(text, "] "); end > 0 {
+			return text[1:end], text[end+2:]
+		}
+	}
+	return "", text
+}
+
+// McpDetails describes an MCP message for JSON output
+type McpDetails struct {
+	Kind      string          `json:"kind"` // tool_call, resource_read, response or notification
+	Server    string          `json:"server,omitempty"`
+	Tool      string          `json:"tool,omitempty"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	URI       string          `json:"uri,omitempty"`
+	MimeType  string          `json:"mime_type,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+	Content   []McpContent    `json:"content,omitempty"`
+	Message   string          `json:"message,omitempty"`
+}
+
+// McpArgumentsJSON returns tool call arguments as JSON, quoting them as a string if they aren't valid JSON
+func McpArgumentsJSON(arguments string) json.RawMessage {
+	if strings.TrimSpace(arguments) == "" {
+		return nil
+	}
+	if json.Valid([]byte(arguments)) {
+		return json.RawMessage(arguments)
+	}
+	quoted, _ := json.Marshal(arguments)
+	return quoted
+}
@@ -144,6 +144,31 @@ type APIRequestRetryStatus struct {
 	ErrorSnippet string `json:"errorSnippet,omitempty"`
 }
 
+// TaskUsage totals the tokens and cost of a task's API requests
+type TaskUsage struct {
+	TokensIn  int
+	TokensOut int
+	Cost      float64
+}
+
+// UsageFromMessages sums the API request info of a task's messages
+func UsageFromMessages(messages []*ClicaMessage) TaskUsage {
+	var usage TaskUsage
+	for _, msg := range messages {
+		if msg.Type != MessageTypeSay || msg.Say != string(SayTypeAPIReqStarted) {
+			continue
+		}
+		var info APIRequestInfo
+		if err := json.Unmarshal([]byte(msg.Text), &info); err != nil {
+			continue
+		}
+		usage.TokensIn += info.TokensIn
+		usage.TokensOut += info.TokensOut
+		usage.Cost += info.Cost
+	}
+	return usage
+}
+
 // GetTimestamp returns a formatted timestamp string
 func (m *ClicaMessage) GetTimestamp() string {
 	return time.Unix(m.Timestamp/1000, 0).Format("15:04:05")
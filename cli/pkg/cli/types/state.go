@@ -8,6 +8,7 @@ import (
 type ConversationState struct {
 	mu               sync.RWMutex
 	StreamingMessage *StreamingMessage `json:"streamingMessage,omitempty"`
+	LastMcpRequest   *McpRequest       `json:"lastMcpRequest,omitempty"` // the request MCP responses answer
 }
 
 // StreamingMessage manages state for streaming message display
@@ -41,11 +42,26 @@ func (cs *ConversationState) GetStreamingMessage() *StreamingMessage {
 	}
 }
 
+// SetLastMcpRequest records the latest MCP tool call or resource read
+func (cs *ConversationState) SetLastMcpRequest(req *McpRequest) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.LastMcpRequest = req
+}
+
+// GetLastMcpRequest returns the latest MCP tool call or resource read, or nil
+func (cs *ConversationState) GetLastMcpRequest() *McpRequest {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.LastMcpRequest
+}
+
 // Clear resets state
 func (cs *ConversationState) Clear() {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
 	cs.StreamingMessage = &StreamingMessage{}
+	cs.LastMcpRequest = nil
 }
 
 // ExtensionState represents the server-side extension state structure
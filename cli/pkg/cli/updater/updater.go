@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/clica/cli/pkg/cli/global"
 	"github.com/clica/cli/pkg/cli/output"
+	"github.com/clica/cli/pkg/cli/theme"
 )
 
 type cacheData struct {
@@ -33,11 +34,22 @@ const (
 	requestTimeout = 3 * time.Second
 )
 
-var (
-	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Bold(true)
-	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true)
-	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-)
+// Styles come from the theme, which is selected after package initialization
+
+func successStyle() lipgloss.Style {
+	t := theme.Current()
+	return t.Style(t.Success).Bold(true)
+}
+
+func errorStyle() lipgloss.Style {
+	t := theme.Current()
+	return t.Style(t.Error).Bold(true)
+}
+
+func dimStyle() lipgloss.Style {
+	t := theme.Current()
+	return t.Style(t.Dim)
+}
 
 var verbose bool
 
@@ -354,9 +366,9 @@ func parseInt(s string) int {
 
 func showSuccessMessage(version string) {
 	output.Printf("\n%s Updated to %s %s Changes will take effect next session\n\n",
-		successStyle.Render("✓"),
-		successStyle.Render("v"+version),
-		dimStyle.Render("→"),
+		successStyle().Render("✓"),
+		successStyle().Render("v"+version),
+		dimStyle().Render("→"),
 	)
 }
 
@@ -367,8 +379,8 @@ func showFailureMessage(channel string) {
 	}
 
 	output.Printf("\n%s Auto-update failed %s Try: %s\n\n",
-		errorStyle.Render("✗"),
-		dimStyle.Render("·"),
+		errorStyle().Render("✗"),
+		dimStyle().Render("·"),
 		"npm install -g "+packageName,
 	)
 }
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// ProcessStats is a snapshot of a process's resource usage
+type ProcessStats struct {
+	PID        int     `json:"pid"`
+	RSSBytes   int64   `json:"rss_bytes"`
+	CPUPercent float64 `json:"cpu_percent"`
+}
+
+// GetProcessStats reads the memory and CPU usage of a process using ps (Linux and macOS)
+func GetProcessStats(pid int) (*ProcessStats, error) {
+	out, err := exec.Command("ps", "-o", "rss=,%cpu=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return nil, fmt.Errorf("process %d not found", pid)
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("unexpected ps output for process %d", pid)
+	}
+
+	rssKB, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse memory usage of process %d: %w", pid, err)
+	}
+	cpu, err := strconv.ParseFloat(strings.ReplaceAll(fields[1], ",", "."), 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CPU usage of process %d: %w", pid, err)
+	}
+
+	return &ProcessStats{
+		PID:        pid,
+		RSSBytes:   rssKB * 1024,
+		CPUPercent: cpu,
+	}, nil
+}
+
+// FindListeningPID returns the PID of the process listening on a local TCP port, using lsof.
+// Returns 0 if no process is listening.
+func FindListeningPID(port int) (int, error) {
+	out, err := exec.Command("lsof", "-nP", "-t", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN").Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			// lsof exits with 1 when nothing matches
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to run lsof: %w", err)
+	}
+
+	for _, line := range strings.Fields(string(out)) {
+		if pid, err := strconv.Atoi(line); err == nil {
+			return pid, nil
+		}
+	}
+	return 0, nil
+}
+
+// GetProcessCommand returns the command line of a process using ps
+func GetProcessCommand(pid int) (string, error) {
+	out, err := exec.Command("ps", "-o", "command=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return "", fmt.Errorf("process %d not found", pid)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -29,11 +29,33 @@ const (
 		WHERE held_by = ? AND lock_type = 'instance'
 	`
 
+	// InsertFileLockSQL takes a file lock if nobody holds it; no row is inserted otherwise
 	InsertFileLockSQL = `
-		INSERT INTO locks (held_by, lock_type, lock_target, locked_at)
+		INSERT OR IGNORE INTO locks (held_by, lock_type, lock_target, locked_at)
 		VALUES (?, 'file', ?, ?)
 	`
 
+	// SelectFileLockSQL selects the current holder of a file lock
+	SelectFileLockSQL = `
+		SELECT id, held_by, lock_type, lock_target, locked_at
+		FROM locks
+		WHERE lock_type = 'file' AND lock_target = ?
+	`
+
+	// RenewFileLockSQL extends the lease of a held file lock
+	RenewFileLockSQL = `
+		UPDATE locks
+		SET locked_at = ?
+		WHERE held_by = ? AND lock_type = 'file' AND lock_target = ?
+	`
+
+	// DeleteFileLockIfUnchangedSQL takes over a stale file lock, but only if it wasn't
+	// renewed or re-acquired since it was read
+	DeleteFileLockIfUnchangedSQL = `
+		DELETE FROM locks
+		WHERE id = ? AND held_by = ? AND locked_at = ?
+	`
+
 	// DeleteFileLockSQL deletes a file lock by holder and target
 	DeleteFileLockSQL = `
 		DELETE FROM locks 
@@ -51,4 +73,104 @@ const (
 		INSERT OR REPLACE INTO locks (held_by, lock_type, lock_target, locked_at)
 		VALUES (?, 'instance', ?, ?)
 	`
+
+	// SelectHeldLocksSQL selects all file and folder locks ordered by creation time
+	SelectHeldLocksSQL = `
+		SELECT id, held_by, lock_type, lock_target, locked_at
+		FROM locks
+		WHERE lock_type IN ('file', 'folder')
+		ORDER BY locked_at ASC
+	`
+
+	// DeleteLockByIDSQL deletes a single lock row
+	DeleteLockByIDSQL = `
+		DELETE FROM locks
+		WHERE id = ?
+	`
+
+	// CreateLockWaitersTableSQL creates the CLI-owned queue of processes waiting for file locks,
+	// which hands locks out in arrival order
+	CreateLockWaitersTableSQL = `
+		CREATE TABLE IF NOT EXISTS lock_waiters (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			lock_target TEXT NOT NULL,
+			waiter TEXT NOT NULL,
+			heartbeat_at INTEGER NOT NULL
+		)
+	`
+
+	// InsertLockWaiterSQL queues a waiter for a file lock
+	InsertLockWaiterSQL = `
+		INSERT INTO lock_waiters (lock_target, waiter, heartbeat_at)
+		VALUES (?, ?, ?)
+	`
+
+	// TouchLockWaiterSQL records that a waiter is still waiting
+	TouchLockWaiterSQL = `
+		UPDATE lock_waiters
+		SET heartbeat_at = ?
+		WHERE id = ?
+	`
+
+	// DeleteLockWaiterSQL removes a waiter from the queue
+	DeleteLockWaiterSQL = `
+		DELETE FROM lock_waiters
+		WHERE id = ?
+	`
+
+	// DeleteExpiredLockWaitersSQL drops waiters that stopped heartbeating
+	DeleteExpiredLockWaitersSQL = `
+		DELETE FROM lock_waiters
+		WHERE heartbeat_at < ?
+	`
+
+	// SelectLockQueueHeadSQL selects the longest-waiting live waiter for a file lock
+	SelectLockQueueHeadSQL = `
+		SELECT id
+		FROM lock_waiters
+		WHERE lock_target = ? AND heartbeat_at >= ?
+		ORDER BY id ASC
+		LIMIT 1
+	`
+
+	// CreateInstanceMetadataTableSQL creates the CLI-owned table holding instance names,
+	// workspaces and labels. It lives beside the locks table but clica-core never touches it.
+	CreateInstanceMetadataTableSQL = `
+		CREATE TABLE IF NOT EXISTS instance_metadata (
+			address TEXT PRIMARY KEY,
+			name TEXT NOT NULL DEFAULT '',
+			workspace TEXT NOT NULL DEFAULT '',
+			labels TEXT NOT NULL DEFAULT '{}',
+			created_at INTEGER NOT NULL
+		)
+	`
+
+	// UpsertInstanceMetadataSQL inserts or replaces the metadata of an instance
+	UpsertInstanceMetadataSQL = `
+		INSERT OR REPLACE INTO instance_metadata (address, name, workspace, labels, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	// SelectLiveInstanceMetadataSQL selects metadata of instances that still hold an instance lock
+	SelectLiveInstanceMetadataSQL = `
+		SELECT m.address, m.name, m.workspace, m.labels, m.created_at
+		FROM instance_metadata m
+		JOIN locks l ON l.held_by = m.address AND l.lock_type = 'instance'
+		ORDER BY l.locked_at ASC
+	`
+
+	// SelectOrphanInstanceMetadataSQL selects addresses whose metadata outlived their instance lock
+	SelectOrphanInstanceMetadataSQL = `
+		SELECT m.address
+		FROM instance_metadata m
+		LEFT JOIN locks l ON l.held_by = m.address AND l.lock_type = 'instance'
+		WHERE l.id IS NULL
+		ORDER BY m.address ASC
+	`
+
+	// DeleteInstanceMetadataSQL deletes the metadata of an instance by address
+	DeleteInstanceMetadataSQL = `
+		DELETE FROM instance_metadata
+		WHERE address = ?
+	`
 )
@@ -42,6 +42,16 @@ type LockRow struct {
 	LockedAt   int64  `json:"locked_at"`
 }
 
+// InstanceMetadata holds the user-assigned name, workspace and labels of an instance.
+// It is stored in the locks database next to the instance's lock row.
+type InstanceMetadata struct {
+	Address   string            `json:"address"`
+	Name      string            `json:"name,omitempty"`
+	Workspace string            `json:"workspace,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt int64             `json:"created_at"`
+}
+
 // InstancesOutput represents the JSON output format for instance listing
 type InstancesOutput struct {
 	DefaultInstance string             `json:"default_instance"`
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -26,16 +25,14 @@ type diffSession struct {
 // DiffService implements the proto.DiffServiceServer interface
 type DiffService struct {
 	proto.UnimplementedDiffServiceServer
-	verbose  bool
 	sessions *sync.Map // thread-safe: diffId -> *diffSession
 	counter  *int64    // atomic counter for unique IDs
 }
 
 // NewDiffService creates a new DiffService
-func NewDiffService(verbose bool) *DiffService {
+func NewDiffService() *DiffService {
 	counter := int64(0)
 	return &DiffService{
-		verbose:  verbose,
 		sessions: &sync.Map{},
 		counter:  &counter,
 	}
@@ -83,9 +80,7 @@ func joinLines(lines []string) string {
 
 // OpenDiff opens a diff view for the specified file
 func (s *DiffService) OpenDiff(ctx context.Context, req *proto.OpenDiffRequest) (*proto.OpenDiffResponse, error) {
-	if s.verbose {
-		log.Printf("OpenDiff called for path: %s", req.GetPath())
-	}
+	logFromContext(ctx).Debug("opening diff", "path", req.GetPath())
 
 	diffID := s.generateDiffID()
 
@@ -121,10 +116,7 @@ func (s *DiffService) OpenDiff(ctx context.Context, req *proto.OpenDiffRequest)
 	// Store the session
 	s.sessions.Store(diffID, session)
 
-	if s.verbose {
-		log.Printf("Created diff session: %s (original: %d bytes, current: %d bytes)",
-			diffID, len(originalContent), len(currentContent))
-	}
+	logFromContext(ctx).Debug("created diff session", "diff_id", diffID, "original_bytes", len(originalContent), "current_bytes", len(currentContent))
 
 	return &proto.OpenDiffResponse{
 		DiffId: &diffID,
@@ -133,9 +125,7 @@ func (s *DiffService) OpenDiff(ctx context.Context, req *proto.OpenDiffRequest)
 
 // GetDocumentText returns the current content of the diff document
 func (s *DiffService) GetDocumentText(ctx context.Context, req *proto.GetDocumentTextRequest) (*proto.GetDocumentTextResponse, error) {
-	if s.verbose {
-		log.Printf("GetDocumentText called for diff ID: %s", req.GetDiffId())
-	}
+	logFromContext(ctx).Debug("getting document text", "diff_id", req.GetDiffId())
 
 	sessionInterface, exists := s.sessions.Load(req.GetDiffId())
 	if !exists {
@@ -152,10 +142,7 @@ func (s *DiffService) GetDocumentText(ctx context.Context, req *proto.GetDocumen
 
 // ReplaceText replaces text in the diff document using line-based operations
 func (s *DiffService) ReplaceText(ctx context.Context, req *proto.ReplaceTextRequest) (*proto.ReplaceTextResponse, error) {
-	if s.verbose {
-		log.Printf("ReplaceText called for diff ID: %s, lines %d-%d",
-			req.GetDiffId(), req.GetStartLine(), req.GetEndLine())
-	}
+	logFromContext(ctx).Debug("replacing text", "diff_id", req.GetDiffId(), "start_line", req.GetStartLine(), "end_line", req.GetEndLine())
 
 	sessionInterface, exists := s.sessions.Load(req.GetDiffId())
 	if !exists {
@@ -203,19 +190,14 @@ func (s *DiffService) ReplaceText(ctx context.Context, req *proto.ReplaceTextReq
 	// Store the updated session
 	s.sessions.Store(req.GetDiffId(), session)
 
-	if s.verbose {
-		log.Printf("Updated diff session %s: %d lines, %d bytes",
-			req.GetDiffId(), len(session.lines), len(session.currentContent))
-	}
+	logFromContext(ctx).Debug("updated diff session", "diff_id", req.GetDiffId(), "lines", len(session.lines), "bytes", len(session.currentContent))
 
 	return &proto.ReplaceTextResponse{}, nil
 }
 
 // ScrollDiff scrolls the diff view to a specific line (no-op for CLI)
 func (s *DiffService) ScrollDiff(ctx context.Context, req *proto.ScrollDiffRequest) (*proto.ScrollDiffResponse, error) {
-	if s.verbose {
-		log.Printf("ScrollDiff called for diff ID: %s, line: %d", req.GetDiffId(), req.GetLine())
-	}
+	logFromContext(ctx).Debug("scrolling diff", "diff_id", req.GetDiffId(), "line", req.GetLine())
 
 	// Verify session exists
 	if _, exists := s.sessions.Load(req.GetDiffId()); !exists {
@@ -229,9 +211,7 @@ func (s *DiffService) ScrollDiff(ctx context.Context, req *proto.ScrollDiffReque
 
 // TruncateDocument truncates the diff document at the specified line
 func (s *DiffService) TruncateDocument(ctx context.Context, req *proto.TruncateDocumentRequest) (*proto.TruncateDocumentResponse, error) {
-	if s.verbose {
-		log.Printf("TruncateDocument called for diff ID: %s, end line: %d", req.GetDiffId(), req.GetEndLine())
-	}
+	logFromContext(ctx).Debug("truncating document", "diff_id", req.GetDiffId(), "end_line", req.GetEndLine())
 
 	sessionInterface, exists := s.sessions.Load(req.GetDiffId())
 	if !exists {
@@ -249,9 +229,7 @@ func (s *DiffService) TruncateDocument(ctx context.Context, req *proto.TruncateD
 		// Store the updated session
 		s.sessions.Store(req.GetDiffId(), session)
 
-		if s.verbose {
-			log.Printf("Truncated diff session %s to %d lines", req.GetDiffId(), len(session.lines))
-		}
+		logFromContext(ctx).Debug("truncated diff session", "diff_id", req.GetDiffId(), "lines", len(session.lines))
 	}
 
 	return &proto.TruncateDocumentResponse{}, nil
@@ -259,9 +237,7 @@ func (s *DiffService) TruncateDocument(ctx context.Context, req *proto.TruncateD
 
 // SaveDocument saves the diff document to the original file
 func (s *DiffService) SaveDocument(ctx context.Context, req *proto.SaveDocumentRequest) (*proto.SaveDocumentResponse, error) {
-	if s.verbose {
-		log.Printf("SaveDocument called for diff ID: %s", req.GetDiffId())
-	}
+	logFromContext(ctx).Debug("saving document", "diff_id", req.GetDiffId())
 
 	sessionInterface, exists := s.sessions.Load(req.GetDiffId())
 	if !exists {
@@ -285,20 +261,13 @@ func (s *DiffService) SaveDocument(ctx context.Context, req *proto.SaveDocumentR
 		return nil, fmt.Errorf("failed to save file: %w", err)
 	}
 
-	if s.verbose {
-		log.Printf("Saved diff session %s to file: %s (%d bytes)",
-			req.GetDiffId(), session.originalPath, len(session.currentContent))
-	}
+	logFromContext(ctx).Info("saved diff session", "diff_id", req.GetDiffId(), "path", session.originalPath, "bytes", len(session.currentContent))
 
 	return &proto.SaveDocumentResponse{}, nil
 }
 
 // CloseAllDiffs closes all diff views and cleans up all sessions
 func (s *DiffService) CloseAllDiffs(ctx context.Context, req *proto.CloseAllDiffsRequest) (*proto.CloseAllDiffsResponse, error) {
-	if s.verbose {
-		log.Printf("CloseAllDiffs called")
-	}
-
 	var count int64
 
 	s.sessions.Range(func(key, value any) bool {
@@ -312,18 +281,14 @@ func (s *DiffService) CloseAllDiffs(ctx context.Context, req *proto.CloseAllDiff
 		return true
 	})
 
-	if s.verbose {
-		log.Printf("Closed %d diff sessions", count)
-	}
+	logFromContext(ctx).Debug("closed diff sessions", "count", count)
 
 	return &proto.CloseAllDiffsResponse{}, nil
 }
 
 // OpenMultiFileDiff displays a diff view comparing before/after states for multiple files
 func (s *DiffService) OpenMultiFileDiff(ctx context.Context, req *proto.OpenMultiFileDiffRequest) (*proto.OpenMultiFileDiffResponse, error) {
-	if s.verbose {
-		log.Printf("OpenMultiFileDiff called with title: %s, %d files", req.GetTitle(), len(req.GetDiffs()))
-	}
+	logFromContext(ctx).Debug("opening multi-file diff", "title", req.GetTitle(), "files", len(req.GetDiffs()))
 
 	// In a CLI implementation, we could display the diffs to console
 	// For now, we'll just log the information
@@ -332,13 +297,9 @@ func (s *DiffService) OpenMultiFileDiff(ctx context.Context, req *proto.OpenMult
 		title = "Multi-file diff"
 	}
 
-	if s.verbose {
-		log.Printf("=== %s ===", title)
-		for i, diff := range req.GetDiffs() {
-			log.Printf("File %d: %s", i+1, diff.GetFilePath())
-			log.Printf("  Left content: %d bytes", len(diff.GetLeftContent()))
-			log.Printf("  Right content: %d bytes", len(diff.GetRightContent()))
-		}
+	for i, diff := range req.GetDiffs() {
+		logFromContext(ctx).Debug("multi-file diff entry", "title", title, "index", i+1, "path", diff.GetFilePath(),
+			"left_bytes", len(diff.GetLeftContent()), "right_bytes", len(diff.GetRightContent()))
 	}
 
 	// In a more sophisticated CLI implementation, we could:
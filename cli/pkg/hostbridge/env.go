@@ -2,7 +2,6 @@ package hostbridge
 
 import (
 	"context"
-	"log"
 	"os"
 
 	"github.com/atotto/clipboard"
@@ -22,27 +21,20 @@ func init() {
 // EnvService implements the host.EnvServiceServer interface
 type EnvService struct {
 	host.UnimplementedEnvServiceServer
-	verbose bool
 }
 
 // NewEnvService creates a new EnvService
-func NewEnvService(verbose bool) *EnvService {
-	return &EnvService{
-		verbose: verbose,
-	}
+func NewEnvService() *EnvService {
+	return &EnvService{}
 }
 
 // ClipboardWriteText writes text to the system clipboard
 func (s *EnvService) ClipboardWriteText(ctx context.Context, req *clica.StringRequest) (*clica.Empty, error) {
-	if s.verbose {
-		log.Printf("ClipboardWriteText called with text length: %d", len(req.GetValue()))
-	}
+	logFromContext(ctx).Debug("writing clipboard", "length", len(req.GetValue()))
 
 	err := clipboard.WriteAll(req.GetValue())
 	if err != nil {
-		if s.verbose {
-			log.Printf("Failed to write to clipboard: %v", err)
-		}
+		logFromContext(ctx).Warn("failed to write to clipboard", "error", err)
 		// Don't fail if clipboard is not available (e.g., headless environment)
 	}
 
@@ -51,15 +43,9 @@ func (s *EnvService) ClipboardWriteText(ctx context.Context, req *clica.StringRe
 
 // ClipboardReadText reads text from the system clipboard
 func (s *EnvService) ClipboardReadText(ctx context.Context, req *clica.EmptyRequest) (*clica.String, error) {
-	if s.verbose {
-		log.Printf("ClipboardReadText called")
-	}
-
 	text, err := clipboard.ReadAll()
 	if err != nil {
-		if s.verbose {
-			log.Printf("Failed to read from clipboard: %v", err)
-		}
+		logFromContext(ctx).Warn("failed to read from clipboard", "error", err)
 		// Return empty string if clipboard is not available
 		text = ""
 	}
@@ -71,10 +57,6 @@ func (s *EnvService) ClipboardReadText(ctx context.Context, req *clica.EmptyRequ
 
 // GetHostVersion returns the host platform name and version
 func (s *EnvService) GetHostVersion(ctx context.Context, req *clica.EmptyRequest) (*host.GetHostVersionResponse, error) {
-	if s.verbose {
-		log.Printf("GetHostVersion called")
-	}
-
 	return &host.GetHostVersionResponse{
 		Platform:     proto.String("Clica CLI"),
 		Version:      proto.String(""),
@@ -85,20 +67,14 @@ func (s *EnvService) GetHostVersion(ctx context.Context, req *clica.EmptyRequest
 
 // Shutdown initiates a graceful shutdown of the host bridge service
 func (s *EnvService) Shutdown(ctx context.Context, req *clica.EmptyRequest) (*clica.Empty, error) {
-	if s.verbose {
-		log.Printf("Shutdown requested via RPC")
-	}
+	logFromContext(ctx).Info("shutdown requested via RPC")
 
 	// Trigger global shutdown signal
 	select {
 	case globalShutdownCh <- struct{}{}:
-		if s.verbose {
-			log.Printf("Shutdown signal sent successfully")
-		}
+		logFromContext(ctx).Debug("shutdown signal sent")
 	default:
-		if s.verbose {
-			log.Printf("Shutdown signal already pending")
-		}
+		logFromContext(ctx).Debug("shutdown signal already pending")
 	}
 
 	return &clica.Empty{}, nil
@@ -106,10 +82,6 @@ func (s *EnvService) Shutdown(ctx context.Context, req *clica.EmptyRequest) (*cl
 
 // GetTelemetrySettings returns the telemetry settings for CLI mode
 func (s *EnvService) GetTelemetrySettings(ctx context.Context, req *clica.EmptyRequest) (*host.GetTelemetrySettingsResponse, error) {
-	if s.verbose {
-		log.Printf("GetTelemetrySettings called")
-	}
-
 	// In CLI mode, check the POSTHOG_TELEMETRY_ENABLED environment variable
 	telemetryEnabled := os.Getenv("POSTHOG_TELEMETRY_ENABLED") == "true"
 
@@ -129,10 +101,6 @@ func (s *EnvService) GetTelemetrySettings(ctx context.Context, req *clica.EmptyR
 // In CLI mode, telemetry settings don't change at runtime, so we just send
 // the current state and keep the stream open
 func (s *EnvService) SubscribeToTelemetrySettings(req *clica.EmptyRequest, stream host.EnvService_SubscribeToTelemetrySettingsServer) error {
-	if s.verbose {
-		log.Printf("SubscribeToTelemetrySettings called")
-	}
-
 	// Send initial telemetry state
 	telemetryEnabled := os.Getenv("POSTHOG_TELEMETRY_ENABLED") == "true"
 
@@ -148,9 +116,6 @@ func (s *EnvService) SubscribeToTelemetrySettings(req *clica.EmptyRequest, strea
 	}
 
 	if err := stream.Send(event); err != nil {
-		if s.verbose {
-			log.Printf("Failed to send telemetry settings event: %v", err)
-		}
 		return err
 	}
 
@@ -158,9 +123,5 @@ func (s *EnvService) SubscribeToTelemetrySettings(req *clica.EmptyRequest, strea
 	// (In CLI mode, settings don't change dynamically)
 	<-stream.Context().Done()
 
-	if s.verbose {
-		log.Printf("SubscribeToTelemetrySettings stream closed")
-	}
-
 	return nil
 }
@@ -3,7 +3,7 @@ package hostbridge
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net"
 
 	"github.com/clica/grpc-go/host"
@@ -15,25 +15,23 @@ import (
 // GrpcServer provides gRPC hostbridge functionality
 type GrpcServer struct {
 	port       int
-	verbose    bool
+	logger     *slog.Logger
 	server     *grpc.Server
 	shutdownCh chan struct{}
 }
 
-// NewGrpcServer creates a new GrpcServer
-func NewGrpcServer(port int, verbose bool) *GrpcServer {
+// NewGrpcServer creates a new GrpcServer that logs through logger
+func NewGrpcServer(port int, logger *slog.Logger) *GrpcServer {
 	return &GrpcServer{
 		port:       port,
-		verbose:    verbose,
+		logger:     logger,
 		shutdownCh: make(chan struct{}),
 	}
 }
 
 // Start starts the gRPC hostbridge server
 func (s *GrpcServer) Start(ctx context.Context) error {
-	if s.verbose {
-		log.Printf("Starting gRPC hostbridge server on port %d", s.port)
-	}
+	s.logger.Debug("starting gRPC hostbridge server", "port", s.port)
 
 	// Create listener
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
@@ -41,8 +39,11 @@ func (s *GrpcServer) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to listen on port %d: %w", s.port, err)
 	}
 
-	// Create gRPC server
-	s.server = grpc.NewServer()
+	// Create gRPC server; every call gets a request-scoped logger
+	s.server = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLoggingInterceptor(s.logger)),
+		grpc.ChainStreamInterceptor(streamLoggingInterceptor(s.logger)),
+	)
 
 	// Register health service
 	healthServer := health.NewServer()
@@ -50,54 +51,40 @@ func (s *GrpcServer) Start(ctx context.Context) error {
 	grpc_health_v1.RegisterHealthServer(s.server, healthServer)
 
 	// Register services
-	workspaceService := NewSimpleWorkspaceService(s.verbose)
+	workspaceService := NewSimpleWorkspaceService()
 	host.RegisterWorkspaceServiceServer(s.server, workspaceService)
 
-	windowService := NewWindowService(s.verbose)
+	windowService := NewWindowService()
 	host.RegisterWindowServiceServer(s.server, windowService)
 
-	diffService := NewDiffService(s.verbose)
+	diffService := NewDiffService()
 	host.RegisterDiffServiceServer(s.server, diffService)
 
-	envService := NewEnvService(s.verbose)
+	envService := NewEnvService()
 	host.RegisterEnvServiceServer(s.server, envService)
 
-	if s.verbose {
-		log.Printf("Registered HealthService")
-		log.Printf("Registered WorkspaceService")
-		log.Printf("Registered WindowService")
-		log.Printf("Registered DiffService")
-		log.Printf("Registered EnvService")
-	}
+	s.logger.Debug("registered services", "services", []string{"HealthService", "WorkspaceService", "WindowService", "DiffService", "EnvService"})
 
 	// Start server in goroutine
 	go func() {
-		if s.verbose {
-			log.Printf("gRPC server listening on :%d", s.port)
-		}
+		s.logger.Info("gRPC server listening", "port", s.port)
 		if err := s.server.Serve(lis); err != nil {
-			log.Printf("gRPC server error: %v", err)
+			s.logger.Error("gRPC server error", "error", err)
 		}
 	}()
 
 	// Wait for context cancellation or global shutdown signal
 	select {
 	case <-ctx.Done():
-		if s.verbose {
-			log.Println("Context cancelled, shutting down gRPC hostbridge server...")
-		}
+		s.logger.Info("context cancelled, shutting down gRPC hostbridge server")
 	case <-globalShutdownCh:
-		if s.verbose {
-			log.Println("Shutdown requested via RPC, shutting down gRPC hostbridge server...")
-		}
+		s.logger.Info("shutdown requested via RPC, shutting down gRPC hostbridge server")
 	}
 
 	// Graceful shutdown
 	s.server.GracefulStop()
 
-	if s.verbose {
-		log.Println("gRPC hostbridge server stopped")
-	}
+	s.logger.Info("gRPC hostbridge server stopped")
 
 	return nil
 }
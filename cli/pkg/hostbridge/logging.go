@@ -0,0 +1,128 @@
+package hostbridge
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// LogOptions configures the host bridge logger
+type LogOptions struct {
+	File     string        // log file path, empty to log to stderr
+	Level    slog.Level    // minimum level written
+	MaxSize  int64         // rotate after the file grows past this many bytes (0 disables)
+	MaxAge   time.Duration // rotate after the file is this old (0 disables)
+	MaxFiles int           // rotated files kept for this log, including the current one (0 keeps all)
+}
+
+type loggerKey struct{}
+
+// NewLogger creates a JSON slog logger writing to stderr or a rotating log file.
+// The returned closer flushes and closes the log file.
+func NewLogger(opts LogOptions) (*slog.Logger, io.Closer, error) {
+	var out io.WriteCloser = nopCloser{os.Stderr}
+	if opts.File != "" {
+		writer, err := NewRotatingWriter(opts.File, opts.MaxSize, opts.MaxAge, opts.MaxFiles)
+		if err != nil {
+			return nil, nil, err
+		}
+		out = writer
+	}
+
+	handler := slog.NewJSONHandler(out, &slog.HandlerOptions{Level: opts.Level})
+	return slog.New(handler).With("service", "host"), out, nil
+}
+
+// ParseLogLevel parses a --log-level value (debug, info, warn or error)
+func ParseLogLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(value))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level '%s': must be one of debug, info, warn, error", value)
+	}
+	return level, nil
+}
+
+// withLogger returns a context carrying logger
+func withLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// logFromContext returns the request-scoped logger of a gRPC call, or the default logger
+func logFromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// newRequestID returns a short random id for correlating the log lines of one call
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// unaryLoggingInterceptor gives every unary call a logger tagged with a request id and
+// the method name, and logs the call's outcome
+func unaryLoggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		callLogger := logger.With("request_id", newRequestID(), "method", info.FullMethod)
+		start := time.Now()
+
+		resp, err := handler(withLogger(ctx, callLogger), req)
+		logCallResult(callLogger, start, err)
+		return resp, err
+	}
+}
+
+// streamLoggingInterceptor is the streaming counterpart of unaryLoggingInterceptor
+func streamLoggingInterceptor(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		callLogger := logger.With("request_id", newRequestID(), "method", info.FullMethod)
+		start := time.Now()
+		callLogger.Debug("stream opened")
+
+		err := handler(srv, &loggingServerStream{ServerStream: stream, ctx: withLogger(stream.Context(), callLogger)})
+		logCallResult(callLogger, start, err)
+		return err
+	}
+}
+
+// logCallResult logs a finished call: failures at error level, successes at debug level
+func logCallResult(logger *slog.Logger, start time.Time, err error) {
+	duration := time.Since(start).Milliseconds()
+	if err != nil {
+		logger.Error("call failed", "duration_ms", duration, "error", err)
+		return
+	}
+	logger.Debug("call finished", "duration_ms", duration)
+}
+
+// loggingServerStream overrides the stream context to carry the request logger
+type loggingServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggingServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// nopCloser keeps NewLogger from closing stderr
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,146 @@
+package hostbridge
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logTimestampLayout is the timestamp embedded in log file names, shared with 'clica logs'
+const logTimestampLayout = "2006-01-02-15-04-05"
+
+var logTimestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}-\d{2}-\d{2}-\d{2}`)
+
+// RotatingWriter is an io.WriteCloser over a log file that moves on to a new file once the
+// current one grows past a size or gets too old. Rotated files are named like the original
+// with a fresh timestamp (clica-host-<timestamp>-localhost-<port>.log), so the CLI's log
+// commands treat them as newer logs of the same process.
+type RotatingWriter struct {
+	mu       sync.Mutex
+	dir      string
+	prefix   string // file name before the timestamp
+	suffix   string // file name after the timestamp
+	maxSize  int64
+	maxAge   time.Duration
+	maxFiles int
+
+	path   string
+	file   *os.File
+	size   int64
+	opened time.Time
+	files  []string // files this writer logged to, oldest first
+}
+
+// NewRotatingWriter opens path for appending. maxSize, maxAge and maxFiles of zero disable
+// size rotation, age rotation and pruning of old files respectively.
+func NewRotatingWriter(path string, maxSize int64, maxAge time.Duration, maxFiles int) (*RotatingWriter, error) {
+	dir, name := filepath.Split(path)
+	w := &RotatingWriter{
+		dir:      filepath.Clean(dir),
+		maxSize:  maxSize,
+		maxAge:   maxAge,
+		maxFiles: maxFiles,
+	}
+
+	if loc := logTimestampPattern.FindStringIndex(name); loc != nil {
+		w.prefix, w.suffix = name[:loc[0]], name[loc[1]:]
+	} else {
+		ext := filepath.Ext(name)
+		w.prefix, w.suffix = strings.TrimSuffix(name, ext)+"-", ext
+	}
+
+	if err := w.open(path); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write writes p to the current log file, rotating first if it is due
+func (w *RotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.rotationDue(len(p)) {
+		if err := w.rotate(); err != nil {
+			// Keep logging to the current file rather than losing the line
+			fmt.Fprintf(os.Stderr, "failed to rotate log file: %v\n", err)
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the current log file
+func (w *RotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+// rotationDue reports whether writing n more bytes should go to a new file
+func (w *RotatingWriter) rotationDue(n int) bool {
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(n) > w.maxSize {
+		return true
+	}
+	return w.maxAge > 0 && time.Since(w.opened) >= w.maxAge
+}
+
+// rotate switches to a new timestamped file and prunes old ones
+func (w *RotatingWriter) rotate() error {
+	path := filepath.Join(w.dir, w.prefix+time.Now().Format(logTimestampLayout)+w.suffix)
+	if path == w.path {
+		// Timestamps have one-second resolution; keep writing until the next second
+		return nil
+	}
+
+	old := w.file
+	if err := w.open(path); err != nil {
+		return err
+	}
+	old.Close()
+
+	return w.prune()
+}
+
+// open makes path the current log file
+func (w *RotatingWriter) open(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	w.path = path
+	w.file = file
+	w.size = info.Size()
+	w.opened = time.Now()
+	w.files = append(w.files, path)
+	return nil
+}
+
+// prune deletes the oldest files this writer created beyond maxFiles. Logs of earlier runs on
+// the same port are left to 'clica logs clean', and so is the file holding stdout and stderr.
+func (w *RotatingWriter) prune() error {
+	if w.maxFiles <= 0 {
+		return nil
+	}
+
+	for len(w.files) > w.maxFiles {
+		if err := os.Remove(w.files[0]); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old log file: %w", err)
+		}
+		w.files = w.files[1:]
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package hostbridge
 
 import (
 	"context"
-	"log"
 	"os"
 
 	"github.com/clica/grpc-go/clica"
@@ -12,22 +11,15 @@ import (
 // SimpleWorkspaceService implements a basic workspace service without complex dependencies
 type SimpleWorkspaceService struct {
 	host.UnimplementedWorkspaceServiceServer
-	verbose bool
 }
 
 // NewSimpleWorkspaceService creates a new SimpleWorkspaceService
-func NewSimpleWorkspaceService(verbose bool) *SimpleWorkspaceService {
-	return &SimpleWorkspaceService{
-		verbose: verbose,
-	}
+func NewSimpleWorkspaceService() *SimpleWorkspaceService {
+	return &SimpleWorkspaceService{}
 }
 
 // GetWorkspacePaths returns the workspace directory paths
 func (s *SimpleWorkspaceService) GetWorkspacePaths(ctx context.Context, req *host.GetWorkspacePathsRequest) (*host.GetWorkspacePathsResponse, error) {
-	if s.verbose {
-		log.Printf("GetWorkspacePaths called")
-	}
-
 	// Get current working directory as the workspace
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -41,9 +33,7 @@ func (s *SimpleWorkspaceService) GetWorkspacePaths(ctx context.Context, req *hos
 
 // SaveOpenDocumentIfDirty saves an open document if it has unsaved changes
 func (s *SimpleWorkspaceService) SaveOpenDocumentIfDirty(ctx context.Context, req *host.SaveOpenDocumentIfDirtyRequest) (*host.SaveOpenDocumentIfDirtyResponse, error) {
-	if s.verbose {
-		log.Printf("SaveOpenDocumentIfDirty called for path: %s", req.GetFilePath())
-	}
+	logFromContext(ctx).Debug("saving document if dirty", "path", req.GetFilePath())
 
 	// For console implementation, we'll assume the document is already saved
 	wasSaved := false
@@ -54,10 +44,6 @@ func (s *SimpleWorkspaceService) SaveOpenDocumentIfDirty(ctx context.Context, re
 
 // GetDiagnostics returns diagnostic information for a file - simplified version
 func (s *SimpleWorkspaceService) GetDiagnostics(ctx context.Context, req *host.GetDiagnosticsRequest) (*host.GetDiagnosticsResponse, error) {
-	if s.verbose {
-		log.Printf("GetDiagnostics called")
-	}
-
 	// For console implementation, return empty diagnostics
 	return &host.GetDiagnosticsResponse{
 		FileDiagnostics: []*clica.FileDiagnostics{},
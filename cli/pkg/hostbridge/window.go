@@ -3,7 +3,6 @@ package hostbridge
 import (
 	"context"
 	"fmt"
-	"log"
 
 	proto "github.com/clica/grpc-go/host"
 )
@@ -11,21 +10,16 @@ import (
 // WindowService implements the proto.WindowServiceServer interface
 type WindowService struct {
 	proto.UnimplementedWindowServiceServer
-	verbose bool
 }
 
 // NewWindowService creates a new WindowService
-func NewWindowService(verbose bool) *WindowService {
-	return &WindowService{
-		verbose: verbose,
-	}
+func NewWindowService() *WindowService {
+	return &WindowService{}
 }
 
 // ShowTextDocument opens a text document for viewing/editing
 func (s *WindowService) ShowTextDocument(ctx context.Context, req *proto.ShowTextDocumentRequest) (*proto.TextEditorInfo, error) {
-	if s.verbose {
-		log.Printf("ShowTextDocument called for path: %s", req.GetPath())
-	}
+	logFromContext(ctx).Debug("showing text document", "path", req.GetPath())
 
 	// For console implementation, we'll just log that we would open the document
 	fmt.Printf("[Clica] Would open document: %s\n", req.GetPath())
@@ -38,10 +32,6 @@ func (s *WindowService) ShowTextDocument(ctx context.Context, req *proto.ShowTex
 
 // ShowOpenDialogue shows a file open dialog
 func (s *WindowService) ShowOpenDialogue(ctx context.Context, req *proto.ShowOpenDialogueRequest) (*proto.SelectedResources, error) {
-	if s.verbose {
-		log.Printf("ShowOpenDialogue called")
-	}
-
 	// For console implementation, return empty list (user cancelled)
 	return &proto.SelectedResources{
 		Paths: []string{},
@@ -50,9 +40,7 @@ func (s *WindowService) ShowOpenDialogue(ctx context.Context, req *proto.ShowOpe
 
 // ShowMessage displays a message to the user
 func (s *WindowService) ShowMessage(ctx context.Context, req *proto.ShowMessageRequest) (*proto.SelectedResponse, error) {
-	if s.verbose {
-		log.Printf("ShowMessage called: %s", req.GetMessage())
-	}
+	logFromContext(ctx).Debug("showing message", "message", req.GetMessage())
 
 	// Display message to console
 	fmt.Printf("[Clica] %s\n", req.GetMessage())
@@ -62,9 +50,7 @@ func (s *WindowService) ShowMessage(ctx context.Context, req *proto.ShowMessageR
 
 // ShowInputBox shows an input dialog to the user
 func (s *WindowService) ShowInputBox(ctx context.Context, req *proto.ShowInputBoxRequest) (*proto.ShowInputBoxResponse, error) {
-	if s.verbose {
-		log.Printf("ShowInputBox called: %s", req.GetTitle())
-	}
+	logFromContext(ctx).Debug("showing input box", "title", req.GetTitle())
 
 	// For console implementation, return empty response (user cancelled)
 	return &proto.ShowInputBoxResponse{}, nil
@@ -72,19 +58,13 @@ func (s *WindowService) ShowInputBox(ctx context.Context, req *proto.ShowInputBo
 
 // ShowSaveDialog shows a save file dialog
 func (s *WindowService) ShowSaveDialog(ctx context.Context, req *proto.ShowSaveDialogRequest) (*proto.ShowSaveDialogResponse, error) {
-	if s.verbose {
-		log.Printf("ShowSaveDialog called")
-	}
-
 	// For console implementation, return empty response (user cancelled)
 	return &proto.ShowSaveDialogResponse{}, nil
 }
 
 // OpenFile opens a file in the editor
 func (s *WindowService) OpenFile(ctx context.Context, req *proto.OpenFileRequest) (*proto.OpenFileResponse, error) {
-	if s.verbose {
-		log.Printf("OpenFile called for path: %s", req.GetFilePath())
-	}
+	logFromContext(ctx).Debug("opening file", "path", req.GetFilePath())
 
 	// For console implementation, just log that we would open the file
 	fmt.Printf("[Clica] Would open file: %s\n", req.GetFilePath())
@@ -94,10 +74,6 @@ func (s *WindowService) OpenFile(ctx context.Context, req *proto.OpenFileRequest
 
 // GetOpenTabs returns a list of currently open tabs
 func (s *WindowService) GetOpenTabs(ctx context.Context, req *proto.GetOpenTabsRequest) (*proto.GetOpenTabsResponse, error) {
-	if s.verbose {
-		log.Printf("GetOpenTabs called")
-	}
-
 	// For console implementation, return empty list
 	return &proto.GetOpenTabsResponse{
 		Paths: []string{},
@@ -106,10 +82,6 @@ func (s *WindowService) GetOpenTabs(ctx context.Context, req *proto.GetOpenTabsR
 
 // GetVisibleTabs returns a list of currently visible tabs
 func (s *WindowService) GetVisibleTabs(ctx context.Context, req *proto.GetVisibleTabsRequest) (*proto.GetVisibleTabsResponse, error) {
-	if s.verbose {
-		log.Printf("GetVisibleTabs called")
-	}
-
 	// For console implementation, return empty list
 	return &proto.GetVisibleTabsResponse{
 		Paths: []string{},
@@ -118,10 +90,6 @@ func (s *WindowService) GetVisibleTabs(ctx context.Context, req *proto.GetVisibl
 
 // GetActiveEditor returns information about the current active editor
 func (s *WindowService) GetActiveEditor(ctx context.Context, req *proto.GetActiveEditorRequest) (*proto.GetActiveEditorResponse, error) {
-	if s.verbose {
-		log.Printf("GetActiveEditor called")
-	}
-
 	// Return empty response (no active file)
 	return &proto.GetActiveEditorResponse{
 		FilePath: nil,